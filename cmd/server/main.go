@@ -14,7 +14,10 @@ import (
 
 	"homecloud--dbmanager-service/config"
 	"homecloud--dbmanager-service/internal/logger"
+	"homecloud--dbmanager-service/internal/models"
 	"homecloud--dbmanager-service/internal/repository"
+	"homecloud--dbmanager-service/internal/sweeper"
+	"homecloud--dbmanager-service/internal/totp"
 	grpcServer "homecloud--dbmanager-service/internal/transport/grpc/dbManagerServer"
 	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
 
@@ -49,6 +52,12 @@ func main() {
 
 	repo := repository.NewDBRepository(db)
 
+	// Фоновая очистка истёкших прав доступа
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	defer stopSweep()
+	permSweeper := &sweeper.PermissionSweeper{Repo: repo, Logger: logr, Interval: cfg.Permissions.SweepInterval}
+	go permSweeper.Run(sweepCtx)
+
 	addr := fmt.Sprintf("%s:%d", cfg.GRPC.Host, cfg.GRPC.Port)
 	logr.Info(context.Background(), "Starting gRPC server", zap.String("address", addr))
 	lis, err := net.Listen("tcp", addr)
@@ -58,7 +67,29 @@ func main() {
 	}
 
 	s := grpc.NewServer()
-	protos.RegisterDBServiceServer(s, &grpcServer.Server{Repo: repo, Logger: logr})
+	lockout := models.LockoutPolicy{
+		Threshold:       cfg.Lockout.Threshold,
+		LockDuration:    cfg.Lockout.LockDuration,
+		MaxLockDuration: cfg.Lockout.MaxLockDuration,
+		ResetWindow:     cfg.Lockout.ResetWindow,
+	}
+	var twoFactor *totp.Cipher
+	if cfg.TwoFactor.EncryptionKey != "" {
+		twoFactor, err = totp.NewCipher(cfg.TwoFactor.EncryptionKey)
+		if err != nil {
+			logr.Error(context.Background(), "invalid two_factor.encryption_key", zap.Error(err))
+			os.Exit(1)
+		}
+	}
+	protos.RegisterDBServiceServer(s, &grpcServer.Server{
+		Repo:                 repo,
+		Logger:               logr,
+		Lockout:              lockout,
+		DeletionGracePeriod:  cfg.Accounts.DeletionGracePeriod,
+		PasswordHistoryDepth: cfg.Passwords.HistoryDepth,
+		TwoFactor:            twoFactor,
+		TwoFactorIssuer:      cfg.TwoFactor.Issuer,
+	})
 
 	// Graceful shutdown
 	go func() {
@@ -72,6 +103,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logr.Info(context.Background(), "Shutting down server...")
+	stopSweep()
 	s.GracefulStop()
 	logr.Info(context.Background(), "Server stopped")
 }
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"gopkg.in/yaml.v3"
 	"os"
+	"time"
 )
 
 type Config struct {
@@ -18,6 +20,27 @@ type Config struct {
 		Host string `yaml:"host"`
 		Port int    `yaml:"port"`
 	} `yaml:"grpc"`
+	Permissions struct {
+		// Как часто удалять права с истёкшим сроком (по умолчанию 1m)
+		SweepInterval time.Duration `yaml:"sweep_interval"`
+	} `yaml:"permissions"`
+	Lockout struct {
+		Threshold       int           `yaml:"threshold"`         // неудачных входов до блокировки, 0 - не блокировать
+		LockDuration    time.Duration `yaml:"lock_duration"`     // первая блокировка, далее удваивается
+		MaxLockDuration time.Duration `yaml:"max_lock_duration"` // 0 - без ограничения
+		ResetWindow     time.Duration `yaml:"reset_window"`      // сброс счётчика после паузы в неудачах
+	} `yaml:"lockout"`
+	Passwords struct {
+		HistoryDepth int `yaml:"history_depth"` // сколько последних паролей хранить, 0 - повторное использование не проверяется
+	} `yaml:"passwords"`
+	Accounts struct {
+		// Срок между деактивацией и стиранием данных (по умолчанию 720h)
+		DeletionGracePeriod time.Duration `yaml:"deletion_grace_period"`
+	} `yaml:"accounts"`
+	TwoFactor struct {
+		EncryptionKey string `yaml:"encryption_key"` // base64, 32 байта (AES-256); пусто - 2FA отключена
+		Issuer        string `yaml:"issuer"`         // отображается в приложении-аутентификаторе
+	} `yaml:"two_factor"`
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -30,5 +53,19 @@ func LoadConfig(path string) (*Config, error) {
 	if err := yaml.NewDecoder(f).Decode(&cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
+}
+
+// validate отклоняет настройки, которые молча отключили бы защиту
+func (c *Config) validate() error {
+	if c.Lockout.Threshold > 0 && c.Lockout.LockDuration <= 0 {
+		return errors.New("lockout.lock_duration must be positive when lockout.threshold is set")
+	}
+	if c.Passwords.HistoryDepth < 0 {
+		return errors.New("passwords.history_depth must not be negative")
+	}
+	return nil
 } 
\ No newline at end of file
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		threshold    int
+		lockDuration time.Duration
+		historyDepth int
+		wantErr      bool
+	}{
+		{name: "lockout disabled", threshold: 0, lockDuration: 0},
+		{name: "lockout enabled", threshold: 5, lockDuration: time.Minute},
+		{name: "threshold without lock duration", threshold: 5, lockDuration: 0, wantErr: true},
+		{name: "negative lock duration", threshold: 5, lockDuration: -time.Minute, wantErr: true},
+		{name: "password history disabled", historyDepth: 0},
+		{name: "password history enabled", historyDepth: 5},
+		{name: "negative password history", historyDepth: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			cfg.Lockout.Threshold = tt.threshold
+			cfg.Lockout.LockDuration = tt.lockDuration
+			cfg.Passwords.HistoryDepth = tt.historyDepth
+			if err := cfg.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,4 +6,41 @@ var (
 	ErrUserNotFound   = errors.New("user not found")
 	ErrEmailExists    = errors.New("email already exists")
 	ErrUsernameExists = errors.New("username already exists")
+	ErrInvalidCursor  = errors.New("invalid page cursor")
+	ErrInvalidField   = errors.New("field cannot be updated")
+	ErrEmptyMask      = errors.New("update mask is empty")
+	ErrInvalidSort    = errors.New("unknown sort field")
+
+	ErrDeletionNotScheduled = errors.New("account deletion is not scheduled")
+	ErrErasureNotDue        = errors.New("account erasure grace period has not ended")
+
+	ErrFileNotFound  = errors.New("file not found")
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+	ErrRevisionNotFound    = errors.New("revision not found")
+	ErrRevisionLabelExists = errors.New("revision label already used for this file")
+
+	ErrBlobNotFound = errors.New("storage blob not found")
+
+	ErrGroupNotFound       = errors.New("group not found")
+	ErrGroupMemberNotFound = errors.New("group member not found")
+	ErrGroupCycle          = errors.New("group membership would create a cycle")
+	ErrLastGroupOwner      = errors.New("group must keep at least one owner")
+
+	ErrShareLinkNotFound = errors.New("share link not found")
+
+	ErrPermissionNotFound = errors.New("permission not found")
+	ErrUnknownRole        = errors.New("unknown permission role")
+
+	ErrSessionNotFound = errors.New("session not found")
+
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+	ErrWebAuthnCredentialNotFound = errors.New("webauthn credential not found")
+	ErrWebAuthnCredentialExists   = errors.New("webauthn credential already registered")
+
+	ErrIdentityNotFound = errors.New("external identity not found")
+	ErrIdentityExists   = errors.New("external identity already linked")
+	ErrLastLoginMethod  = errors.New("cannot remove the last login method")
 )
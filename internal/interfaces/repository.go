@@ -11,13 +11,27 @@ type DBRepository interface {
 	CreateUser(ctx context.Context, user *models.User) (string, error)
 	GetUserByID(ctx context.Context, id string) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
-	UpdateUser(ctx context.Context, user *models.User) error
-	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	ListUsers(ctx context.Context, filter models.UserFilter, sort models.UserSort, pageSize int, cursor string) (*models.UserPage, error)
+	UpdateUser(ctx context.Context, user *models.User, fields []string, audit models.AuditInfo) error
+	UpdatePassword(ctx context.Context, id, passwordHash string, historyDepth int, audit models.AuditInfo) error
+	GetPasswordHistory(ctx context.Context, id string, limit int) ([]*models.PasswordHistoryEntry, error)
+	GetPreferences(ctx context.Context, userID string) (map[string]interface{}, error)
+	SetPreferences(ctx context.Context, userID string, prefs map[string]interface{}) (map[string]interface{}, error)
+	PatchPreferences(ctx context.Context, userID string, patch map[string]interface{}) (map[string]interface{}, error)
 	UpdateUsername(ctx context.Context, id, username string) error
-	UpdateEmailVerification(ctx context.Context, id string, isVerified bool) error
+	UpdateEmailVerification(ctx context.Context, id string, isVerified bool, audit models.AuditInfo) error
 	UpdateLastLogin(ctx context.Context, id string, lastLogin time.Time) error
 	UpdateFailedLoginAttempts(ctx context.Context, id string, attempts int) error
-	UpdateLockedUntil(ctx context.Context, id string, lockedUntil time.Time) error
+	UpdateLockedUntil(ctx context.Context, id string, lockedUntil *time.Time, audit models.AuditInfo) error
+	RecordFailedLogin(ctx context.Context, id string, policy models.LockoutPolicy, audit models.AuditInfo) (*models.LoginState, error)
+	RecordSuccessfulLogin(ctx context.Context, id string, audit models.AuditInfo) (*models.LoginState, error)
+	ListSecurityEvents(ctx context.Context, userID string, pageSize int, cursor string) (*models.SecurityEventPage, error)
+	IssueToken(ctx context.Context, token *models.UserToken) (*models.UserToken, string, error)
+	ConsumeToken(ctx context.Context, secret, purpose, newPasswordHash string, historyDepth int, audit models.AuditInfo) (*models.TokenConsumption, error)
+	DeactivateUser(ctx context.Context, id string, gracePeriod time.Duration, audit models.AuditInfo) (*models.AccountDeletion, error)
+	CancelUserDeletion(ctx context.Context, id string, audit models.AuditInfo) error
+	ListPendingErasures(ctx context.Context, limit int) ([]*models.AccountDeletion, error)
+	EraseUser(ctx context.Context, id string) (*models.ErasureResult, error)
 	UpdateStorageUsage(ctx context.Context, id string, usedSpace int64) error
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
 	CheckUsernameExists(ctx context.Context, username string) (bool, error)
@@ -26,7 +40,7 @@ type DBRepository interface {
 	CreateFile(ctx context.Context, file *models.File) (string, error)
 	GetFileByID(ctx context.Context, id string) (*models.File, error)
 	GetFileByPath(ctx context.Context, ownerID, path string) (*models.File, error)
-	UpdateFile(ctx context.Context, file *models.File) error
+	UpdateFile(ctx context.Context, file *models.File, fields []string) error
 	DeleteFile(ctx context.Context, id string) error
 	SoftDeleteFile(ctx context.Context, id string) error
 	RestoreFile(ctx context.Context, id string) error
@@ -45,13 +59,21 @@ type DBRepository interface {
 	GetRevisions(ctx context.Context, fileID string) ([]*models.FileRevision, error)
 	GetRevision(ctx context.Context, fileID string, revisionID int64) (*models.FileRevision, error)
 	DeleteRevision(ctx context.Context, id string) error
+	SetRevisionLabel(ctx context.Context, fileID string, revisionID int64, label, description string) (*models.FileRevision, error)
+	ClearRevisionLabel(ctx context.Context, fileID string, revisionID int64) error
+	GetRevisionByLabel(ctx context.Context, fileID, label string) (*models.FileRevision, error)
+	PruneRevisions(ctx context.Context, fileID string, keepLatest int) ([]*models.FileRevision, error)
 
 	// File permission operations
 	CreatePermission(ctx context.Context, permission *models.FilePermission) (string, error)
 	GetPermissions(ctx context.Context, fileID string) ([]*models.FilePermission, error)
+	GetPermission(ctx context.Context, id string) (*models.FilePermission, error)
 	UpdatePermission(ctx context.Context, permission *models.FilePermission) error
 	DeletePermission(ctx context.Context, id string) error
 	CheckPermission(ctx context.Context, fileID, userID, requiredRole string) (bool, error)
+	CheckPermissions(ctx context.Context, fileIDs []string, userID, requiredRole string) (map[string]bool, error)
+	GetEffectivePermission(ctx context.Context, fileID, userID, linkID string) (*models.EffectivePermission, error)
+	SweepExpiredPermissions(ctx context.Context) ([]*models.FilePermission, error)
 
 	// File metadata operations
 	UpdateFileMetadata(ctx context.Context, fileID, metadata string) error
@@ -63,8 +85,63 @@ type DBRepository interface {
 	MoveFile(ctx context.Context, fileID, newParentID string) error
 	CopyFile(ctx context.Context, fileID, newParentID, newName string) (*models.File, error)
 	RenameFile(ctx context.Context, fileID, newName string) error
+	TransferOwnership(ctx context.Context, fileID, newOwnerID, keepPreviousAsRole string) (*models.OwnershipTransfer, error)
 
 	// File integrity operations
 	VerifyFileIntegrity(ctx context.Context, id string) (bool, error)
 	CalculateFileChecksums(ctx context.Context, id string) (map[string]string, error)
+
+	// Content deduplication operations
+	FindBySHA256(ctx context.Context, ownerID, sha256 string) (*models.StorageBlob, error)
+	FindDuplicates(ctx context.Context, ownerID string) ([]*models.DuplicateGroup, error)
+	GetStorageBlob(ctx context.Context, storagePath string) (*models.StorageBlob, error)
+	ListUnreferencedBlobs(ctx context.Context, limit int) ([]*models.StorageBlob, error)
+	ReleaseBlob(ctx context.Context, storagePath string) (bool, error)
+
+	// Share link operations
+	CreateShareLink(ctx context.Context, link *models.ShareLink) (string, string, error)
+	ListShareLinks(ctx context.Context, fileID string) ([]*models.ShareLink, error)
+	RevokeShareLink(ctx context.Context, id string) error
+	ResolveShareLink(ctx context.Context, token string, passwordVerified bool) (*models.ShareLinkResolution, error)
+
+	// Two-factor authentication operations
+	SaveTwoFactorSecret(ctx context.Context, userID string, encryptedSecret []byte) error
+	GetTwoFactor(ctx context.Context, userID string) (*models.TwoFactor, error)
+	ListTwoFactors(ctx context.Context, userIDs []string) (map[string]*models.TwoFactor, error)
+	EnableTwoFactor(ctx context.Context, userID string, step int64) ([]string, error)
+	UseTwoFactorStep(ctx context.Context, userID string, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, userID, code string) (bool, int, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID string, step int64) ([]string, error)
+
+	// WebAuthn credential operations
+	RegisterWebAuthnCredential(ctx context.Context, cred *models.WebAuthnCredential) (*models.WebAuthnCredential, error)
+	GetWebAuthnCredential(ctx context.Context, credentialID []byte) (*models.WebAuthnCredential, error)
+	ListWebAuthnCredentials(ctx context.Context, userID string) ([]*models.WebAuthnCredential, error)
+	RenameWebAuthnCredential(ctx context.Context, id, name string) (*models.WebAuthnCredential, error)
+	DeleteWebAuthnCredential(ctx context.Context, id string) error
+	UpdateWebAuthnSignCount(ctx context.Context, credentialID []byte, signCount uint32) (*models.SignCountUpdate, error)
+
+	// External identity operations
+	LinkIdentity(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error)
+	UnlinkIdentity(ctx context.Context, userID, provider, subject string) error
+	ListIdentities(ctx context.Context, userID string) ([]*models.UserIdentity, error)
+	GetUserByIdentity(ctx context.Context, provider, subject string) (*models.User, error)
+
+	// Session operations
+	CreateSession(ctx context.Context, session *models.Session) (*models.Session, string, error)
+	RotateSession(ctx context.Context, refreshToken string, ipAddress, userAgent *string) (*models.SessionRotation, error)
+	ListSessions(ctx context.Context, userID string) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, id string) error
+	RevokeAllSessions(ctx context.Context, userID, exceptSessionID string) (int, error)
+
+	// Group operations
+	CreateGroup(ctx context.Context, group *models.Group) (string, error)
+	GetGroup(ctx context.Context, id string) (*models.Group, error)
+	UpdateGroup(ctx context.Context, group *models.Group) error
+	DeleteGroup(ctx context.Context, id string) error
+	ListGroups(ctx context.Context, userID string) ([]*models.Group, error)
+	AddGroupMember(ctx context.Context, member *models.GroupMember) error
+	RemoveGroupMember(ctx context.Context, groupID, memberID, memberType string) error
+	ListGroupMembers(ctx context.Context, groupID string) ([]*models.GroupMember, error)
+	GetGroupMemberRole(ctx context.Context, groupID, memberID, memberType string) (string, error)
 }
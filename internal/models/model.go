@@ -19,6 +19,21 @@ type User struct {
 	LastLogin           *time.Time
 }
 
+// LockoutPolicy - политика блокировки аккаунта после неудачных попыток входа
+type LockoutPolicy struct {
+	Threshold       int           // после стольких неудач подряд аккаунт блокируется, 0 - не блокировать
+	LockDuration    time.Duration // первая блокировка, каждая следующая вдвое дольше
+	MaxLockDuration time.Duration // верхняя граница блокировки, 0 - без ограничения
+	ResetWindow     time.Duration // счётчик сбрасывается, если с последней неудачи прошло больше, 0 - не сбрасывать
+}
+
+// LoginState - состояние счётчика неудачных входов и блокировки
+type LoginState struct {
+	FailedLoginAttempts int32
+	LockedUntil         *time.Time
+	Locked              bool
+}
+
 // File представляет файл в системе
 type File struct {
 	ID             string
@@ -50,15 +65,18 @@ type File struct {
 
 // FileRevision представляет ревизию файла
 type FileRevision struct {
-	ID          string
-	FileID      string
-	RevisionID  int64
-	MD5Checksum *string
-	Size        int64
-	CreatedAt   time.Time
-	StoragePath string
-	MimeType    *string
-	UserID      *string
+	ID             string
+	FileID         string
+	RevisionID     int64
+	MD5Checksum    *string
+	SHA256Checksum *string
+	Size           int64
+	CreatedAt      time.Time
+	StoragePath    string
+	MimeType       *string
+	UserID         *string
+	Label          *string
+	Description    *string
 }
 
 // FilePermission представляет права доступа к файлу
@@ -67,9 +85,99 @@ type FilePermission struct {
 	FileID      string
 	GranteeID   *string
 	GranteeType string
+	Domain      *string // только для GranteeType = DOMAIN
 	Role        string
 	AllowShare  bool
 	CreatedAt   time.Time
+	ExpiresAt   *time.Time // nil - бессрочно
+	// Inherited - право получено от папки-предка InheritedFrom, а не выдано на сам файл
+	Inherited     bool
+	InheritedFrom *string
+}
+
+// Роли доступа к файлам
+const (
+	RoleReader    = "READER"
+	RoleCommenter = "COMMENTER"
+	RoleWriter    = "WRITER"
+	RoleFileOwner = "FILE_OWNER"
+	RoleOrganizer = "ORGANIZER"
+	RoleOwner     = "OWNER"
+)
+
+// PermissionRoles - иерархия ролей доступа от самой слабой к самой сильной.
+// Единственная таблица рангов ролей: по ней работают и Go-код, и SQL-запросы.
+var PermissionRoles = []string{RoleReader, RoleCommenter, RoleWriter, RoleFileOwner, RoleOrganizer, RoleOwner}
+
+// RoleRank возвращает ранг роли (чем больше, тем сильнее), для неизвестной роли - 0
+func RoleRank(role string) int {
+	for i, r := range PermissionRoles {
+		if r == role {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// RolesAtLeast возвращает роли не слабее role, для неизвестной роли - nil
+func RolesAtLeast(role string) []string {
+	rank := RoleRank(role)
+	if rank == 0 {
+		return nil
+	}
+	return PermissionRoles[rank-1:]
+}
+
+// Типы получателей прав доступа
+const (
+	GranteeUser   = "USER"
+	GranteeGroup  = "GROUP"
+	GranteeDomain = "DOMAIN"
+	GranteeAnyone = "ANYONE"
+)
+
+// Типы источников доступа в EffectivePermission
+const (
+	PermissionSourceOwner  = "OWNER"
+	PermissionSourceUser   = "USER"
+	PermissionSourceGroup  = "GROUP"
+	PermissionSourceDomain = "DOMAIN"
+	PermissionSourceAnyone = "ANYONE"
+	PermissionSourceLink   = "LINK"
+)
+
+// PermissionSource - одно основание, по которому у пользователя есть доступ к файлу
+type PermissionSource struct {
+	Type         string // см. PermissionSource* константы
+	Role         string
+	AllowShare   bool
+	PermissionID *string // ID права или ссылки (для LINK), у OWNER пусто
+	FileID       string  // файл или папка, на которую выдан доступ
+	FileName     string
+	Inherited    bool    // доступ выдан на папку-предка
+	GroupID      *string // только для GROUP
+	GroupName    *string
+	Domain       *string // только для DOMAIN
+	ExpiresAt    *time.Time
+}
+
+// EffectivePermission - итоговый доступ пользователя к файлу с объяснением.
+// Role пустая, если доступа нет. Sources упорядочены от самого сильного.
+type EffectivePermission struct {
+	FileID     string
+	UserID     string
+	Role       string
+	AllowShare bool
+	Sources    []*PermissionSource
+}
+
+// OwnershipTransfer - результат передачи владения файлом или папкой с содержимым
+type OwnershipTransfer struct {
+	FileID           string
+	PreviousOwnerID  string
+	NewOwnerID       string
+	FilesTransferred int
+	BytesTransferred int64
 }
 
 // FileMetadata представляет метаданные файла
@@ -77,3 +185,305 @@ type FileMetadata struct {
 	FileID   string
 	Metadata string
 }
+
+// StorageBlob представляет физический объект хранилища и число ссылок на него
+type StorageBlob struct {
+	StoragePath    string
+	SHA256Checksum *string
+	Size           int64
+	RefCount       int64
+	CreatedAt      time.Time
+	ReleasedAt     *time.Time
+}
+
+// DuplicateGroup - группа файлов пользователя с одинаковым содержимым
+type DuplicateGroup struct {
+	SHA256Checksum string
+	Size           int64
+	Files          []*File
+	WastedBytes    int64
+}
+
+// Group представляет группу пользователей
+type Group struct {
+	ID          string
+	Name        string
+	Description *string
+	CreatedBy   string // пусто, если аккаунт создателя стёрт
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// GroupMember - участник группы: пользователь (USER) или вложенная группа (GROUP)
+type GroupMember struct {
+	GroupID    string
+	MemberID   string
+	MemberType string // см. GroupMember* константы
+	Role       string // см. GroupRole* константы
+	CreatedAt  time.Time
+}
+
+// Типы участников группы
+const (
+	GroupMemberUser  = "USER"
+	GroupMemberGroup = "GROUP"
+)
+
+// Роли в группе: OWNER и ADMIN управляют группой, удалить её может только OWNER
+const (
+	GroupRoleOwner  = "OWNER"
+	GroupRoleAdmin  = "ADMIN"
+	GroupRoleMember = "MEMBER"
+)
+
+// ShareLink - ссылка общего доступа к файлу. Токен хранится только в виде хеша.
+type ShareLink struct {
+	ID           string
+	FileID       string
+	Role         string
+	CreatedBy    string
+	CreatedAt    time.Time
+	ExpiresAt    *time.Time
+	PasswordHash *string
+	MaxUses      *int32
+	UseCount     int32
+	LastUsedAt   *time.Time
+	Revoked      bool
+	RevokedAt    *time.Time
+}
+
+// Причины, по которым ссылка общего доступа не может быть использована
+const (
+	ShareLinkValid            = "VALID"
+	ShareLinkNotFound         = "NOT_FOUND"
+	ShareLinkRevoked          = "REVOKED"
+	ShareLinkExpired          = "EXPIRED"
+	ShareLinkUseLimitReached  = "USE_LIMIT_REACHED"
+	ShareLinkPasswordRequired = "PASSWORD_REQUIRED"
+	ShareLinkFileUnavailable  = "FILE_UNAVAILABLE"
+)
+
+// ShareLinkResolution - результат проверки ссылки: Status и сама ссылка, если она найдена
+type ShareLinkResolution struct {
+	Status string
+	Link   *ShareLink
+}
+
+// Session - вход пользователя с устройства. Refresh-токен хранится только в виде хеша.
+// Ротации одного входа образуют семью FamilyID.
+type Session struct {
+	ID            string
+	FamilyID      string
+	UserID        string
+	DeviceName    *string
+	IPAddress     *string
+	UserAgent     *string
+	CreatedAt     time.Time
+	LastUsedAt    time.Time
+	ExpiresAt     time.Time
+	RevokedAt     *time.Time
+	RevokedReason *string
+}
+
+// Причины отзыва сессии
+const (
+	SessionRevokedRotated       = "ROTATED"
+	SessionRevokedByUser        = "REVOKED"
+	SessionRevokedReuseDetected = "REUSE_DETECTED"
+)
+
+// Результаты RotateSession
+const (
+	SessionRotated       = "ROTATED"
+	SessionNotFound      = "NOT_FOUND"
+	SessionExpired       = "EXPIRED"
+	SessionRevoked       = "REVOKED"
+	SessionReuseDetected = "REUSE_DETECTED"
+)
+
+// SessionRotation - результат ротации: Status, новая сессия и её refresh-токен (только при SessionRotated)
+type SessionRotation struct {
+	Status       string
+	Session      *Session
+	RefreshToken string
+}
+
+// Назначения одноразовых токенов пользователя
+const (
+	TokenPurposeEmailVerification = "EMAIL_VERIFICATION"
+	TokenPurposePasswordReset     = "PASSWORD_RESET"
+	TokenPurposeEmailChange       = "EMAIL_CHANGE"
+)
+
+// UserToken - одноразовый токен пользователя. Хранится только хеш токена.
+type UserToken struct {
+	ID        string
+	UserID    string
+	Purpose   string
+	NewEmail  *string // только для TokenPurposeEmailChange
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// Результаты ConsumeToken
+const (
+	TokenConsumed    = "CONSUMED"
+	TokenNotFound    = "NOT_FOUND"
+	TokenExpired     = "EXPIRED"
+	TokenAlreadyUsed = "ALREADY_USED"
+)
+
+// TokenConsumption - результат ConsumeToken: Status и сам токен, если он найден
+type TokenConsumption struct {
+	Status string
+	Token  *UserToken
+}
+
+// TwoFactor - настройки TOTP пользователя. Секрет хранится зашифрованным.
+type TwoFactor struct {
+	UserID                 string
+	EncryptedSecret        []byte
+	Enabled                bool
+	EnrolledAt             time.Time
+	EnabledAt              *time.Time
+	LastUsedStep           *int64
+	RecoveryCodesRemaining int
+}
+
+// WebAuthnCredential - ключ доступа (passkey) пользователя
+type WebAuthnCredential struct {
+	ID           string
+	UserID       string
+	CredentialID []byte
+	PublicKey    []byte
+	SignCount    uint32
+	Transports   []string
+	Name         string
+	CreatedAt    time.Time
+	LastUsedAt   *time.Time
+}
+
+// Результаты UpdateWebAuthnSignCount
+const (
+	SignCountUpdated       = "UPDATED"
+	SignCountNotFound      = "NOT_FOUND"
+	SignCountCloneDetected = "CLONE_DETECTED"
+)
+
+// SignCountUpdate - результат проверки счётчика подписей
+type SignCountUpdate struct {
+	Status     string
+	Credential *WebAuthnCredential
+}
+
+// UserIdentity - привязанная к пользователю учётная запись внешнего провайдера
+type UserIdentity struct {
+	Provider string
+	Subject  string
+	UserID   string
+	Email    *string
+	LinkedAt time.Time
+}
+
+// AccountDeletion - запланированное удаление аккаунта
+type AccountDeletion struct {
+	UserID        string
+	DeactivatedAt time.Time
+	EraseAfter    time.Time
+}
+
+// ErasureResult - итог стирания аккаунта. StoragePaths - объекты хранилища,
+// на которые после удаления не осталось ссылок; их нужно стереть с диска.
+type ErasureResult struct {
+	UserID             string
+	FilesDeleted       int
+	RevisionsDeleted   int
+	PermissionsDeleted int
+	SessionsDeleted    int
+	GroupsTransferred  int // группы, где пользователь был единственным владельцем и владение перешло другому
+	GroupsDeleted      int // такие же группы без других участников-пользователей
+	StoragePaths       []string
+}
+
+// UserFilter - фильтры ListUsers. Пустые поля не ограничивают выборку.
+type UserFilter struct {
+	Role            string
+	IsActive        *bool
+	IsEmailVerified *bool
+	Locked          *bool // заблокирован прямо сейчас
+	QuotaExceeded   *bool
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	LastLoginAfter  *time.Time
+	LastLoginBefore *time.Time
+	Search          string // подстрока email или username, без учёта регистра
+}
+
+// Поля сортировки ListUsers
+const (
+	UserSortCreatedAt   = "CREATED_AT"
+	UserSortLastLoginAt = "LAST_LOGIN_AT"
+	UserSortEmail       = "EMAIL"
+	UserSortUsername    = "USERNAME"
+	UserSortUsedSpace   = "USED_SPACE"
+)
+
+type UserSort struct {
+	Field      string
+	Descending bool
+}
+
+// UserPage - страница ListUsers. NextCursor пуст на последней странице.
+type UserPage struct {
+	Users      []*User
+	NextCursor string
+	Total      int64
+}
+
+// PasswordHistoryEntry - один из прошлых хешей пароля, первым идёт текущий
+type PasswordHistoryEntry struct {
+	PasswordHash string
+	CreatedAt    time.Time
+}
+
+// Типы событий безопасности
+const (
+	SecurityEventPasswordChanged    = "PASSWORD_CHANGED"
+	SecurityEventEmailVerified      = "EMAIL_VERIFIED"
+	SecurityEventEmailUnverified    = "EMAIL_UNVERIFIED"
+	SecurityEventAccountLocked      = "ACCOUNT_LOCKED"
+	SecurityEventAccountUnlocked    = "ACCOUNT_UNLOCKED"
+	SecurityEventRoleChanged        = "ROLE_CHANGED"
+	SecurityEventAccountActivated   = "ACCOUNT_ACTIVATED"
+	SecurityEventAccountDeactivated = "ACCOUNT_DEACTIVATED"
+	SecurityEventLoginSucceeded     = "LOGIN_SUCCEEDED"
+	SecurityEventLoginFailed        = "LOGIN_FAILED"
+	SecurityEventLoginBlocked       = "LOGIN_BLOCKED"  // вход отклонён: аккаунт заблокирован
+	SecurityEventAccountErased      = "ACCOUNT_ERASED" // данные стёрты, прошлые события обезличены
+)
+
+// AuditInfo - кто и откуда выполнил действие, из метаданных запроса
+type AuditInfo struct {
+	ActorID   *string
+	IPAddress *string
+	UserAgent *string
+}
+
+// SecurityEvent - запись журнала событий безопасности
+type SecurityEvent struct {
+	ID        int64
+	UserID    string
+	EventType string
+	ActorID   *string
+	IPAddress *string
+	UserAgent *string
+	Details   map[string]interface{}
+	CreatedAt time.Time
+}
+
+// SecurityEventPage - страница журнала, от новых событий к старым
+type SecurityEventPage struct {
+	Events     []*SecurityEvent
+	NextCursor string
+}
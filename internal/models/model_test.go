@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleRank(t *testing.T) {
+	tests := []struct {
+		role string
+		want int
+	}{
+		{RoleReader, 1},
+		{RoleCommenter, 2},
+		{RoleWriter, 3},
+		{RoleFileOwner, 4},
+		{RoleOrganizer, 5},
+		{RoleOwner, 6},
+		{"", 0},
+		{"reader", 0},
+		{"ADMIN", 0},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, RoleRank(tt.role), "role %q", tt.role)
+	}
+}
+
+func TestRoleRankOrder(t *testing.T) {
+	for i := 1; i < len(PermissionRoles); i++ {
+		require.Less(t, RoleRank(PermissionRoles[i-1]), RoleRank(PermissionRoles[i]))
+	}
+}
+
+func TestRolesAtLeast(t *testing.T) {
+	tests := []struct {
+		role string
+		want []string
+	}{
+		{RoleReader, PermissionRoles},
+		{RoleWriter, []string{RoleWriter, RoleFileOwner, RoleOrganizer, RoleOwner}},
+		{RoleOwner, []string{RoleOwner}},
+		{"UNKNOWN", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, RolesAtLeast(tt.role), "role %q", tt.role)
+	}
+}
@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// DeactivateUser отключает аккаунт, отзывает его сессии и планирует стирание данных
+// через gracePeriod. Повторная деактивация не сдвигает уже назначенный срок.
+func (r *dbRepository) DeactivateUser(ctx context.Context, id string, gracePeriod time.Duration, audit models.AuditInfo) (*models.AccountDeletion, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	deletion := &models.AccountDeletion{}
+	var wasActive bool
+	err = tx.QueryRowContext(ctx, `WITH prev AS (
+			SELECT id, is_active FROM homecloud.users WHERE id=$1 FOR UPDATE
+		)
+		UPDATE homecloud.users u
+		SET is_active=false,
+			deactivated_at=COALESCE(u.deactivated_at, NOW()),
+			erase_after=COALESCE(u.erase_after, NOW() + $2::float8 * interval '1 second')
+		FROM prev WHERE u.id = prev.id
+		RETURNING u.id, u.deactivated_at, u.erase_after, prev.is_active`, id, gracePeriod.Seconds()).Scan(
+		&deletion.UserID, &deletion.DeactivatedAt, &deletion.EraseAfter, &wasActive,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE homecloud.sessions SET revoked_at=NOW(), revoked_reason=$2
+		WHERE user_id=$1 AND revoked_at IS NULL`, id, models.SessionRevokedByUser)
+	if err != nil {
+		return nil, err
+	}
+	if wasActive {
+		details := map[string]interface{}{"erase_after": deletion.EraseAfter.UTC().Format(time.RFC3339)}
+		if err := recordSecurityEvent(ctx, tx, id, models.SecurityEventAccountDeactivated, audit, details); err != nil {
+			return nil, err
+		}
+	}
+	return deletion, tx.Commit()
+}
+
+// CancelUserDeletion восстанавливает аккаунт, пока данные ещё не стёрты
+func (r *dbRepository) CancelUserDeletion(ctx context.Context, id string, audit models.AuditInfo) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var scheduled, wasActive bool
+	err = tx.QueryRowContext(ctx, `SELECT erase_after IS NOT NULL, is_active FROM homecloud.users WHERE id=$1 FOR UPDATE`, id).
+		Scan(&scheduled, &wasActive)
+	if err == sql.ErrNoRows {
+		return errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !scheduled {
+		return errdefs.ErrDeletionNotScheduled
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE homecloud.users SET is_active=true, deactivated_at=NULL, erase_after=NULL WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if !wasActive {
+		if err := recordSecurityEvent(ctx, tx, id, models.SecurityEventAccountActivated, audit, nil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListPendingErasures возвращает аккаунты, у которых истёк льготный срок
+func (r *dbRepository) ListPendingErasures(ctx context.Context, limit int) ([]*models.AccountDeletion, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, deactivated_at, erase_after FROM homecloud.users
+		WHERE erase_after <= NOW() AND NOT is_active
+		ORDER BY erase_after LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deletions []*models.AccountDeletion
+	for rows.Next() {
+		deletion := &models.AccountDeletion{}
+		if err := rows.Scan(&deletion.UserID, &deletion.DeactivatedAt, &deletion.EraseAfter); err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, deletion)
+	}
+	return deletions, rows.Err()
+}
+
+// EraseUser необратимо удаляет аккаунт, у которого истёк льготный срок: файлы и их ревизии,
+// права доступа, ссылки, сессии и членство в группах. Ревизии чужих файлов, загруженные
+// пользователем, остаются без автора. Чужие файлы из папок пользователя переносятся в корень.
+// Группы, где пользователь был единственным владельцем, передаются другому участнику или удаляются.
+// Журнал событий безопасности сохраняется обезличенным и завершается событием ACCOUNT_ERASED.
+// Возвращает пути объектов хранилища, на которые больше нет ссылок.
+func (r *dbRepository) EraseUser(ctx context.Context, id string) (*models.ErasureResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var due bool
+	err = tx.QueryRowContext(ctx, `SELECT NOT COALESCE(is_active, true) AND erase_after IS NOT NULL AND erase_after <= NOW()
+		FROM homecloud.users WHERE id=$1 FOR UPDATE`, id).Scan(&due)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !due {
+		return nil, errdefs.ErrErasureNotDue
+	}
+
+	result := &models.ErasureResult{UserID: id}
+	exec := func(query string, args ...interface{}) (int, error) {
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+		n, err := res.RowsAffected()
+		return int(n), err
+	}
+
+	// Пути, которые могут освободиться, собираем до удаления
+	var candidates []string
+	rows, err := tx.QueryContext(ctx, `SELECT storage_path FROM homecloud.files WHERE owner_id=$1 AND NOT is_folder
+		UNION
+		SELECT rv.storage_path FROM homecloud.file_revisions rv
+		JOIN homecloud.files f ON f.id = rv.file_id
+		WHERE f.owner_id=$1`, id)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, path)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := exec(`UPDATE homecloud.files SET parent_id=NULL
+		WHERE owner_id<>$1 AND parent_id IN (SELECT id FROM homecloud.files WHERE owner_id=$1)`, id); err != nil {
+		return nil, err
+	}
+	if result.PermissionsDeleted, err = exec(`DELETE FROM homecloud.file_permissions
+		WHERE file_id IN (SELECT id FROM homecloud.files WHERE owner_id=$1)
+		   OR (grantee_type=$2 AND grantee_id=$1)`, id, models.GranteeUser); err != nil {
+		return nil, err
+	}
+	if _, err := exec(`DELETE FROM homecloud.expired_permissions_log
+		WHERE file_id IN (SELECT id FROM homecloud.files WHERE owner_id=$1)
+		   OR (grantee_type=$2 AND grantee_id=$1)`, id, models.GranteeUser); err != nil {
+		return nil, err
+	}
+	if _, err := exec(`DELETE FROM homecloud.share_links
+		WHERE created_by=$1 OR file_id IN (SELECT id FROM homecloud.files WHERE owner_id=$1)`, id); err != nil {
+		return nil, err
+	}
+	if result.RevisionsDeleted, err = exec(`DELETE FROM homecloud.file_revisions
+		WHERE file_id IN (SELECT id FROM homecloud.files WHERE owner_id=$1)`, id); err != nil {
+		return nil, err
+	}
+	if _, err := exec(`UPDATE homecloud.file_revisions SET user_id=NULL WHERE user_id=$1`, id); err != nil {
+		return nil, err
+	}
+	if result.FilesDeleted, err = exec(`DELETE FROM homecloud.files WHERE owner_id=$1`, id); err != nil {
+		return nil, err
+	}
+	if result.GroupsTransferred, result.GroupsDeleted, err = handOverOwnedGroups(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	if _, err := exec(`DELETE FROM homecloud.group_members WHERE member_id=$1 AND member_type=$2`, id, models.GroupMemberUser); err != nil {
+		return nil, err
+	}
+	if result.SessionsDeleted, err = exec(`DELETE FROM homecloud.sessions WHERE user_id=$1`, id); err != nil {
+		return nil, err
+	}
+	// Токены, 2FA, ключи WebAuthn и внешние учётные записи удаляются каскадно,
+	// groups.created_by обнуляется внешним ключом
+	if _, err := exec(`DELETE FROM homecloud.users WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	// Триггер журнала разрешает только такое изменение и только после удаления пользователя
+	if _, err := exec(`UPDATE homecloud.security_events SET ip_address=NULL, user_agent=NULL
+		WHERE user_id=$1 AND (ip_address IS NOT NULL OR user_agent IS NOT NULL)`, id); err != nil {
+		return nil, err
+	}
+	details := map[string]interface{}{
+		"files_deleted":      result.FilesDeleted,
+		"groups_transferred": result.GroupsTransferred,
+		"groups_deleted":     result.GroupsDeleted,
+	}
+	if err := recordSecurityEvent(ctx, tx, id, models.SecurityEventAccountErased, models.AuditInfo{}, details); err != nil {
+		return nil, err
+	}
+
+	// Счётчики ссылок поддерживаются триггерами; объект мог использоваться и чужими файлами
+	rows, err = tx.QueryContext(ctx, `SELECT storage_path FROM homecloud.storage_blobs
+		WHERE storage_path = ANY($1) AND ref_count = 0 ORDER BY storage_path`, pq.Array(candidates))
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		result.StoragePaths = append(result.StoragePaths, path)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, tx.Commit()
+}
+
+// handOverOwnedGroups передаёт группы, где userID - единственный владелец: владельцем становится
+// ADMIN, а без него - самый давний участник-пользователь. Группы без других участников-пользователей
+// удаляются. Возвращает число переданных и удалённых групп.
+func handOverOwnedGroups(ctx context.Context, tx *sql.Tx, userID string) (transferred, deleted int, err error) {
+	rows, err := tx.QueryContext(ctx, `SELECT gm.group_id FROM homecloud.group_members gm
+		WHERE gm.member_id=$1 AND gm.member_type=$2 AND gm.role=$3
+		  AND NOT EXISTS(SELECT 1 FROM homecloud.group_members o
+		                 WHERE o.group_id = gm.group_id AND o.role=$3 AND NOT (o.member_id=$1 AND o.member_type=$2))
+		FOR UPDATE`, userID, models.GroupMemberUser, models.GroupRoleOwner)
+	if err != nil {
+		return 0, 0, err
+	}
+	var soleOwned []string
+	for rows.Next() {
+		var groupID string
+		if err := rows.Scan(&groupID); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		soleOwned = append(soleOwned, groupID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+	if len(soleOwned) == 0 {
+		return 0, 0, nil
+	}
+
+	rows, err = tx.QueryContext(ctx, `UPDATE homecloud.group_members m SET role=$4
+		FROM (
+			SELECT DISTINCT ON (group_id) group_id, member_id FROM homecloud.group_members
+			WHERE group_id = ANY($1::uuid[]) AND member_type=$2 AND member_id<>$3
+			ORDER BY group_id, role=$5 DESC, created_at, member_id
+		) heir
+		WHERE m.group_id = heir.group_id AND m.member_id = heir.member_id AND m.member_type=$2
+		RETURNING m.group_id`,
+		pq.Array(soleOwned), models.GroupMemberUser, userID, models.GroupRoleOwner, models.GroupRoleAdmin)
+	if err != nil {
+		return 0, 0, err
+	}
+	promoted := make(map[string]bool, len(soleOwned))
+	for rows.Next() {
+		var groupID string
+		if err := rows.Scan(&groupID); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		promoted[groupID] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	var orphaned []string
+	for _, groupID := range soleOwned {
+		if !promoted[groupID] {
+			orphaned = append(orphaned, groupID)
+		}
+	}
+	if len(orphaned) > 0 {
+		if deleted, err = deleteGroups(ctx, tx, orphaned); err != nil {
+			return 0, 0, err
+		}
+	}
+	return len(promoted), deleted, nil
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// userGroupsCTE возвращает рекурсивный CTE "user_groups" со всеми группами, в которые
+// входит пользователь из параметра userParam (например, "$2"): напрямую или через
+// вложенные группы. path защищает от циклов. Требует WITH RECURSIVE.
+func userGroupsCTE(userParam string) string {
+	return `user_groups AS (
+	SELECT gm.group_id, ARRAY[gm.group_id] AS path
+	FROM homecloud.group_members gm WHERE gm.member_type = '` + models.GroupMemberUser + `' AND gm.member_id = ` + userParam + `
+	UNION ALL
+	SELECT gm.group_id, ug.path || gm.group_id
+	FROM homecloud.group_members gm JOIN user_groups ug ON gm.member_type = '` + models.GroupMemberGroup + `' AND gm.member_id = ug.group_id
+	WHERE NOT gm.group_id = ANY(ug.path)
+)`
+}
+
+const groupColumns = `id, name, description, created_by, created_at, updated_at`
+
+func scanGroup(row rowScanner) (*models.Group, error) {
+	group := &models.Group{}
+	var createdBy sql.NullString
+	err := row.Scan(&group.ID, &group.Name, &group.Description, &createdBy, &group.CreatedAt, &group.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	group.CreatedBy = createdBy.String
+	return group, nil
+}
+
+// CreateGroup создаёт группу и делает создателя её владельцем
+func (r *dbRepository) CreateGroup(ctx context.Context, group *models.Group) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowContext(ctx, `INSERT INTO homecloud.groups (name, description, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW()) RETURNING id`,
+		group.Name, group.Description, group.CreatedBy,
+	).Scan(&id)
+	if isForeignKeyViolation(err) {
+		return "", errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO homecloud.group_members (group_id, member_id, member_type, role, created_at)
+		VALUES ($1, $2, $3, $4, NOW())`, id, group.CreatedBy, models.GroupMemberUser, models.GroupRoleOwner)
+	if err != nil {
+		return "", err
+	}
+	return id, tx.Commit()
+}
+
+func (r *dbRepository) GetGroup(ctx context.Context, id string) (*models.Group, error) {
+	group, err := scanGroup(r.db.QueryRowContext(ctx, `SELECT `+groupColumns+` FROM homecloud.groups WHERE id=$1`, id))
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrGroupNotFound
+	}
+	return group, err
+}
+
+func (r *dbRepository) UpdateGroup(ctx context.Context, group *models.Group) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE homecloud.groups SET name=$1, description=$2, updated_at=NOW() WHERE id=$3`,
+		group.Name, group.Description, group.ID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrGroupNotFound
+	}
+	return nil
+}
+
+// DeleteGroup удаляет группу вместе с членством в других группах и выданными ей правами
+func (r *dbRepository) DeleteGroup(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	n, err := deleteGroups(ctx, tx, []string{id})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errdefs.ErrGroupNotFound
+	}
+	return tx.Commit()
+}
+
+// deleteGroups удаляет группы ids вместе с их вложением в другие группы и выданными им правами.
+// Возвращает число удалённых групп.
+func deleteGroups(ctx context.Context, tx *sql.Tx, ids []string) (int, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM homecloud.groups WHERE id = ANY($1::uuid[])`, pq.Array(ids))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM homecloud.group_members WHERE member_type=$2 AND member_id = ANY($1::uuid[])`,
+		pq.Array(ids), models.GroupMemberGroup); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM homecloud.file_permissions WHERE grantee_type=$2 AND grantee_id = ANY($1::uuid[])`,
+		pq.Array(ids), models.GranteeGroup); err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// ListGroups возвращает все группы пользователя, включая полученные через вложенные группы
+func (r *dbRepository) ListGroups(ctx context.Context, userID string) ([]*models.Group, error) {
+	query := `WITH RECURSIVE ` + userGroupsCTE("$1") + `
+		SELECT ` + groupColumns + ` FROM homecloud.groups
+		WHERE id IN (SELECT group_id FROM user_groups)
+		ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*models.Group
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// AddGroupMember добавляет участника или меняет его роль. Вложение группы,
+// которое замкнуло бы цикл, отклоняется.
+func (r *dbRepository) AddGroupMember(ctx context.Context, member *models.GroupMember) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Блокируем затронутые группы в фиксированном порядке, чтобы параллельные
+	// вложения A->B и B->A не прошли проверку цикла одновременно
+	ids := []string{member.GroupID}
+	if member.MemberType == models.GroupMemberGroup {
+		ids = append(ids, member.MemberID)
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM homecloud.groups WHERE id = ANY($1::uuid[]) ORDER BY id FOR UPDATE`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	locked := 0
+	for rows.Next() {
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if locked < len(uniqueStrings(ids)) {
+		return errdefs.ErrGroupNotFound
+	}
+
+	if member.MemberType == models.GroupMemberGroup {
+		var cycle bool
+		err := tx.QueryRowContext(ctx, `WITH RECURSIVE nested AS (
+				SELECT $2::uuid AS id
+				UNION
+				SELECT gm.member_id FROM homecloud.group_members gm JOIN nested n ON gm.group_id = n.id WHERE gm.member_type = $3
+			)
+			SELECT EXISTS(SELECT 1 FROM nested WHERE id = $1::uuid)`, member.GroupID, member.MemberID, models.GroupMemberGroup).Scan(&cycle)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return errdefs.ErrGroupCycle
+		}
+	}
+
+	if member.Role != models.GroupRoleOwner {
+		if err := ensureOtherGroupOwner(ctx, tx, member.GroupID, member.MemberID, member.MemberType); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO homecloud.group_members (group_id, member_id, member_type, role, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (group_id, member_id, member_type) DO UPDATE SET role = EXCLUDED.role`,
+		member.GroupID, member.MemberID, member.MemberType, member.Role)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *dbRepository) RemoveGroupMember(ctx context.Context, groupID, memberID, memberType string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM homecloud.groups WHERE id=$1 FOR UPDATE`, groupID); err != nil {
+		return err
+	}
+	if err := ensureOtherGroupOwner(ctx, tx, groupID, memberID, memberType); err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM homecloud.group_members WHERE group_id=$1 AND member_id=$2 AND member_type=$3`,
+		groupID, memberID, memberType)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrGroupMemberNotFound
+	}
+	return tx.Commit()
+}
+
+func (r *dbRepository) ListGroupMembers(ctx context.Context, groupID string) ([]*models.GroupMember, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT group_id, member_id, member_type, role, created_at
+		FROM homecloud.group_members WHERE group_id=$1 ORDER BY created_at`, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*models.GroupMember
+	for rows.Next() {
+		member := &models.GroupMember{}
+		if err := rows.Scan(&member.GroupID, &member.MemberID, &member.MemberType, &member.Role, &member.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// GetGroupMemberRole возвращает роль участника в группе (только прямое членство);
+// пустая строка - не участник
+func (r *dbRepository) GetGroupMemberRole(ctx context.Context, groupID, memberID, memberType string) (string, error) {
+	var exists bool
+	var role sql.NullString
+	err := r.db.QueryRowContext(ctx, `SELECT
+			EXISTS(SELECT 1 FROM homecloud.groups WHERE id=$1),
+			(SELECT role FROM homecloud.group_members WHERE group_id=$1 AND member_id=$2 AND member_type=$3)`,
+		groupID, memberID, memberType,
+	).Scan(&exists, &role)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errdefs.ErrGroupNotFound
+	}
+	return role.String, nil
+}
+
+// ensureOtherGroupOwner не даёт лишить группу последнего владельца: проверяет, что
+// кроме указанного участника в группе остаётся хотя бы один OWNER.
+// Если участник сам не владелец, проверка проходит.
+func ensureOtherGroupOwner(ctx context.Context, tx *sql.Tx, groupID, memberID, memberType string) error {
+	var isOwner, hasOther bool
+	err := tx.QueryRowContext(ctx, `SELECT
+			EXISTS(SELECT 1 FROM homecloud.group_members WHERE group_id=$1 AND member_id=$2 AND member_type=$3 AND role=$4),
+			EXISTS(SELECT 1 FROM homecloud.group_members WHERE group_id=$1 AND role=$4 AND NOT (member_id=$2 AND member_type=$3))`,
+		groupID, memberID, memberType, models.GroupRoleOwner,
+	).Scan(&isOwner, &hasOther)
+	if err != nil {
+		return err
+	}
+	if isOwner && !hasOther {
+		return errdefs.ErrLastGroupOwner
+	}
+	return nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var result []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
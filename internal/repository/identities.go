@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+const identityColumns = `provider, subject, user_id, email, linked_at`
+
+func scanIdentity(row rowScanner) (*models.UserIdentity, error) {
+	identity := &models.UserIdentity{}
+	if err := row.Scan(&identity.Provider, &identity.Subject, &identity.UserID, &identity.Email, &identity.LinkedAt); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// LinkIdentity привязывает внешнюю учётную запись. Одна запись провайдера
+// может принадлежать только одному пользователю.
+func (r *dbRepository) LinkIdentity(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error) {
+	linked, err := scanIdentity(r.db.QueryRowContext(ctx, `INSERT INTO homecloud.user_identities (provider, subject, user_id, email, linked_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING `+identityColumns,
+		identity.Provider, identity.Subject, identity.UserID, identity.Email,
+	))
+	if isUniqueViolation(err) {
+		return nil, errdefs.ErrIdentityExists
+	}
+	if isForeignKeyViolation(err) {
+		return nil, errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return linked, nil
+}
+
+// UnlinkIdentity отвязывает внешнюю учётную запись, если у пользователя остаётся
+// другой способ входа: пароль, другая внешняя запись или ключ WebAuthn.
+// Строка пользователя блокируется, чтобы параллельные отвязки не удалили все способы сразу.
+func (r *dbRepository) UnlinkIdentity(ctx context.Context, userID, provider, subject string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var hasPassword bool
+	err = tx.QueryRowContext(ctx, `SELECT password_hash <> '' FROM homecloud.users WHERE id=$1 FOR UPDATE`, userID).Scan(&hasPassword)
+	if err == sql.ErrNoRows {
+		return errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM homecloud.user_identities WHERE provider=$1 AND subject=$2 AND user_id=$3`,
+		provider, subject, userID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrIdentityNotFound
+	}
+
+	if !hasPassword {
+		var otherMethods bool
+		err = tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM homecloud.user_identities WHERE user_id=$1)
+			OR EXISTS(SELECT 1 FROM homecloud.webauthn_credentials WHERE user_id=$1)`, userID).Scan(&otherMethods)
+		if err != nil {
+			return err
+		}
+		if !otherMethods {
+			return errdefs.ErrLastLoginMethod
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *dbRepository) ListIdentities(ctx context.Context, userID string) ([]*models.UserIdentity, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+identityColumns+` FROM homecloud.user_identities
+		WHERE user_id=$1 ORDER BY linked_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []*models.UserIdentity
+	for rows.Next() {
+		identity, err := scanIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	return identities, rows.Err()
+}
+
+// GetUserByIdentity находит пользователя, которому привязана запись провайдера
+func (r *dbRepository) GetUserByIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	var userID string
+	err := r.db.QueryRowContext(ctx, `SELECT user_id FROM homecloud.user_identities WHERE provider=$1 AND subject=$2`,
+		provider, subject).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.GetUserByID(ctx, userID)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"homecloud--dbmanager-service/internal/models"
+)
+
+// recordPassword добавляет новый хеш в историю пользователя и оставляет в ней
+// только historyDepth последних записей. historyDepth <= 0 - история не обрезается:
+// пропущенная настройка не должна стирать уже накопленную историю.
+func recordPassword(ctx context.Context, tx *sql.Tx, userID, passwordHash string, historyDepth int) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO homecloud.password_history (user_id, password_hash, created_at) VALUES ($1, $2, NOW())`,
+		userID, passwordHash)
+	if err != nil {
+		return err
+	}
+	if historyDepth <= 0 {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM homecloud.password_history
+		WHERE user_id=$1 AND id NOT IN (
+			SELECT id FROM homecloud.password_history WHERE user_id=$1 ORDER BY id DESC LIMIT $2
+		)`, userID, historyDepth)
+	return err
+}
+
+// GetPasswordHistory возвращает до limit последних хешей пароля, начиная с текущего
+func (r *dbRepository) GetPasswordHistory(ctx context.Context, id string, limit int) ([]*models.PasswordHistoryEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT password_hash, created_at FROM homecloud.password_history
+		WHERE user_id=$1 ORDER BY id DESC LIMIT $2`, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*models.PasswordHistoryEntry
+	for rows.Next() {
+		entry := &models.PasswordHistoryEntry{}
+		if err := rows.Scan(&entry.PasswordHash, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+)
+
+func (r *dbRepository) GetPreferences(ctx context.Context, userID string) (map[string]interface{}, error) {
+	return r.queryPreferences(ctx, `SELECT preferences FROM homecloud.users WHERE id=$1`, userID)
+}
+
+// SetPreferences заменяет документ настроек целиком
+func (r *dbRepository) SetPreferences(ctx context.Context, userID string, prefs map[string]interface{}) (map[string]interface{}, error) {
+	doc, err := json.Marshal(prefs)
+	if err != nil {
+		return nil, err
+	}
+	return r.queryPreferences(ctx, `UPDATE homecloud.users SET preferences=$2, updated_at=NOW()
+		WHERE id=$1 RETURNING preferences`, userID, doc)
+}
+
+// PatchPreferences применяет JSON Merge Patch к документу настроек. Слияние выполняется
+// одним UPDATE, поэтому параллельные патчи разных ключей не теряют друг друга.
+func (r *dbRepository) PatchPreferences(ctx context.Context, userID string, patch map[string]interface{}) (map[string]interface{}, error) {
+	doc, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return r.queryPreferences(ctx, `UPDATE homecloud.users SET preferences=homecloud.jsonb_merge_patch(preferences, $2), updated_at=NOW()
+		WHERE id=$1 RETURNING preferences`, userID, doc)
+}
+
+func (r *dbRepository) queryPreferences(ctx context.Context, query string, args ...interface{}) (map[string]interface{}, error) {
+	var doc []byte
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&doc)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefs := map[string]interface{}{}
+	if err := json.Unmarshal(doc, &prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
+	"homecloud--dbmanager-service/internal/errdefs"
 	"homecloud--dbmanager-service/internal/interfaces"
 	"homecloud--dbmanager-service/internal/models"
+
+	"github.com/lib/pq"
 )
 
 type dbRepository struct {
@@ -18,14 +22,122 @@ func NewDBRepository(db *sql.DB) interfaces.DBRepository {
 	return &dbRepository{db: db}
 }
 
+// isUniqueViolation - нарушение уникального индекса (SQLSTATE 23505)
+func isUniqueViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == "23505"
+}
+
+// isForeignKeyViolation - ссылка на несуществующую строку (SQLSTATE 23503)
+func isForeignKeyViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == "23503"
+}
+
+// fileColumns - список колонок homecloud.files в порядке, ожидаемом scanFile
+const fileColumns = `id, owner_id, parent_id, name, file_extension, mime_type, storage_path, size, md5_checksum, sha256_checksum, is_folder, is_trashed, trashed_at, starred, created_at, updated_at, last_viewed_at, viewed_by_me, version, revision_id, indexable_text, thumbnail_link, web_view_link, web_content_link, icon_link`
+
+// normalizeEmail - email хранится и сравнивается в нижнем регистре
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// userUniqueViolation переводит нарушение уникальности email или username в доменную ошибку
+func userUniqueViolation(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if !ok || pqErr.Code != "23505" {
+		return err
+	}
+	switch pqErr.Constraint {
+	case "users_email_key", "idx_users_email_lower":
+		return errdefs.ErrEmailExists
+	case "users_username_key":
+		return errdefs.ErrUsernameExists
+	}
+	return err
+}
+
+// updateColumn - изменяемое поле: имя в API, колонка и новое значение
+type updateColumn struct {
+	field  string
+	column string
+	value  interface{}
+}
+
+// updateSet строит список SET для частичного обновления. fields - имена полей из
+// маски; пустая маска - ErrEmptyMask, неизвестное поле - ErrInvalidField.
+func updateSet(fields []string, columns []updateColumn) (string, []interface{}, error) {
+	if len(fields) == 0 {
+		return "", nil, errdefs.ErrEmptyMask
+	}
+	byField := make(map[string]updateColumn, len(columns))
+	for _, c := range columns {
+		byField[c.field] = c
+	}
+	var selected []updateColumn
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		c, ok := byField[f]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s", errdefs.ErrInvalidField, f)
+		}
+		if !seen[f] {
+			seen[f] = true
+			selected = append(selected, c)
+		}
+	}
+
+	set := make([]string, len(selected))
+	args := make([]interface{}, len(selected))
+	for i, c := range selected {
+		set[i] = fmt.Sprintf("%s=$%d", c.column, i+1)
+		args[i] = c.value
+	}
+	return strings.Join(set, ", "), args, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanFile читает строку с колонками fileColumns
+func scanFile(row rowScanner) (*models.File, error) {
+	file := &models.File{}
+	err := row.Scan(
+		&file.ID, &file.OwnerID, &file.ParentID, &file.Name, &file.FileExtension, &file.MimeType, &file.StoragePath, &file.Size, &file.MD5Checksum, &file.SHA256Checksum, &file.IsFolder, &file.IsTrashed, &file.TrashedAt, &file.Starred, &file.CreatedAt, &file.UpdatedAt, &file.LastViewedAt, &file.ViewedByMe, &file.Version, &file.RevisionID, &file.IndexableText, &file.ThumbnailLink, &file.WebViewLink, &file.WebContentLink, &file.IconLink,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// CreateUser создаёт пользователя; начальный пароль сразу попадает в историю,
+// чтобы к нему нельзя было вернуться при первой же смене
 func (r *dbRepository) CreateUser(ctx context.Context, user *models.User) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
 	query := `INSERT INTO homecloud.users (id, email, username, password_hash, is_active, is_email_verified, role, storage_quota, used_space, created_at, updated_at, failed_login_attempts, locked_until, last_login_at)
 		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,NOW(),NOW(),$10,$11,$12) RETURNING id`
 	var id string
-	err := r.db.QueryRowContext(ctx, query,
-		user.ID, user.Email, user.Username, user.PasswordHash, user.IsActive, user.IsEmailVerified, user.Role, user.StorageQuota, user.UsedSpace, user.FailedLoginAttempts, user.LockedUntil, user.LastLogin,
+	err = tx.QueryRowContext(ctx, query,
+		user.ID, normalizeEmail(user.Email), user.Username, user.PasswordHash, user.IsActive, user.IsEmailVerified, user.Role, user.StorageQuota, user.UsedSpace, user.FailedLoginAttempts, user.LockedUntil, user.LastLogin,
 	).Scan(&id)
-	return id, err
+	if err != nil {
+		return "", userUniqueViolation(err)
+	}
+	// Пользователи только с внешним входом создаются без пароля. У нового пользователя
+	// в истории одна запись, поэтому обрезать нечего и глубина не нужна.
+	if user.PasswordHash != "" {
+		if err := recordPassword(ctx, tx, id, user.PasswordHash, 0); err != nil {
+			return "", err
+		}
+	}
+	return id, tx.Commit()
 }
 
 func (r *dbRepository) GetUserByID(ctx context.Context, id string) (*models.User, error) {
@@ -48,10 +160,10 @@ func (r *dbRepository) GetUserByID(ctx context.Context, id string) (*models.User
 }
 
 func (r *dbRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
-	query := `SELECT id, email, username, password_hash, is_active, is_email_verified, role, storage_quota, used_space, created_at, updated_at, failed_login_attempts, locked_until, last_login_at FROM homecloud.users WHERE email=$1`
+	query := `SELECT id, email, username, password_hash, is_active, is_email_verified, role, storage_quota, used_space, created_at, updated_at, failed_login_attempts, locked_until, last_login_at FROM homecloud.users WHERE lower(email)=$1`
 	user := &models.User{}
 	var lockedUntil, lastLogin sql.NullTime
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	err := r.db.QueryRowContext(ctx, query, normalizeEmail(email)).Scan(
 		&user.ID, &user.Email, &user.Username, &user.PasswordHash, &user.IsActive, &user.IsEmailVerified, &user.Role, &user.StorageQuota, &user.UsedSpace, &user.CreatedAt, &user.UpdatedAt, &user.FailedLoginAttempts, &lockedUntil, &lastLogin,
 	)
 	if err != nil {
@@ -66,17 +178,90 @@ func (r *dbRepository) GetUserByEmail(ctx context.Context, email string) (*model
 	return user, nil
 }
 
-func (r *dbRepository) UpdateUser(ctx context.Context, user *models.User) error {
-	query := `UPDATE homecloud.users SET email=$1, username=$2, password_hash=$3, is_active=$4, is_email_verified=$5, role=$6, storage_quota=$7, used_space=$8, updated_at=NOW(), failed_login_attempts=$9, locked_until=$10, last_login_at=$11 WHERE id=$12`
-	_, err := r.db.ExecContext(ctx, query,
-		user.Email, user.Username, user.PasswordHash, user.IsActive, user.IsEmailVerified, user.Role, user.StorageQuota, user.UsedSpace, user.FailedLoginAttempts, user.LockedUntil, user.LastLogin, user.ID,
-	)
-	return err
+// UpdateUser записывает только поля fields (имена полей protos.User); пустой fields - ErrEmptyMask.
+// password_hash здесь не меняется: только UpdatePassword и ConsumeToken, с историей паролей.
+// Смена роли и активности аккаунта попадает в журнал событий безопасности.
+func (r *dbRepository) UpdateUser(ctx context.Context, user *models.User, fields []string, audit models.AuditInfo) error {
+	set, args, err := updateSet(fields, []updateColumn{
+		{"email", "email", normalizeEmail(user.Email)},
+		{"username", "username", user.Username},
+		{"is_active", "is_active", user.IsActive},
+		{"is_email_verified", "is_email_verified", user.IsEmailVerified},
+		{"role", "role", user.Role},
+		{"storage_quota", "storage_quota", user.StorageQuota},
+		{"used_space", "used_space", user.UsedSpace},
+		{"failed_login_attempts", "failed_login_attempts", user.FailedLoginAttempts},
+		{"locked_until", "locked_until", user.LockedUntil},
+		{"last_login", "last_login_at", user.LastLogin},
+	})
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var oldRole string
+	var oldActive bool
+	err = tx.QueryRowContext(ctx, `SELECT role, is_active FROM homecloud.users WHERE id=$1 FOR UPDATE`, user.ID).Scan(&oldRole, &oldActive)
+	if err == sql.ErrNoRows {
+		return errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var newRole string
+	var newActive bool
+	query := fmt.Sprintf(`UPDATE homecloud.users SET %s, updated_at=NOW() WHERE id=$%d RETURNING role, is_active`, set, len(args)+1)
+	err = tx.QueryRowContext(ctx, query, append(args, user.ID)...).Scan(&newRole, &newActive)
+	if err != nil {
+		return userUniqueViolation(err)
+	}
+
+	if newRole != oldRole {
+		details := map[string]interface{}{"from": oldRole, "to": newRole}
+		if err := recordSecurityEvent(ctx, tx, user.ID, models.SecurityEventRoleChanged, audit, details); err != nil {
+			return err
+		}
+	}
+	if newActive != oldActive {
+		eventType := models.SecurityEventAccountDeactivated
+		if newActive {
+			eventType = models.SecurityEventAccountActivated
+		}
+		if err := recordSecurityEvent(ctx, tx, user.ID, eventType, audit, nil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
-func (r *dbRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
-	_, err := r.db.ExecContext(ctx, `UPDATE homecloud.users SET password_hash=$1, updated_at=NOW() WHERE id=$2`, passwordHash, id)
-	return err
+// UpdatePassword меняет пароль и добавляет его хеш в историю глубиной historyDepth
+func (r *dbRepository) UpdatePassword(ctx context.Context, id, passwordHash string, historyDepth int, audit models.AuditInfo) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE homecloud.users SET password_hash=$1, updated_at=NOW() WHERE id=$2`, passwordHash, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrUserNotFound
+	}
+	if err := recordPassword(ctx, tx, id, passwordHash, historyDepth); err != nil {
+		return err
+	}
+	if err := recordSecurityEvent(ctx, tx, id, models.SecurityEventPasswordChanged, audit, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *dbRepository) UpdateUsername(ctx context.Context, id, username string) error {
@@ -84,9 +269,37 @@ func (r *dbRepository) UpdateUsername(ctx context.Context, id, username string)
 	return err
 }
 
-func (r *dbRepository) UpdateEmailVerification(ctx context.Context, id string, isVerified bool) error {
-	_, err := r.db.ExecContext(ctx, `UPDATE homecloud.users SET is_email_verified=$1, updated_at=NOW() WHERE id=$2`, isVerified, id)
-	return err
+// UpdateEmailVerification меняет статус подтверждения email; фактическая смена статуса пишется в журнал
+func (r *dbRepository) UpdateEmailVerification(ctx context.Context, id string, isVerified bool, audit models.AuditInfo) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var wasVerified bool
+	err = tx.QueryRowContext(ctx, `SELECT is_email_verified FROM homecloud.users WHERE id=$1 FOR UPDATE`, id).Scan(&wasVerified)
+	if err == sql.ErrNoRows {
+		return errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if wasVerified == isVerified {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE homecloud.users SET is_email_verified=$1, updated_at=NOW() WHERE id=$2`, isVerified, id); err != nil {
+		return err
+	}
+	eventType := models.SecurityEventEmailUnverified
+	if isVerified {
+		eventType = models.SecurityEventEmailVerified
+	}
+	if err := recordSecurityEvent(ctx, tx, id, eventType, audit, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *dbRepository) UpdateLastLogin(ctx context.Context, id string, lastLogin time.Time) error {
@@ -99,9 +312,162 @@ func (r *dbRepository) UpdateFailedLoginAttempts(ctx context.Context, id string,
 	return err
 }
 
-func (r *dbRepository) UpdateLockedUntil(ctx context.Context, id string, lockedUntil time.Time) error {
-	_, err := r.db.ExecContext(ctx, `UPDATE homecloud.users SET locked_until=$1, updated_at=NOW() WHERE id=$2`, lockedUntil, id)
-	return err
+// UpdateLockedUntil задаёт время блокировки: время в будущем блокирует аккаунт,
+// nil или время в прошлом - снимает блокировку
+func (r *dbRepository) UpdateLockedUntil(ctx context.Context, id string, lockedUntil *time.Time, audit models.AuditInfo) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Сравнение с NOW() в базе, чтобы не зависеть от часов сервиса
+	var locked, wasLocked bool
+	err = tx.QueryRowContext(ctx, `WITH prev AS (
+			SELECT id, COALESCE(locked_until > NOW(), false) AS was_locked FROM homecloud.users WHERE id=$2 FOR UPDATE
+		)
+		UPDATE homecloud.users u SET locked_until=$1, updated_at=NOW() FROM prev WHERE u.id = prev.id
+		RETURNING COALESCE(u.locked_until > NOW(), false), prev.was_locked`, lockedUntil, id).Scan(&locked, &wasLocked)
+	if err == sql.ErrNoRows {
+		return errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// Событие пишется, только если блокировка действительно поставлена или снята
+	var eventType string
+	switch {
+	case locked:
+		eventType = models.SecurityEventAccountLocked
+	case wasLocked:
+		eventType = models.SecurityEventAccountUnlocked
+	default:
+		return tx.Commit()
+	}
+	var details map[string]interface{}
+	if lockedUntil != nil {
+		details = map[string]interface{}{"locked_until": lockedUntil.UTC().Format(time.RFC3339)}
+	}
+	if err := recordSecurityEvent(ctx, tx, id, eventType, audit, details); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RecordFailedLogin атомарно засчитывает неудачный вход и по политике блокирует аккаунт.
+// Строка пользователя блокируется на время запроса, поэтому параллельные попытки не теряются.
+// Попытки во время действующей блокировки не засчитываются и не продлевают её:
+// иначе любой, кто знает логин, мог бы держать аккаунт заблокированным бесконечно.
+func (r *dbRepository) RecordFailedLogin(ctx context.Context, id string, policy models.LockoutPolicy, audit models.AuditInfo) (*models.LoginState, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	state := &models.LoginState{}
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(failed_login_attempts, 0), locked_until, COALESCE(locked_until > NOW(), false)
+		FROM homecloud.users WHERE id=$1 FOR UPDATE`, id,
+	).Scan(&state.FailedLoginAttempts, &state.LockedUntil, &state.Locked)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if state.Locked {
+		details := map[string]interface{}{"attempts": state.FailedLoginAttempts}
+		if err := recordSecurityEvent(ctx, tx, id, models.SecurityEventLoginBlocked, audit, details); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return state, nil
+	}
+
+	// Показатель степени ограничен, чтобы power() не переполнялся
+	query := `UPDATE homecloud.users SET
+			failed_login_attempts = next.attempts,
+			last_failed_login_at = NOW(),
+			locked_until = CASE
+				WHEN $3::int > 0 AND next.attempts >= $3::int THEN NOW() + (CASE
+					WHEN $5::float8 > 0 THEN LEAST($4::float8 * power(2, LEAST(next.attempts - $3::int, 30)), $5::float8)
+					ELSE $4::float8 * power(2, LEAST(next.attempts - $3::int, 30))
+				END) * interval '1 second'
+				ELSE locked_until
+			END,
+			updated_at = NOW()
+		FROM (
+			SELECT CASE
+				WHEN last_failed_login_at IS NULL THEN 1
+				WHEN $2::float8 > 0 AND last_failed_login_at < NOW() - $2::float8 * interval '1 second' THEN 1
+				ELSE COALESCE(failed_login_attempts, 0) + 1
+			END AS attempts
+			FROM homecloud.users WHERE id=$1
+		) next
+		WHERE id=$1
+		RETURNING failed_login_attempts, locked_until, COALESCE(locked_until > NOW(), false)`
+	err = tx.QueryRowContext(ctx, query, id,
+		policy.ResetWindow.Seconds(), policy.Threshold, policy.LockDuration.Seconds(), policy.MaxLockDuration.Seconds(),
+	).Scan(&state.FailedLoginAttempts, &state.LockedUntil, &state.Locked)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"attempts": state.FailedLoginAttempts}
+	if err := recordSecurityEvent(ctx, tx, id, models.SecurityEventLoginFailed, audit, details); err != nil {
+		return nil, err
+	}
+	if state.Locked {
+		details := map[string]interface{}{"locked_until": state.LockedUntil.UTC().Format(time.RFC3339), "attempts": state.FailedLoginAttempts}
+		if err := recordSecurityEvent(ctx, tx, id, models.SecurityEventAccountLocked, audit, details); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// RecordSuccessfulLogin сбрасывает счётчик неудач и обновляет время входа.
+// Пока аккаунт заблокирован, вход не засчитывается и возвращается текущая блокировка.
+func (r *dbRepository) RecordSuccessfulLogin(ctx context.Context, id string, audit models.AuditInfo) (*models.LoginState, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	state := &models.LoginState{}
+	err = tx.QueryRowContext(ctx, `UPDATE homecloud.users
+		SET failed_login_attempts=0, last_failed_login_at=NULL, locked_until=NULL, last_login_at=NOW(), updated_at=NOW()
+		WHERE id=$1 AND (locked_until IS NULL OR locked_until <= NOW())
+		RETURNING failed_login_attempts, locked_until, false`, id,
+	).Scan(&state.FailedLoginAttempts, &state.LockedUntil, &state.Locked)
+	eventType := models.SecurityEventLoginSucceeded
+	if err == sql.ErrNoRows {
+		eventType = models.SecurityEventLoginBlocked
+		err = tx.QueryRowContext(ctx, `SELECT COALESCE(failed_login_attempts, 0), locked_until, COALESCE(locked_until > NOW(), false)
+			FROM homecloud.users WHERE id=$1`, id,
+		).Scan(&state.FailedLoginAttempts, &state.LockedUntil, &state.Locked)
+		if err == sql.ErrNoRows {
+			return nil, errdefs.ErrUserNotFound
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordSecurityEvent(ctx, tx, id, eventType, audit, nil); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return state, nil
 }
 
 func (r *dbRepository) UpdateStorageUsage(ctx context.Context, id string, usedSpace int64) error {
@@ -111,7 +477,7 @@ func (r *dbRepository) UpdateStorageUsage(ctx context.Context, id string, usedSp
 
 func (r *dbRepository) CheckEmailExists(ctx context.Context, email string) (bool, error) {
 	var exists bool
-	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM homecloud.users WHERE email=$1)`, email).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM homecloud.users WHERE lower(email)=$1)`, normalizeEmail(email)).Scan(&exists)
 	return exists, err
 }
 
@@ -239,11 +605,37 @@ func (r *dbRepository) GetFileByPath(ctx context.Context, ownerID, path string)
 	return file, nil
 }
 
-func (r *dbRepository) UpdateFile(ctx context.Context, file *models.File) error {
-	query := `UPDATE homecloud.files SET owner_id=$1, parent_id=$2, name=$3, file_extension=$4, mime_type=$5, storage_path=$6, size=$7, md5_checksum=$8, sha256_checksum=$9, is_folder=$10, is_trashed=$11, trashed_at=$12, starred=$13, updated_at=NOW(), last_viewed_at=$14, viewed_by_me=$15, version=$16, revision_id=$17, indexable_text=$18, thumbnail_link=$19, web_view_link=$20, web_content_link=$21, icon_link=$22 WHERE id=$23`
-	_, err := r.db.ExecContext(ctx, query,
-		file.OwnerID, file.ParentID, file.Name, file.FileExtension, file.MimeType, file.StoragePath, file.Size, file.MD5Checksum, file.SHA256Checksum, file.IsFolder, file.IsTrashed, file.TrashedAt, file.Starred, file.LastViewedAt, file.ViewedByMe, file.Version, file.RevisionID, file.IndexableText, file.ThumbnailLink, file.WebViewLink, file.WebContentLink, file.IconLink, file.ID,
-	)
+// UpdateFile записывает только поля fields (имена полей protos.File); пустой fields - ErrEmptyMask.
+// owner_id здесь не меняется: владельца вместе с квотой переносит TransferOwnership.
+func (r *dbRepository) UpdateFile(ctx context.Context, file *models.File, fields []string) error {
+	set, args, err := updateSet(fields, []updateColumn{
+		{"parent_id", "parent_id", file.ParentID},
+		{"name", "name", file.Name},
+		{"file_extension", "file_extension", file.FileExtension},
+		{"mime_type", "mime_type", file.MimeType},
+		{"storage_path", "storage_path", file.StoragePath},
+		{"size", "size", file.Size},
+		{"md5_checksum", "md5_checksum", file.MD5Checksum},
+		{"sha256_checksum", "sha256_checksum", file.SHA256Checksum},
+		{"is_folder", "is_folder", file.IsFolder},
+		{"is_trashed", "is_trashed", file.IsTrashed},
+		{"trashed_at", "trashed_at", file.TrashedAt},
+		{"starred", "starred", file.Starred},
+		{"last_viewed_at", "last_viewed_at", file.LastViewedAt},
+		{"viewed_by_me", "viewed_by_me", file.ViewedByMe},
+		{"version", "version", file.Version},
+		{"revision_id", "revision_id", file.RevisionID},
+		{"indexable_text", "indexable_text", file.IndexableText},
+		{"thumbnail_link", "thumbnail_link", file.ThumbnailLink},
+		{"web_view_link", "web_view_link", file.WebViewLink},
+		{"web_content_link", "web_content_link", file.WebContentLink},
+		{"icon_link", "icon_link", file.IconLink},
+	})
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`UPDATE homecloud.files SET %s, updated_at=NOW() WHERE id=$%d`, set, len(args)+1)
+	_, err = r.db.ExecContext(ctx, query, append(args, file.ID)...)
 	return err
 }
 
@@ -481,17 +873,17 @@ func (r *dbRepository) GetFileTree(ctx context.Context, ownerID, rootID string)
 
 // File revision operations
 func (r *dbRepository) CreateRevision(ctx context.Context, revision *models.FileRevision) (string, error) {
-	query := `INSERT INTO homecloud.file_revisions (file_id, revision_id, md5_checksum, size, created_at, storage_path, mime_type, user_id)
-		VALUES ($1, $2, $3, $4, NOW(), $5, $6, $7) RETURNING id`
+	query := `INSERT INTO homecloud.file_revisions (file_id, revision_id, md5_checksum, sha256_checksum, size, created_at, storage_path, mime_type, user_id)
+		VALUES ($1, $2, $3, $4, $5, NOW(), $6, $7, $8) RETURNING id`
 	var id string
 	err := r.db.QueryRowContext(ctx, query,
-		revision.FileID, revision.RevisionID, revision.MD5Checksum, revision.Size, revision.StoragePath, revision.MimeType, revision.UserID,
+		revision.FileID, revision.RevisionID, revision.MD5Checksum, revision.SHA256Checksum, revision.Size, revision.StoragePath, revision.MimeType, revision.UserID,
 	).Scan(&id)
 	return id, err
 }
 
 func (r *dbRepository) GetRevisions(ctx context.Context, fileID string) ([]*models.FileRevision, error) {
-	query := `SELECT id, file_id, revision_id, md5_checksum, size, created_at, storage_path, mime_type, user_id FROM homecloud.file_revisions WHERE file_id=$1 ORDER BY revision_id DESC`
+	query := `SELECT id, file_id, revision_id, md5_checksum, sha256_checksum, size, created_at, storage_path, mime_type, user_id, label, description FROM homecloud.file_revisions WHERE file_id=$1 ORDER BY revision_id DESC`
 	rows, err := r.db.QueryContext(ctx, query, fileID)
 	if err != nil {
 		return nil, err
@@ -502,7 +894,7 @@ func (r *dbRepository) GetRevisions(ctx context.Context, fileID string) ([]*mode
 	for rows.Next() {
 		revision := &models.FileRevision{}
 		err := rows.Scan(
-			&revision.ID, &revision.FileID, &revision.RevisionID, &revision.MD5Checksum, &revision.Size, &revision.CreatedAt, &revision.StoragePath, &revision.MimeType, &revision.UserID,
+			&revision.ID, &revision.FileID, &revision.RevisionID, &revision.MD5Checksum, &revision.SHA256Checksum, &revision.Size, &revision.CreatedAt, &revision.StoragePath, &revision.MimeType, &revision.UserID, &revision.Label, &revision.Description,
 		)
 		if err != nil {
 			return nil, err
@@ -514,10 +906,10 @@ func (r *dbRepository) GetRevisions(ctx context.Context, fileID string) ([]*mode
 }
 
 func (r *dbRepository) GetRevision(ctx context.Context, fileID string, revisionID int64) (*models.FileRevision, error) {
-	query := `SELECT id, file_id, revision_id, md5_checksum, size, created_at, storage_path, mime_type, user_id FROM homecloud.file_revisions WHERE file_id=$1 AND revision_id=$2`
+	query := `SELECT id, file_id, revision_id, md5_checksum, sha256_checksum, size, created_at, storage_path, mime_type, user_id, label, description FROM homecloud.file_revisions WHERE file_id=$1 AND revision_id=$2`
 	revision := &models.FileRevision{}
 	err := r.db.QueryRowContext(ctx, query, fileID, revisionID).Scan(
-		&revision.ID, &revision.FileID, &revision.RevisionID, &revision.MD5Checksum, &revision.Size, &revision.CreatedAt, &revision.StoragePath, &revision.MimeType, &revision.UserID,
+		&revision.ID, &revision.FileID, &revision.RevisionID, &revision.MD5Checksum, &revision.SHA256Checksum, &revision.Size, &revision.CreatedAt, &revision.StoragePath, &revision.MimeType, &revision.UserID, &revision.Label, &revision.Description,
 	)
 	if err != nil {
 		return nil, err
@@ -530,19 +922,143 @@ func (r *dbRepository) DeleteRevision(ctx context.Context, id string) error {
 	return err
 }
 
+func (r *dbRepository) SetRevisionLabel(ctx context.Context, fileID string, revisionID int64, label, description string) (*models.FileRevision, error) {
+	query := `UPDATE homecloud.file_revisions SET label=$1, description=NULLIF($2, '') WHERE file_id=$3 AND revision_id=$4
+		RETURNING id, file_id, revision_id, md5_checksum, sha256_checksum, size, created_at, storage_path, mime_type, user_id, label, description`
+	revision := &models.FileRevision{}
+	err := r.db.QueryRowContext(ctx, query, label, description, fileID, revisionID).Scan(
+		&revision.ID, &revision.FileID, &revision.RevisionID, &revision.MD5Checksum, &revision.SHA256Checksum, &revision.Size, &revision.CreatedAt, &revision.StoragePath, &revision.MimeType, &revision.UserID, &revision.Label, &revision.Description,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrRevisionNotFound
+	}
+	if isUniqueViolation(err) {
+		return nil, errdefs.ErrRevisionLabelExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (r *dbRepository) ClearRevisionLabel(ctx context.Context, fileID string, revisionID int64) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE homecloud.file_revisions SET label=NULL, description=NULL WHERE file_id=$1 AND revision_id=$2`, fileID, revisionID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrRevisionNotFound
+	}
+	return nil
+}
+
+func (r *dbRepository) GetRevisionByLabel(ctx context.Context, fileID, label string) (*models.FileRevision, error) {
+	query := `SELECT id, file_id, revision_id, md5_checksum, sha256_checksum, size, created_at, storage_path, mime_type, user_id, label, description FROM homecloud.file_revisions WHERE file_id=$1 AND label=$2`
+	revision := &models.FileRevision{}
+	err := r.db.QueryRowContext(ctx, query, fileID, label).Scan(
+		&revision.ID, &revision.FileID, &revision.RevisionID, &revision.MD5Checksum, &revision.SHA256Checksum, &revision.Size, &revision.CreatedAt, &revision.StoragePath, &revision.MimeType, &revision.UserID, &revision.Label, &revision.Description,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// PruneRevisions удаляет ревизии старше keepLatest последних.
+// Ревизии с меткой и текущая ревизия файла не удаляются.
+func (r *dbRepository) PruneRevisions(ctx context.Context, fileID string, keepLatest int) ([]*models.FileRevision, error) {
+	query := `DELETE FROM homecloud.file_revisions fr
+		WHERE fr.file_id=$1
+		  AND fr.label IS NULL
+		  AND fr.id NOT IN (SELECT revision_id FROM homecloud.files WHERE id=$1 AND revision_id IS NOT NULL)
+		  AND fr.id NOT IN (SELECT id FROM homecloud.file_revisions WHERE file_id=$1 ORDER BY revision_id DESC LIMIT $2)
+		RETURNING id, file_id, revision_id, md5_checksum, sha256_checksum, size, created_at, storage_path, mime_type, user_id, label, description`
+	rows, err := r.db.QueryContext(ctx, query, fileID, keepLatest)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*models.FileRevision
+	for rows.Next() {
+		revision := &models.FileRevision{}
+		err := rows.Scan(
+			&revision.ID, &revision.FileID, &revision.RevisionID, &revision.MD5Checksum, &revision.SHA256Checksum, &revision.Size, &revision.CreatedAt, &revision.StoragePath, &revision.MimeType, &revision.UserID, &revision.Label, &revision.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
 // File permission operations
 func (r *dbRepository) CreatePermission(ctx context.Context, permission *models.FilePermission) (string, error) {
-	query := `INSERT INTO homecloud.file_permissions (file_id, grantee_id, grantee_type, role, allow_share, created_at)
-		VALUES ($1, $2, $3, $4, $5, NOW()) RETURNING id`
+	query := `INSERT INTO homecloud.file_permissions (file_id, grantee_id, grantee_type, domain, role, allow_share, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), $7) RETURNING id`
 	var id string
 	err := r.db.QueryRowContext(ctx, query,
-		permission.FileID, permission.GranteeID, permission.GranteeType, permission.Role, permission.AllowShare,
+		permission.FileID, permission.GranteeID, permission.GranteeType, permission.Domain, permission.Role, permission.AllowShare, permission.ExpiresAt,
 	).Scan(&id)
+	if isForeignKeyViolation(err) {
+		return "", errdefs.ErrFileNotFound
+	}
 	return id, err
 }
 
+// fileAncestorsCTE - рекурсивный CTE "ancestors" с самим файлом $1 (depth=0) и всеми его
+// папками-предками вверх по parent_id. path защищает от циклов в дереве.
+const fileAncestorsCTE = `WITH RECURSIVE ancestors AS (
+	SELECT id, parent_id, 0 AS depth, ARRAY[id] AS path FROM homecloud.files WHERE id=$1
+	UNION ALL
+	SELECT f.id, f.parent_id, a.depth + 1, a.path || f.id
+	FROM homecloud.files f JOIN ancestors a ON f.id = a.parent_id
+	WHERE NOT f.id = ANY(a.path)
+)`
+
+// granteeMatchesUserSQL - право p относится к пользователю $2: выдано ему лично,
+// одной из его групп (GROUP, нужен CTE user_groups), всем (ANYONE) или домену его email (DOMAIN)
+const granteeMatchesUserSQL = `(
+	(p.grantee_type = 'USER' AND p.grantee_id = $2)
+	OR (p.grantee_type = 'GROUP' AND p.grantee_id IN (SELECT group_id FROM user_groups))
+	OR p.grantee_type = 'ANYONE'
+	OR (p.grantee_type = 'DOMAIN' AND lower(p.domain) = (SELECT lower(split_part(email, '@', 2)) FROM homecloud.users WHERE id = $2))
+)`
+
+// permissionActiveSQL - срок действия права p не истёк
+const permissionActiveSQL = `(p.expires_at IS NULL OR p.expires_at > NOW())`
+
+// permissionRoleRankSQL - ранг роли p.role по models.PermissionRoles, чем больше, тем сильнее
+var permissionRoleRankSQL = roleRankSQL("p.role")
+
+// roleRankSQL строит CASE, переводящий роль из column в ранг models.RoleRank.
+// Роли берутся из models.PermissionRoles, а не из пользовательского ввода.
+func roleRankSQL(column string) string {
+	var b strings.Builder
+	b.WriteString("CASE " + column)
+	for _, role := range models.PermissionRoles {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", role, models.RoleRank(role))
+	}
+	b.WriteString(" ELSE 0 END")
+	return b.String()
+}
+
+// GetPermissions возвращает действующие права на файл: собственные и унаследованные
+// от папок-предков. Для каждого получателя остаётся самая сильная роль,
+// при равенстве - ближайшая к файлу. Истёкшие права не учитываются.
 func (r *dbRepository) GetPermissions(ctx context.Context, fileID string) ([]*models.FilePermission, error) {
-	query := `SELECT id, file_id, grantee_id, grantee_type, role, allow_share, created_at FROM homecloud.file_permissions WHERE file_id=$1 ORDER BY created_at DESC`
+	query := fileAncestorsCTE + `
+		SELECT id, file_id, grantee_id, grantee_type, domain, role, allow_share, created_at, expires_at, depth FROM (
+			SELECT DISTINCT ON (p.grantee_type, p.grantee_id, lower(p.domain)) p.id, p.file_id, p.grantee_id, p.grantee_type, p.domain, p.role, p.allow_share, p.created_at, p.expires_at, a.depth
+			FROM homecloud.file_permissions p JOIN ancestors a ON p.file_id = a.id
+			WHERE ` + permissionActiveSQL + `
+			ORDER BY p.grantee_type, p.grantee_id, lower(p.domain), ` + permissionRoleRankSQL + ` DESC, a.depth ASC
+		) effective ORDER BY created_at DESC`
 	rows, err := r.db.QueryContext(ctx, query, fileID)
 	if err != nil {
 		return nil, err
@@ -552,57 +1068,248 @@ func (r *dbRepository) GetPermissions(ctx context.Context, fileID string) ([]*mo
 	var permissions []*models.FilePermission
 	for rows.Next() {
 		permission := &models.FilePermission{}
+		var depth int
 		err := rows.Scan(
-			&permission.ID, &permission.FileID, &permission.GranteeID, &permission.GranteeType, &permission.Role, &permission.AllowShare, &permission.CreatedAt,
+			&permission.ID, &permission.FileID, &permission.GranteeID, &permission.GranteeType, &permission.Domain, &permission.Role, &permission.AllowShare, &permission.CreatedAt, &permission.ExpiresAt, &depth,
 		)
 		if err != nil {
 			return nil, err
 		}
+		// Унаследованное право показываем как право на сам файл с указанием папки-источника
+		if depth > 0 {
+			inheritedFrom := permission.FileID
+			permission.Inherited = true
+			permission.InheritedFrom = &inheritedFrom
+			permission.FileID = fileID
+		}
 		permissions = append(permissions, permission)
 	}
 
-	return permissions, nil
+	return permissions, rows.Err()
+}
+
+func (r *dbRepository) GetPermission(ctx context.Context, id string) (*models.FilePermission, error) {
+	permission := &models.FilePermission{}
+	err := r.db.QueryRowContext(ctx, `SELECT id, file_id, grantee_id, grantee_type, domain, role, allow_share, created_at, expires_at
+		FROM homecloud.file_permissions WHERE id=$1`, id).Scan(
+		&permission.ID, &permission.FileID, &permission.GranteeID, &permission.GranteeType, &permission.Domain, &permission.Role, &permission.AllowShare, &permission.CreatedAt, &permission.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrPermissionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
 }
 
 func (r *dbRepository) UpdatePermission(ctx context.Context, permission *models.FilePermission) error {
-	query := `UPDATE homecloud.file_permissions SET grantee_id=$1, grantee_type=$2, role=$3, allow_share=$4 WHERE id=$5`
-	_, err := r.db.ExecContext(ctx, query,
-		permission.GranteeID, permission.GranteeType, permission.Role, permission.AllowShare, permission.ID,
+	query := `UPDATE homecloud.file_permissions SET grantee_id=$1, grantee_type=$2, domain=$3, role=$4, allow_share=$5, expires_at=$6 WHERE id=$7`
+	res, err := r.db.ExecContext(ctx, query,
+		permission.GranteeID, permission.GranteeType, permission.Domain, permission.Role, permission.AllowShare, permission.ExpiresAt, permission.ID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrPermissionNotFound
+	}
+	return nil
 }
 
 func (r *dbRepository) DeletePermission(ctx context.Context, id string) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM homecloud.file_permissions WHERE id=$1`, id)
-	return err
+	res, err := r.db.ExecContext(ctx, `DELETE FROM homecloud.file_permissions WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrPermissionNotFound
+	}
+	return nil
 }
 
 func (r *dbRepository) CheckPermission(ctx context.Context, fileID, userID, requiredRole string) (bool, error) {
-	// Определяем иерархию ролей
-	var roleHierarchy string
-	switch requiredRole {
-	case "READER":
-		roleHierarchy = "'READER', 'COMMENTER', 'WRITER', 'FILE_OWNER', 'ORGANIZER', 'OWNER'"
-	case "COMMENTER":
-		roleHierarchy = "'COMMENTER', 'WRITER', 'FILE_OWNER', 'ORGANIZER', 'OWNER'"
-	case "WRITER":
-		roleHierarchy = "'WRITER', 'FILE_OWNER', 'ORGANIZER', 'OWNER'"
-	case "FILE_OWNER":
-		roleHierarchy = "'FILE_OWNER', 'ORGANIZER', 'OWNER'"
-	case "ORGANIZER":
-		roleHierarchy = "'ORGANIZER', 'OWNER'"
-	case "OWNER":
-		roleHierarchy = "'OWNER'"
-	default:
-		roleHierarchy = fmt.Sprintf("'%s'", requiredRole)
+	roles := models.RolesAtLeast(requiredRole)
+	if roles == nil {
+		return false, errdefs.ErrUnknownRole
 	}
 
-	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM homecloud.file_permissions WHERE file_id=$1 AND grantee_id=$2 AND role IN (%s))`, roleHierarchy)
+	// Владелец файла имеет любые права. Права на папку распространяются на всё её содержимое.
+	query := fileAncestorsCTE + `, ` + userGroupsCTE("$2") + `
+		SELECT EXISTS(SELECT 1 FROM homecloud.files WHERE id=$1 AND owner_id=$2)
+		    OR EXISTS(SELECT 1 FROM homecloud.file_permissions p JOIN ancestors a ON p.file_id = a.id
+		              WHERE p.role = ANY($3) AND ` + permissionActiveSQL + ` AND ` + granteeMatchesUserSQL + `)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, fileID, userID).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, fileID, userID, pq.Array(roles)).Scan(&exists)
 	return exists, err
 }
 
+// CheckPermissions - CheckPermission для нескольких файлов одним запросом.
+// В результате есть все запрошенные ID, для несуществующих файлов - false.
+func (r *dbRepository) CheckPermissions(ctx context.Context, fileIDs []string, userID, requiredRole string) (map[string]bool, error) {
+	roles := models.RolesAtLeast(requiredRole)
+	if roles == nil {
+		return nil, errdefs.ErrUnknownRole
+	}
+	// База возвращает UUID в нижнем регистре, а ключи результата - ID в том виде, в каком их передали
+	result := make(map[string]bool, len(fileIDs))
+	requested := make(map[string][]string, len(fileIDs))
+	for _, id := range fileIDs {
+		result[id] = false
+		requested[strings.ToLower(id)] = append(requested[strings.ToLower(id)], id)
+	}
+	if len(fileIDs) == 0 {
+		return result, nil
+	}
+
+	// Как fileAncestorsCTE, но для каждого файла из $1 отдельно (root_id - исходный файл)
+	query := `WITH RECURSIVE ancestors AS (
+			SELECT id AS root_id, id, parent_id, ARRAY[id] AS path FROM homecloud.files WHERE id = ANY($1::uuid[])
+			UNION ALL
+			SELECT a.root_id, f.id, f.parent_id, a.path || f.id
+			FROM homecloud.files f JOIN ancestors a ON f.id = a.parent_id
+			WHERE NOT f.id = ANY(a.path)
+		), ` + userGroupsCTE("$2") + `
+		SELECT t.id,
+		       t.owner_id = $2
+		    OR EXISTS(SELECT 1 FROM homecloud.file_permissions p JOIN ancestors a ON p.file_id = a.id
+		              WHERE a.root_id = t.id AND p.role = ANY($3) AND ` + permissionActiveSQL + ` AND ` + granteeMatchesUserSQL + `)
+		FROM homecloud.files t WHERE t.id = ANY($1::uuid[])`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(fileIDs), userID, pq.Array(roles))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var allowed bool
+		if err := rows.Scan(&id, &allowed); err != nil {
+			return nil, err
+		}
+		for _, requestedID := range requested[id] {
+			result[requestedID] = allowed
+		}
+	}
+	return result, rows.Err()
+}
+
+// GetEffectivePermission объясняет доступ пользователя к файлу: собирает все основания
+// (владение, права на файл и папки-предки, группы, домен, ANYONE и ссылку linkID,
+// если она передана и действительна по тем же правилам, что в ResolveShareLink)
+// и вычисляет по ним итоговую роль и право делиться.
+func (r *dbRepository) GetEffectivePermission(ctx context.Context, fileID, userID, linkID string) (*models.EffectivePermission, error) {
+	var link interface{}
+	if linkID != "" {
+		link = linkID
+	}
+	query := fileAncestorsCTE + `, ` + userGroupsCTE("$2") + `
+		SELECT source_type, role, allow_share, permission_id, source_file_id, source_file_name, depth, group_id, group_name, domain, expires_at
+		FROM (
+			SELECT 'OWNER' AS source_type, 'OWNER' AS role, true AS allow_share, NULL::uuid AS permission_id,
+			       f.id AS source_file_id, f.name AS source_file_name, 0 AS depth,
+			       NULL::uuid AS group_id, NULL::text AS group_name, NULL::text AS domain, NULL::timestamp AS expires_at
+			FROM homecloud.files f WHERE f.id=$1 AND f.owner_id=$2
+			UNION ALL
+			SELECT p.grantee_type, p.role, p.allow_share, p.id, f.id, f.name, a.depth, g.id, g.name, p.domain, p.expires_at
+			FROM homecloud.file_permissions p
+			JOIN ancestors a ON p.file_id = a.id
+			JOIN homecloud.files f ON f.id = a.id
+			LEFT JOIN homecloud.groups g ON p.grantee_type = 'GROUP' AND g.id = p.grantee_id
+			WHERE ` + permissionActiveSQL + ` AND ` + granteeMatchesUserSQL + `
+			UNION ALL
+			SELECT 'LINK', l.role, false, l.id, f.id, f.name, a.depth, NULL, NULL, NULL, l.expires_at
+			FROM homecloud.share_links l
+			JOIN ancestors a ON l.file_id = a.id
+			JOIN homecloud.files f ON f.id = a.id
+			WHERE l.id = $3 AND NOT f.is_trashed AND NOT l.revoked
+			  AND (l.expires_at IS NULL OR l.expires_at > NOW())
+			  AND (l.max_uses IS NULL OR l.use_count < l.max_uses)
+		) p
+		ORDER BY ` + permissionRoleRankSQL + ` DESC, depth ASC,
+		         array_position(ARRAY['OWNER', 'USER', 'GROUP', 'DOMAIN', 'ANYONE', 'LINK'], source_type)`
+	rows, err := r.db.QueryContext(ctx, query, fileID, userID, link)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	effective := &models.EffectivePermission{FileID: fileID, UserID: userID}
+	for rows.Next() {
+		source := &models.PermissionSource{}
+		var depth int
+		err := rows.Scan(
+			&source.Type, &source.Role, &source.AllowShare, &source.PermissionID, &source.FileID, &source.FileName, &depth, &source.GroupID, &source.GroupName, &source.Domain, &source.ExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		source.Inherited = depth > 0
+		// Источники отсортированы по силе роли, первый определяет итоговую роль
+		if effective.Role == "" {
+			effective.Role = source.Role
+		}
+		// Делиться можно по любому явному основанию с allow_share; ссылка таких прав не даёт
+		if source.Type != models.PermissionSourceLink {
+			effective.AllowShare = effective.AllowShare || source.AllowShare
+		}
+		effective.Sources = append(effective.Sources, source)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Нет ни одного основания: отличаем отсутствие доступа от несуществующего файла или пользователя
+	if len(effective.Sources) == 0 {
+		var fileExists, userExists bool
+		err := r.db.QueryRowContext(ctx, `SELECT
+				EXISTS(SELECT 1 FROM homecloud.files WHERE id=$1),
+				EXISTS(SELECT 1 FROM homecloud.users WHERE id=$2)`, fileID, userID).Scan(&fileExists, &userExists)
+		if err != nil {
+			return nil, err
+		}
+		if !fileExists {
+			return nil, errdefs.ErrFileNotFound
+		}
+		if !userExists {
+			return nil, errdefs.ErrUserNotFound
+		}
+	}
+	return effective, nil
+}
+
+// SweepExpiredPermissions удаляет права с истёкшим сроком и записывает удалённые
+// строки в homecloud.expired_permissions_log
+func (r *dbRepository) SweepExpiredPermissions(ctx context.Context) ([]*models.FilePermission, error) {
+	query := `WITH removed AS (
+			DELETE FROM homecloud.file_permissions WHERE expires_at <= NOW()
+			RETURNING id, file_id, grantee_id, grantee_type, domain, role, allow_share, created_at, expires_at
+		), logged AS (
+			INSERT INTO homecloud.expired_permissions_log (permission_id, file_id, grantee_id, grantee_type, domain, role, allow_share, granted_at, expired_at, removed_at)
+			SELECT id, file_id, grantee_id, grantee_type, domain, role, allow_share, created_at, expires_at, NOW() FROM removed
+		)
+		SELECT id, file_id, grantee_id, grantee_type, domain, role, allow_share, created_at, expires_at FROM removed`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []*models.FilePermission
+	for rows.Next() {
+		permission := &models.FilePermission{}
+		err := rows.Scan(
+			&permission.ID, &permission.FileID, &permission.GranteeID, &permission.GranteeType, &permission.Domain, &permission.Role, &permission.AllowShare, &permission.CreatedAt, &permission.ExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, rows.Err()
+}
+
 // File metadata operations
 func (r *dbRepository) UpdateFileMetadata(ctx context.Context, fileID, metadata string) error {
 	_, err := r.db.ExecContext(ctx, `UPDATE homecloud.files SET indexable_text=$1, updated_at=NOW() WHERE id=$2`, metadata, fileID)
@@ -668,6 +1375,114 @@ func (r *dbRepository) RenameFile(ctx context.Context, fileID, newName string) e
 	return err
 }
 
+// fileSubtreeCTE - рекурсивный CTE "subtree" с файлом $1 и всем его содержимым вниз по parent_id
+const fileSubtreeCTE = `WITH RECURSIVE subtree AS (
+	SELECT id, ARRAY[id] AS path FROM homecloud.files WHERE id=$1
+	UNION ALL
+	SELECT f.id, s.path || f.id
+	FROM homecloud.files f JOIN subtree s ON f.parent_id = s.id
+	WHERE NOT f.id = ANY(s.path)
+)`
+
+// TransferOwnership передаёт владение файлом $1 и всем его содержимым пользователю newOwnerID
+// в одной транзакции: проверяет квоту нового владельца, переносит занятое место между
+// пользователями и, если задан keepPreviousAsRole, оставляет прежнему владельцу право с этой ролью.
+func (r *dbRepository) TransferOwnership(ctx context.Context, fileID, newOwnerID, keepPreviousAsRole string) (*models.OwnershipTransfer, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	transfer := &models.OwnershipTransfer{FileID: fileID, NewOwnerID: newOwnerID}
+	err = tx.QueryRowContext(ctx, `SELECT owner_id FROM homecloud.files WHERE id=$1 FOR UPDATE`, fileID).Scan(&transfer.PreviousOwnerID)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var quota sql.NullInt64 // NULL - без ограничения
+	var used int64
+	err = tx.QueryRowContext(ctx, `SELECT storage_quota, COALESCE(used_space, 0) FROM homecloud.users WHERE id=$1 FOR UPDATE`, newOwnerID).Scan(&quota, &used)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Меняем владельца и собираем, сколько места освобождается у каждого прежнего владельца.
+	// Содержимое папки может принадлежать разным пользователям.
+	rows, err := tx.QueryContext(ctx, fileSubtreeCTE+`,
+		old AS (
+			SELECT f.id, f.owner_id, f.size, f.is_folder FROM homecloud.files f
+			WHERE f.id IN (SELECT id FROM subtree) AND f.owner_id <> $2
+			FOR UPDATE
+		), moved AS (
+			UPDATE homecloud.files f SET owner_id=$2, updated_at=NOW()
+			FROM old WHERE f.id = old.id
+			RETURNING old.owner_id AS prev_owner, old.size, old.is_folder
+		)
+		SELECT prev_owner, COUNT(*), COALESCE(SUM(size) FILTER (WHERE NOT is_folder), 0) FROM moved GROUP BY prev_owner`,
+		fileID, newOwnerID)
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[string]int64)
+	for rows.Next() {
+		var owner string
+		var count int
+		var size int64
+		if err := rows.Scan(&owner, &count, &size); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		usage[owner] = size
+		transfer.FilesTransferred += count
+		transfer.BytesTransferred += size
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if quota.Valid && used+transfer.BytesTransferred > quota.Int64 {
+		return nil, errdefs.ErrQuotaExceeded
+	}
+	for owner, size := range usage {
+		_, err := tx.ExecContext(ctx, `UPDATE homecloud.users SET used_space=GREATEST(COALESCE(used_space, 0) - $1, 0), updated_at=NOW() WHERE id=$2`, size, owner)
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE homecloud.users SET used_space=COALESCE(used_space, 0) + $1, updated_at=NOW() WHERE id=$2`, transfer.BytesTransferred, newOwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Личные права нового владельца внутри поддерева больше не нужны
+	_, err = tx.ExecContext(ctx, fileSubtreeCTE+`
+		DELETE FROM homecloud.file_permissions
+		WHERE grantee_type='USER' AND grantee_id=$2 AND file_id IN (SELECT id FROM subtree)`, fileID, newOwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if keepPreviousAsRole != "" && transfer.PreviousOwnerID != newOwnerID {
+		_, err = tx.ExecContext(ctx, `INSERT INTO homecloud.file_permissions (file_id, grantee_id, grantee_type, role, allow_share, created_at)
+			VALUES ($1, $2, 'USER', $3, false, NOW())
+			ON CONFLICT (file_id, grantee_id, grantee_type) DO UPDATE SET role = EXCLUDED.role, expires_at = NULL`,
+			fileID, transfer.PreviousOwnerID, keepPreviousAsRole)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return transfer, tx.Commit()
+}
+
 // File integrity operations
 func (r *dbRepository) VerifyFileIntegrity(ctx context.Context, id string) (bool, error) {
 	var exists bool
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSet(t *testing.T) {
+	columns := []updateColumn{
+		{"name", "name", "report.pdf"},
+		{"starred", "starred", true},
+		{"last_login", "last_login_at", nil},
+	}
+
+	tests := []struct {
+		name     string
+		fields   []string
+		wantSet  string
+		wantArgs []interface{}
+		wantErr  error
+	}{
+		{
+			name:    "empty mask",
+			fields:  nil,
+			wantErr: errdefs.ErrEmptyMask,
+		},
+		{
+			name:     "single field",
+			fields:   []string{"starred"},
+			wantSet:  "starred=$1",
+			wantArgs: []interface{}{true},
+		},
+		{
+			name:     "mask order is kept",
+			fields:   []string{"last_login", "name"},
+			wantSet:  "last_login_at=$1, name=$2",
+			wantArgs: []interface{}{nil, "report.pdf"},
+		},
+		{
+			name:     "duplicate paths are written once",
+			fields:   []string{"name", "name"},
+			wantSet:  "name=$1",
+			wantArgs: []interface{}{"report.pdf"},
+		},
+		{
+			name:    "unknown path",
+			fields:  []string{"name", "password_hash"},
+			wantErr: errdefs.ErrInvalidField,
+		},
+		{
+			name:    "column name is not a path",
+			fields:  []string{"last_login_at"},
+			wantErr: errdefs.ErrInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, args, err := updateSet(tt.fields, columns)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSet, set)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+// recordSecurityEvent добавляет событие в журнал в транзакции изменения,
+// поэтому событие записывается тогда и только тогда, когда изменение применено
+func recordSecurityEvent(ctx context.Context, tx *sql.Tx, userID, eventType string, audit models.AuditInfo, details map[string]interface{}) error {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	doc, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO homecloud.security_events (user_id, event_type, actor_id, ip_address, user_agent, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())`,
+		userID, eventType, audit.ActorID, audit.IPAddress, audit.UserAgent, doc)
+	return err
+}
+
+// encodeSecurityEventCursor - курсор страницы журнала: id последнего события
+func encodeSecurityEventCursor(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// decodeSecurityEventCursor разбирает курсор; пустой курсор - первая страница
+func decodeSecurityEventCursor(cursor string) (sql.NullInt64, error) {
+	if cursor == "" {
+		return sql.NullInt64{}, nil
+	}
+	id, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || id <= 0 {
+		return sql.NullInt64{}, errdefs.ErrInvalidCursor
+	}
+	return sql.NullInt64{Int64: id, Valid: true}, nil
+}
+
+// ListSecurityEvents возвращает страницу журнала пользователя от новых событий к старым.
+// Курсор - id последнего события предыдущей страницы.
+func (r *dbRepository) ListSecurityEvents(ctx context.Context, userID string, pageSize int, cursor string) (*models.SecurityEventPage, error) {
+	after, err := decodeSecurityEventCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Берём на одну запись больше, чтобы узнать, есть ли следующая страница
+	rows, err := r.db.QueryContext(ctx, `SELECT id, user_id, event_type, actor_id, ip_address, user_agent, details, created_at
+		FROM homecloud.security_events
+		WHERE user_id=$1 AND ($2::bigint IS NULL OR id < $2)
+		ORDER BY id DESC LIMIT $3`, userID, after, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &models.SecurityEventPage{}
+	for rows.Next() {
+		event := &models.SecurityEvent{}
+		var actorID, ipAddress, userAgent sql.NullString
+		var details []byte
+		if err := rows.Scan(&event.ID, &event.UserID, &event.EventType, &actorID, &ipAddress, &userAgent, &details, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		if actorID.Valid {
+			event.ActorID = &actorID.String
+		}
+		if ipAddress.Valid {
+			event.IPAddress = &ipAddress.String
+		}
+		if userAgent.Valid {
+			event.UserAgent = &userAgent.String
+		}
+		if err := json.Unmarshal(details, &event.Details); err != nil {
+			return nil, err
+		}
+		page.Events = append(page.Events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Events) > pageSize {
+		page.Events = page.Events[:pageSize]
+		page.NextCursor = encodeSecurityEventCursor(page.Events[pageSize-1].ID)
+	}
+	return page, nil
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityEventCursor(t *testing.T) {
+	after, err := decodeSecurityEventCursor("")
+	require.NoError(t, err)
+	require.False(t, after.Valid, "empty cursor is the first page")
+
+	for _, id := range []int64{1, 42, 9223372036854775807} {
+		after, err := decodeSecurityEventCursor(encodeSecurityEventCursor(id))
+		require.NoError(t, err)
+		require.True(t, after.Valid)
+		require.Equal(t, id, after.Int64)
+	}
+
+	for _, cursor := range []string{"0", "-5", "abc", "1.5", " 7", "9223372036854775808"} {
+		_, err := decodeSecurityEventCursor(cursor)
+		require.True(t, errors.Is(err, errdefs.ErrInvalidCursor), "cursor %q: got %v", cursor, err)
+	}
+}
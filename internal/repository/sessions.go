@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+const refreshTokenBytes = 32
+
+const sessionColumns = `id, family_id, user_id, device_name, ip_address, user_agent, created_at, last_used_at, expires_at, revoked_at, revoked_reason`
+
+func scanSession(row rowScanner) (*models.Session, error) {
+	session := &models.Session{}
+	err := row.Scan(
+		&session.ID, &session.FamilyID, &session.UserID, &session.DeviceName, &session.IPAddress, &session.UserAgent, &session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt, &session.RevokedAt, &session.RevokedReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// CreateSession создаёт сессию с новой семьёй токенов и возвращает её вместе с refresh-токеном.
+// Токен возвращается только здесь и при ротации.
+func (r *dbRepository) CreateSession(ctx context.Context, session *models.Session) (*models.Session, string, error) {
+	token, err := newToken(refreshTokenBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	created, err := scanSession(r.db.QueryRowContext(ctx, `INSERT INTO homecloud.sessions
+			(family_id, user_id, refresh_token_hash, device_name, ip_address, user_agent, created_at, last_used_at, expires_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, NOW(), NOW(), $6)
+		RETURNING `+sessionColumns,
+		session.UserID, hashToken(token), session.DeviceName, session.IPAddress, session.UserAgent, session.ExpiresAt,
+	))
+	if isForeignKeyViolation(err) {
+		return nil, "", errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return created, token, nil
+}
+
+// RotateSession обменивает refresh-токен на новый. Старый токен становится недействительным.
+// Повторное предъявление уже обменянного токена означает его утечку: вся семья отзывается.
+func (r *dbRepository) RotateSession(ctx context.Context, refreshToken string, ipAddress, userAgent *string) (*models.SessionRotation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var expired bool
+	current := &models.Session{}
+	err = tx.QueryRowContext(ctx, `SELECT `+sessionColumns+`, expires_at <= NOW()
+		FROM homecloud.sessions WHERE refresh_token_hash=$1 FOR UPDATE`, hashToken(refreshToken)).Scan(
+		&current.ID, &current.FamilyID, &current.UserID, &current.DeviceName, &current.IPAddress, &current.UserAgent, &current.CreatedAt, &current.LastUsedAt, &current.ExpiresAt, &current.RevokedAt, &current.RevokedReason, &expired,
+	)
+	if err == sql.ErrNoRows {
+		return &models.SessionRotation{Status: models.SessionNotFound}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case current.RevokedReason != nil && *current.RevokedReason == models.SessionRevokedRotated:
+		_, err := tx.ExecContext(ctx, `UPDATE homecloud.sessions SET revoked_at=NOW(), revoked_reason=$2
+			WHERE family_id=$1 AND revoked_at IS NULL`, current.FamilyID, models.SessionRevokedReuseDetected)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return &models.SessionRotation{Status: models.SessionReuseDetected, Session: current}, nil
+	case current.RevokedAt != nil:
+		return &models.SessionRotation{Status: models.SessionRevoked, Session: current}, nil
+	case expired:
+		return &models.SessionRotation{Status: models.SessionExpired, Session: current}, nil
+	}
+
+	token, err := newToken(refreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	if ipAddress == nil {
+		ipAddress = current.IPAddress
+	}
+	if userAgent == nil {
+		userAgent = current.UserAgent
+	}
+	// Новая строка продолжает тот же вход: created_at и expires_at не меняются
+	next, err := scanSession(tx.QueryRowContext(ctx, `INSERT INTO homecloud.sessions
+			(family_id, user_id, refresh_token_hash, device_name, ip_address, user_agent, created_at, last_used_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), $8)
+		RETURNING `+sessionColumns,
+		current.FamilyID, current.UserID, hashToken(token), current.DeviceName, ipAddress, userAgent, current.CreatedAt, current.ExpiresAt,
+	))
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE homecloud.sessions SET revoked_at=NOW(), revoked_reason=$2, replaced_by=$3 WHERE id=$1`,
+		current.ID, models.SessionRevokedRotated, next.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &models.SessionRotation{Status: models.SessionRotated, Session: next, RefreshToken: token}, nil
+}
+
+// ListSessions возвращает действующие сессии пользователя, по одной на вход
+func (r *dbRepository) ListSessions(ctx context.Context, userID string) ([]*models.Session, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+sessionColumns+` FROM homecloud.sessions
+		WHERE user_id=$1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// RevokeSession отзывает вход, которому принадлежит сессия id, вместе со всеми его ротациями
+func (r *dbRepository) RevokeSession(ctx context.Context, id string) error {
+	var found bool
+	err := r.db.QueryRowContext(ctx, `WITH target AS (
+			SELECT family_id FROM homecloud.sessions WHERE id=$1
+		), revoked AS (
+			UPDATE homecloud.sessions SET revoked_at=NOW(), revoked_reason=$2
+			WHERE family_id IN (SELECT family_id FROM target) AND revoked_at IS NULL
+		)
+		SELECT EXISTS(SELECT 1 FROM target)`, id, models.SessionRevokedByUser).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errdefs.ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeAllSessions отзывает все сессии пользователя, кроме входа exceptSessionID (если задан),
+// и возвращает число отозванных сессий
+func (r *dbRepository) RevokeAllSessions(ctx context.Context, userID, exceptSessionID string) (int, error) {
+	var except interface{}
+	if exceptSessionID != "" {
+		except = exceptSessionID
+	}
+	res, err := r.db.ExecContext(ctx, `UPDATE homecloud.sessions SET revoked_at=NOW(), revoked_reason=$3
+		WHERE user_id=$1 AND revoked_at IS NULL
+		  AND family_id NOT IN (SELECT family_id FROM homecloud.sessions WHERE id = $2::uuid)`,
+		userID, except, models.SessionRevokedByUser)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
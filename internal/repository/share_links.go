@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+const shareLinkTokenBytes = 32
+
+const shareLinkColumns = `id, file_id, role, created_by, created_at, expires_at, password_hash, max_uses, use_count, last_used_at, revoked, revoked_at`
+
+func scanShareLink(row rowScanner) (*models.ShareLink, error) {
+	link := &models.ShareLink{}
+	err := row.Scan(
+		&link.ID, &link.FileID, &link.Role, &link.CreatedBy, &link.CreatedAt, &link.ExpiresAt, &link.PasswordHash, &link.MaxUses, &link.UseCount, &link.LastUsedAt, &link.Revoked, &link.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// hashToken - SHA-256 от токена в hex. В базе хранятся только хеши токенов.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newToken генерирует случайный URL-safe токен
+func newToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreateShareLink создаёт ссылку и возвращает её id и токен.
+// Токен возвращается только здесь, повторно получить его нельзя.
+func (r *dbRepository) CreateShareLink(ctx context.Context, link *models.ShareLink) (string, string, error) {
+	token, err := newToken(shareLinkTokenBytes)
+	if err != nil {
+		return "", "", err
+	}
+	query := `INSERT INTO homecloud.share_links (file_id, token_hash, role, created_by, created_at, expires_at, password_hash, max_uses)
+		VALUES ($1, $2, $3, $4, NOW(), $5, $6, $7) RETURNING id`
+	var id string
+	err = r.db.QueryRowContext(ctx, query,
+		link.FileID, hashToken(token), link.Role, link.CreatedBy, link.ExpiresAt, link.PasswordHash, link.MaxUses,
+	).Scan(&id)
+	if isForeignKeyViolation(err) {
+		return "", "", errdefs.ErrFileNotFound
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return id, token, nil
+}
+
+func (r *dbRepository) ListShareLinks(ctx context.Context, fileID string) ([]*models.ShareLink, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+shareLinkColumns+` FROM homecloud.share_links WHERE file_id=$1 ORDER BY created_at DESC`, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*models.ShareLink
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+func (r *dbRepository) RevokeShareLink(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE homecloud.share_links SET revoked=true, revoked_at=COALESCE(revoked_at, NOW()) WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrShareLinkNotFound
+	}
+	return nil
+}
+
+// ResolveShareLink проверяет ссылку по токену и, если она действительна, засчитывает
+// использование. Ссылка с паролем засчитывается только при passwordVerified:
+// пароль по password_hash проверяет вызывающий сервис.
+func (r *dbRepository) ResolveShareLink(ctx context.Context, token string, passwordVerified bool) (*models.ShareLinkResolution, error) {
+	tokenHash := hashToken(token)
+	query := `UPDATE homecloud.share_links l SET use_count = l.use_count + 1, last_used_at = NOW()
+		FROM homecloud.files f
+		WHERE l.token_hash=$1 AND f.id = l.file_id AND NOT f.is_trashed
+		  AND NOT l.revoked
+		  AND (l.expires_at IS NULL OR l.expires_at > NOW())
+		  AND (l.max_uses IS NULL OR l.use_count < l.max_uses)
+		  AND (l.password_hash IS NULL OR $2)
+		RETURNING l.id, l.file_id, l.role, l.created_by, l.created_at, l.expires_at, l.password_hash, l.max_uses, l.use_count, l.last_used_at, l.revoked, l.revoked_at`
+	link, err := scanShareLink(r.db.QueryRowContext(ctx, query, tokenHash, passwordVerified))
+	if err == nil {
+		return &models.ShareLinkResolution{Status: models.ShareLinkValid, Link: link}, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	// Ссылка не прошла проверку - выясняем причину
+	// (время сравнивается на стороне базы, как и в UPDATE выше)
+	var expired, fileTrashed bool
+	row := r.db.QueryRowContext(ctx, `SELECT `+shareLinkColumns+`,
+			COALESCE(expires_at <= NOW(), false),
+			(SELECT is_trashed FROM homecloud.files WHERE id = l.file_id)
+		FROM homecloud.share_links l WHERE token_hash=$1`, tokenHash)
+	link = &models.ShareLink{}
+	err = row.Scan(
+		&link.ID, &link.FileID, &link.Role, &link.CreatedBy, &link.CreatedAt, &link.ExpiresAt, &link.PasswordHash, &link.MaxUses, &link.UseCount, &link.LastUsedAt, &link.Revoked, &link.RevokedAt, &expired, &fileTrashed,
+	)
+	if err == sql.ErrNoRows {
+		return &models.ShareLinkResolution{Status: models.ShareLinkNotFound}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Строка могла измениться между UPDATE и SELECT (например, файл восстановили из корзины).
+	// Если ни одна причина не подошла, ссылку считаем временно недоступной.
+	status := models.ShareLinkFileUnavailable
+	switch {
+	case link.Revoked:
+		status = models.ShareLinkRevoked
+	case expired:
+		status = models.ShareLinkExpired
+	case link.MaxUses != nil && link.UseCount >= *link.MaxUses:
+		status = models.ShareLinkUseLimitReached
+	case fileTrashed:
+		status = models.ShareLinkFileUnavailable
+	case link.PasswordHash != nil && !passwordVerified:
+		status = models.ShareLinkPasswordRequired
+	}
+	return &models.ShareLinkResolution{Status: status, Link: link}, nil
+}
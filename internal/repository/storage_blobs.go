@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+// Счётчики ссылок в homecloud.storage_blobs поддерживаются триггерами
+// на files и file_revisions (см. migrations/008_create_storage_blobs_table.up.sql).
+
+const storageBlobColumns = `storage_path, sha256_checksum, size, ref_count, created_at, released_at`
+
+func scanStorageBlob(row rowScanner) (*models.StorageBlob, error) {
+	blob := &models.StorageBlob{}
+	err := row.Scan(&blob.StoragePath, &blob.SHA256Checksum, &blob.Size, &blob.RefCount, &blob.CreatedAt, &blob.ReleasedAt)
+	if err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// FindBySHA256 ищет живой объект с данным содержимым. Если ownerID пустой,
+// поиск идёт по всему хранилищу, иначе только среди файлов и ревизий владельца.
+func (r *dbRepository) FindBySHA256(ctx context.Context, ownerID, sha256 string) (*models.StorageBlob, error) {
+	var row *sql.Row
+	if ownerID == "" {
+		row = r.db.QueryRowContext(ctx, `SELECT `+storageBlobColumns+` FROM homecloud.storage_blobs
+			WHERE sha256_checksum=$1 AND ref_count > 0 ORDER BY created_at LIMIT 1`, sha256)
+	} else {
+		row = r.db.QueryRowContext(ctx, `SELECT `+storageBlobColumns+` FROM homecloud.storage_blobs b
+			WHERE b.sha256_checksum=$1 AND b.ref_count > 0
+			  AND (EXISTS(SELECT 1 FROM homecloud.files f WHERE f.storage_path=b.storage_path AND f.owner_id=$2)
+			    OR EXISTS(SELECT 1 FROM homecloud.file_revisions fr JOIN homecloud.files f ON f.id=fr.file_id
+			              WHERE fr.storage_path=b.storage_path AND f.owner_id=$2))
+			ORDER BY b.created_at LIMIT 1`, sha256, ownerID)
+	}
+	blob, err := scanStorageBlob(row)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrBlobNotFound
+	}
+	return blob, err
+}
+
+func (r *dbRepository) GetStorageBlob(ctx context.Context, storagePath string) (*models.StorageBlob, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+storageBlobColumns+` FROM homecloud.storage_blobs WHERE storage_path=$1`, storagePath)
+	blob, err := scanStorageBlob(row)
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrBlobNotFound
+	}
+	return blob, err
+}
+
+// ListUnreferencedBlobs возвращает объекты, на которые больше нет ссылок,
+// начиная с давно освобождённых.
+func (r *dbRepository) ListUnreferencedBlobs(ctx context.Context, limit int) ([]*models.StorageBlob, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+storageBlobColumns+` FROM homecloud.storage_blobs
+		WHERE ref_count = 0 ORDER BY released_at LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blobs []*models.StorageBlob
+	for rows.Next() {
+		blob, err := scanStorageBlob(rows)
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, rows.Err()
+}
+
+// ReleaseBlob удаляет запись об объекте, только если на него по-прежнему нет ссылок.
+// false означает, что объект снова используется и стирать его нельзя.
+func (r *dbRepository) ReleaseBlob(ctx context.Context, storagePath string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM homecloud.storage_blobs WHERE storage_path=$1 AND ref_count = 0`, storagePath)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *dbRepository) FindDuplicates(ctx context.Context, ownerID string) ([]*models.DuplicateGroup, error) {
+	query := `SELECT ` + fileColumns + ` FROM homecloud.files
+		WHERE owner_id=$1 AND NOT is_folder AND NOT is_trashed
+		  AND sha256_checksum IN (
+		    SELECT sha256_checksum FROM homecloud.files
+		    WHERE owner_id=$1 AND NOT is_folder AND NOT is_trashed AND sha256_checksum IS NOT NULL
+		    GROUP BY sha256_checksum HAVING COUNT(*) > 1)
+		ORDER BY sha256_checksum, created_at`
+	rows, err := r.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*models.DuplicateGroup
+	var current *models.DuplicateGroup
+	paths := map[string]bool{}
+	flush := func() {
+		if current == nil {
+			return
+		}
+		// Файлы, уже разделяющие один storage_path, места не тратят
+		current.WastedBytes = current.Size * int64(len(paths)-1)
+		groups = append(groups, current)
+	}
+	for rows.Next() {
+		file, err := scanFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil || current.SHA256Checksum != *file.SHA256Checksum {
+			flush()
+			current = &models.DuplicateGroup{SHA256Checksum: *file.SHA256Checksum, Size: file.Size}
+			paths = map[string]bool{}
+		}
+		current.Files = append(current.Files, file)
+		paths[file.StoragePath] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return groups, nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"strings"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const recoveryCodeCount = 10
+
+// SaveTwoFactorSecret сохраняет зашифрованный секрет до подтверждения. Повторная выдача
+// заменяет неподтверждённый секрет, включённую 2FA перезаписать нельзя.
+func (r *dbRepository) SaveTwoFactorSecret(ctx context.Context, userID string, encryptedSecret []byte) error {
+	var saved bool
+	err := r.db.QueryRowContext(ctx, `WITH saved AS (
+			INSERT INTO homecloud.user_two_factor (user_id, secret_encrypted, enabled, enrolled_at)
+			SELECT id, $2, false, NOW() FROM homecloud.users WHERE id=$1
+			ON CONFLICT (user_id) DO UPDATE
+				SET secret_encrypted = EXCLUDED.secret_encrypted, enrolled_at = NOW(), last_used_step = NULL
+				WHERE NOT homecloud.user_two_factor.enabled
+			RETURNING user_id
+		)
+		SELECT EXISTS(SELECT 1 FROM saved)`, userID, encryptedSecret).Scan(&saved)
+	if err != nil {
+		return err
+	}
+	if saved {
+		return nil
+	}
+
+	var userExists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM homecloud.users WHERE id=$1)`, userID).Scan(&userExists); err != nil {
+		return err
+	}
+	if !userExists {
+		return errdefs.ErrUserNotFound
+	}
+	return errdefs.ErrTwoFactorAlreadyEnabled
+}
+
+// twoFactorColumns - колонки user_two_factor t и число неиспользованных резервных кодов
+const twoFactorColumns = `t.user_id, t.secret_encrypted, t.enabled, t.enrolled_at, t.enabled_at, t.last_used_step,
+	(SELECT COUNT(*) FROM homecloud.user_recovery_codes c WHERE c.user_id = t.user_id AND c.used_at IS NULL)`
+
+func scanTwoFactor(row rowScanner) (*models.TwoFactor, error) {
+	tf := &models.TwoFactor{}
+	err := row.Scan(&tf.UserID, &tf.EncryptedSecret, &tf.Enabled, &tf.EnrolledAt, &tf.EnabledAt, &tf.LastUsedStep, &tf.RecoveryCodesRemaining)
+	if err != nil {
+		return nil, err
+	}
+	return tf, nil
+}
+
+func (r *dbRepository) GetTwoFactor(ctx context.Context, userID string) (*models.TwoFactor, error) {
+	tf, err := scanTwoFactor(r.db.QueryRowContext(ctx, `SELECT `+twoFactorColumns+`
+		FROM homecloud.user_two_factor t WHERE t.user_id=$1`, userID))
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tf, nil
+}
+
+// ListTwoFactors загружает 2FA сразу для нескольких пользователей одним запросом.
+// Пользователей без 2FA в результате нет.
+func (r *dbRepository) ListTwoFactors(ctx context.Context, userIDs []string) (map[string]*models.TwoFactor, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+twoFactorColumns+`
+		FROM homecloud.user_two_factor t WHERE t.user_id = ANY($1::uuid[])`, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	twoFactors := make(map[string]*models.TwoFactor, len(userIDs))
+	for rows.Next() {
+		tf, err := scanTwoFactor(rows)
+		if err != nil {
+			return nil, err
+		}
+		twoFactors[tf.UserID] = tf
+	}
+	return twoFactors, rows.Err()
+}
+
+// EnableTwoFactor включает 2FA после проверки кода из интервала step и выдаёт новые
+// резервные коды. Коды возвращаются только здесь, в базе хранятся их хеши.
+func (r *dbRepository) EnableTwoFactor(ctx context.Context, userID string, step int64) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE homecloud.user_two_factor SET enabled=true, enabled_at=NOW(), last_used_step=$2
+		WHERE user_id=$1 AND NOT enabled`, userID, step)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, errdefs.ErrTwoFactorAlreadyEnabled
+	}
+
+	codes, err := replaceRecoveryCodes(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return codes, tx.Commit()
+}
+
+// RegenerateRecoveryCodes выдаёт новый набор резервных кодов взамен прежнего. Код из
+// интервала step засчитывается как в UseTwoFactorStep; повтор кода даёт nil без ошибки.
+func (r *dbRepository) RegenerateRecoveryCodes(ctx context.Context, userID string, step int64) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE homecloud.user_two_factor SET last_used_step=$2
+		WHERE user_id=$1 AND enabled AND (last_used_step IS NULL OR last_used_step < $2)`, userID, step)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, nil
+	}
+
+	codes, err := replaceRecoveryCodes(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return codes, tx.Commit()
+}
+
+// replaceRecoveryCodes удаляет все резервные коды пользователя и создаёт новые
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID string) ([]string, error) {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM homecloud.user_recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return nil, err
+	}
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		_, err = tx.ExecContext(ctx, `INSERT INTO homecloud.user_recovery_codes (user_id, code_hash, created_at) VALUES ($1, $2, NOW())`,
+			userID, hashToken(normalizeRecoveryCode(code)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
+
+// UseTwoFactorStep засчитывает код из интервала step. Код из уже использованного
+// или более раннего интервала отклоняется - так один код нельзя предъявить дважды.
+func (r *dbRepository) UseTwoFactorStep(ctx context.Context, userID string, step int64) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `UPDATE homecloud.user_two_factor SET last_used_step=$2
+		WHERE user_id=$1 AND enabled AND (last_used_step IS NULL OR last_used_step < $2)`, userID, step)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// ConsumeRecoveryCode помечает резервный код использованным и возвращает,
+// подошёл ли код и сколько неиспользованных кодов осталось
+func (r *dbRepository) ConsumeRecoveryCode(ctx context.Context, userID, code string) (bool, int, error) {
+	var used bool
+	var remaining int
+	err := r.db.QueryRowContext(ctx, `WITH used AS (
+			UPDATE homecloud.user_recovery_codes SET used_at=NOW()
+			WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL
+			  AND EXISTS(SELECT 1 FROM homecloud.user_two_factor WHERE user_id=$1 AND enabled)
+			RETURNING id
+		)
+		SELECT EXISTS(SELECT 1 FROM used),
+			(SELECT COUNT(*) FROM homecloud.user_recovery_codes WHERE user_id=$1 AND used_at IS NULL) - (SELECT COUNT(*) FROM used)`,
+		userID, hashToken(normalizeRecoveryCode(code))).Scan(&used, &remaining)
+	if err != nil {
+		return false, 0, err
+	}
+	return used, remaining, nil
+}
+
+// recoveryCodeEncoding - base32 в нижнем регистре: 32 символа без различия регистра,
+// каждый символ несёт ровно 5 случайных бит
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// newRecoveryCode генерирует код вида xxxx-xxxx-xxxx (60 случайных бит)
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := recoveryCodeEncoding.EncodeToString(b)[:12]
+	return code[:4] + "-" + code[4:8] + "-" + code[8:], nil
+}
+
+// normalizeRecoveryCode убирает разделитель и регистр, чтобы код можно было вводить как угодно
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
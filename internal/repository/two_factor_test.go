@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecoveryCode(t *testing.T) {
+	format := regexp.MustCompile(`^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`)
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		code, err := newRecoveryCode()
+		require.NoError(t, err)
+		require.Regexp(t, format, code)
+		require.False(t, seen[code], "duplicate recovery code %s", code)
+		seen[code] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"abcd-efgh-ijkl", "abcdefghijkl"},
+		{" ABCD-EFGH-IJKL ", "abcdefghijkl"},
+		{"abcdefghijkl", "abcdefghijkl"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, normalizeRecoveryCode(tt.in))
+	}
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+const userTokenBytes = 32
+
+const userTokenColumns = `id, user_id, purpose, new_email, created_at, expires_at, used_at`
+
+func scanUserToken(row rowScanner) (*models.UserToken, error) {
+	token := &models.UserToken{}
+	err := row.Scan(&token.ID, &token.UserID, &token.Purpose, &token.NewEmail, &token.CreatedAt, &token.ExpiresAt, &token.UsedAt)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// IssueToken выпускает одноразовый токен и возвращает его вместе с сохранённой записью.
+// Неиспользованные токены пользователя с тем же назначением перестают действовать.
+func (r *dbRepository) IssueToken(ctx context.Context, token *models.UserToken) (*models.UserToken, string, error) {
+	secret, err := newToken(userTokenBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer tx.Rollback()
+
+	var newEmail *string
+	if token.NewEmail != nil {
+		normalized := normalizeEmail(*token.NewEmail)
+		newEmail = &normalized
+		var taken bool
+		err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM homecloud.users WHERE lower(email)=$1)`, normalized).Scan(&taken)
+		if err != nil {
+			return nil, "", err
+		}
+		if taken {
+			return nil, "", errdefs.ErrEmailExists
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM homecloud.user_tokens WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL`,
+		token.UserID, token.Purpose)
+	if err != nil {
+		return nil, "", err
+	}
+	issued, err := scanUserToken(tx.QueryRowContext(ctx, `INSERT INTO homecloud.user_tokens (user_id, purpose, token_hash, new_email, created_at, expires_at)
+		SELECT id, $2, $3, $4, NOW(), $5 FROM homecloud.users WHERE id=$1
+		RETURNING `+userTokenColumns,
+		token.UserID, token.Purpose, hashToken(secret), newEmail, token.ExpiresAt,
+	))
+	if err == sql.ErrNoRows {
+		return nil, "", errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, "", err
+	}
+	return issued, secret, nil
+}
+
+// ConsumeToken атомарно помечает токен использованным и в той же транзакции применяет его:
+// EMAIL_VERIFICATION подтверждает email, PASSWORD_RESET устанавливает newPasswordHash,
+// снимает блокировку и отзывает сессии, EMAIL_CHANGE меняет email на подтверждённый новый.
+// Если применить токен не удалось, он остаётся неиспользованным.
+// Изменения аккаунта записываются в журнал событий безопасности.
+func (r *dbRepository) ConsumeToken(ctx context.Context, secret, purpose, newPasswordHash string, historyDepth int, audit models.AuditInfo) (*models.TokenConsumption, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	tokenHash := hashToken(secret)
+	token, err := scanUserToken(tx.QueryRowContext(ctx, `UPDATE homecloud.user_tokens SET used_at=NOW()
+		WHERE token_hash=$1 AND purpose=$2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING `+userTokenColumns, tokenHash, purpose))
+	if err == sql.ErrNoRows {
+		return r.diagnoseToken(ctx, tokenHash, purpose)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"token_purpose": token.Purpose}
+	switch token.Purpose {
+	case models.TokenPurposeEmailVerification:
+		_, err = tx.ExecContext(ctx, `UPDATE homecloud.users SET is_email_verified=true, updated_at=NOW() WHERE id=$1`, token.UserID)
+		if err == nil {
+			err = recordSecurityEvent(ctx, tx, token.UserID, models.SecurityEventEmailVerified, audit, details)
+		}
+	case models.TokenPurposePasswordReset:
+		var wasLocked bool
+		err = tx.QueryRowContext(ctx, `WITH prev AS (
+				SELECT id, COALESCE(locked_until > NOW(), false) AS locked FROM homecloud.users WHERE id=$1 FOR UPDATE
+			)
+			UPDATE homecloud.users u
+			SET password_hash=$2, failed_login_attempts=0, locked_until=NULL, updated_at=NOW()
+			FROM prev WHERE u.id = prev.id
+			RETURNING prev.locked`, token.UserID, newPasswordHash).Scan(&wasLocked)
+		if err == sql.ErrNoRows {
+			return nil, errdefs.ErrUserNotFound
+		}
+		if err == nil {
+			err = recordPassword(ctx, tx, token.UserID, newPasswordHash, historyDepth)
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `UPDATE homecloud.sessions SET revoked_at=NOW(), revoked_reason=$2
+				WHERE user_id=$1 AND revoked_at IS NULL`, token.UserID, models.SessionRevokedByUser)
+		}
+		if err == nil {
+			err = recordSecurityEvent(ctx, tx, token.UserID, models.SecurityEventPasswordChanged, audit, details)
+		}
+		if err == nil && wasLocked {
+			err = recordSecurityEvent(ctx, tx, token.UserID, models.SecurityEventAccountUnlocked, audit, details)
+		}
+	case models.TokenPurposeEmailChange:
+		_, err = tx.ExecContext(ctx, `UPDATE homecloud.users SET email=$2, is_email_verified=true, updated_at=NOW() WHERE id=$1`,
+			token.UserID, *token.NewEmail)
+		if isUniqueViolation(err) {
+			return nil, errdefs.ErrEmailExists
+		}
+		if err == nil {
+			details["email"] = *token.NewEmail
+			err = recordSecurityEvent(ctx, tx, token.UserID, models.SecurityEventEmailVerified, audit, details)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &models.TokenConsumption{Status: models.TokenConsumed, Token: token}, nil
+}
+
+// diagnoseToken выясняет, почему токен не удалось использовать
+// (время сравнивается на стороне базы, как и в ConsumeToken)
+func (r *dbRepository) diagnoseToken(ctx context.Context, tokenHash, purpose string) (*models.TokenConsumption, error) {
+	var expired bool
+	token := &models.UserToken{}
+	err := r.db.QueryRowContext(ctx, `SELECT `+userTokenColumns+`, expires_at <= NOW()
+		FROM homecloud.user_tokens WHERE token_hash=$1 AND purpose=$2`, tokenHash, purpose).Scan(
+		&token.ID, &token.UserID, &token.Purpose, &token.NewEmail, &token.CreatedAt, &token.ExpiresAt, &token.UsedAt, &expired,
+	)
+	if err == sql.ErrNoRows {
+		return &models.TokenConsumption{Status: models.TokenNotFound}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var status string
+	switch {
+	case token.UsedAt != nil:
+		status = models.TokenAlreadyUsed
+	case expired:
+		status = models.TokenExpired
+	default:
+		// Токен использован параллельным запросом, который ещё не завершился
+		status = models.TokenAlreadyUsed
+	}
+	return &models.TokenConsumption{Status: status, Token: token}, nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+const userColumns = `id, email, username, password_hash, is_active, is_email_verified, role, storage_quota, COALESCE(used_space, 0), created_at, updated_at, failed_login_attempts, locked_until, last_login_at`
+
+func scanUser(row rowScanner, extra ...interface{}) (*models.User, error) {
+	user := &models.User{}
+	dest := []interface{}{
+		&user.ID, &user.Email, &user.Username, &user.PasswordHash, &user.IsActive, &user.IsEmailVerified, &user.Role, &user.StorageQuota, &user.UsedSpace, &user.CreatedAt, &user.UpdatedAt, &user.FailedLoginAttempts, &user.LockedUntil, &user.LastLogin,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// userSortColumns - выражения сортировки. NULL в last_login_at заменяется на -infinity,
+// а в used_space на 0, чтобы ключ страницы всегда был определён.
+var userSortColumns = map[string]struct{ expr, cast string }{
+	models.UserSortCreatedAt:   {"created_at", "timestamptz"},
+	models.UserSortLastLoginAt: {"COALESCE(last_login_at, '-infinity'::timestamptz)", "timestamptz"},
+	models.UserSortEmail:       {"email", "text"},
+	models.UserSortUsername:    {"username", "text"},
+	models.UserSortUsedSpace:   {"COALESCE(used_space, 0)", "bigint"},
+}
+
+// userCursor - позиция последней строки страницы. Значение ключа хранится в текстовом
+// виде Postgres, чтобы сравнение шло без потери точности.
+type userCursor struct {
+	Field      string `json:"f"`
+	Descending bool   `json:"d"`
+	Value      string `json:"v"`
+	ID         string `json:"id"`
+}
+
+func encodeUserCursor(c userCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Текстовый вид timestamptz в Postgres (DateStyle ISO): смещение бывает часовым или с минутами
+var cursorTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999-07",
+	"2006-01-02 15:04:05.999999-07:00",
+	"2006-01-02 15:04:05.999999-07:00:00",
+}
+
+// decodeUserCursor разбирает курсор и проверяет его содержимое: испорченный курсор
+// должен давать ErrInvalidCursor, а не ошибку приведения типа в базе
+func decodeUserCursor(s string) (*userCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errdefs.ErrInvalidCursor
+	}
+	var c userCursor
+	if err := json.Unmarshal(data, &c); err != nil || !uuidPattern.MatchString(c.ID) {
+		return nil, errdefs.ErrInvalidCursor
+	}
+	column, ok := userSortColumns[c.Field]
+	if !ok || !validCursorValue(column.cast, c.Value) {
+		return nil, errdefs.ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// validCursorValue проверяет, что value приводится к типу cast
+func validCursorValue(cast, value string) bool {
+	switch cast {
+	case "bigint":
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	case "timestamptz":
+		if value == "-infinity" || value == "infinity" {
+			return true
+		}
+		for _, layout := range cursorTimeLayouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// ListUsers возвращает страницу пользователей с постраничной навигацией по ключу:
+// ORDER BY (поле сортировки, id), следующая страница начинается строго после курсора.
+// Курсор привязан к сортировке, с которой он выдан.
+func (r *dbRepository) ListUsers(ctx context.Context, filter models.UserFilter, sort models.UserSort, pageSize int, cursor string) (*models.UserPage, error) {
+	if sort.Field == "" {
+		sort.Field = models.UserSortCreatedAt
+	}
+	column, ok := userSortColumns[sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errdefs.ErrInvalidSort, sort.Field)
+	}
+
+	var conds []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Role != "" {
+		conds = append(conds, "role = "+arg(filter.Role))
+	}
+	if filter.IsActive != nil {
+		conds = append(conds, "COALESCE(is_active, true) = "+arg(*filter.IsActive))
+	}
+	if filter.IsEmailVerified != nil {
+		conds = append(conds, "COALESCE(is_email_verified, false) = "+arg(*filter.IsEmailVerified))
+	}
+	if filter.Locked != nil {
+		conds = append(conds, "(locked_until IS NOT NULL AND locked_until > NOW()) = "+arg(*filter.Locked))
+	}
+	if filter.QuotaExceeded != nil {
+		conds = append(conds, "((storage_quota > 0 AND used_space > storage_quota) IS TRUE) = "+arg(*filter.QuotaExceeded))
+	}
+	if filter.CreatedAfter != nil {
+		conds = append(conds, "created_at >= "+arg(*filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		conds = append(conds, "created_at < "+arg(*filter.CreatedBefore))
+	}
+	if filter.LastLoginAfter != nil {
+		conds = append(conds, "last_login_at >= "+arg(*filter.LastLoginAfter))
+	}
+	if filter.LastLoginBefore != nil {
+		conds = append(conds, "last_login_at < "+arg(*filter.LastLoginBefore))
+	}
+	if filter.Search != "" {
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.Search) + "%"
+		p := arg(pattern)
+		conds = append(conds, "(email ILIKE "+p+" OR username ILIKE "+p+")")
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	page := &models.UserPage{}
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM homecloud.users`+where, args...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	dir, cmp := "ASC", ">"
+	if sort.Descending {
+		dir, cmp = "DESC", "<"
+	}
+	if cursor != "" {
+		c, err := decodeUserCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Field != sort.Field || c.Descending != sort.Descending {
+			return nil, errdefs.ErrInvalidCursor
+		}
+		keyset := fmt.Sprintf("(%s, id) %s (%s::%s, %s::uuid)", column.expr, cmp, arg(c.Value), column.cast, arg(c.ID))
+		if where == "" {
+			where = " WHERE " + keyset
+		} else {
+			where += " AND " + keyset
+		}
+	}
+
+	query := fmt.Sprintf(`SELECT %s, (%s)::text FROM homecloud.users%s ORDER BY %s %s, id %s LIMIT %s`,
+		userColumns, column.expr, where, column.expr, dir, dir, arg(pageSize+1))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lastKey string
+	for rows.Next() {
+		var key string
+		user, err := scanUser(rows, &key)
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Users) == pageSize {
+			// Лишняя строка только показывает, что есть следующая страница
+			last := page.Users[len(page.Users)-1]
+			page.NextCursor = encodeUserCursor(userCursor{Field: sort.Field, Descending: sort.Descending, Value: lastKey, ID: last.ID})
+			break
+		}
+		page.Users = append(page.Users, user)
+		lastKey = key
+	}
+	return page, rows.Err()
+}
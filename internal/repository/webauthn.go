@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const webAuthnColumns = `id, user_id, credential_id, public_key, sign_count, transports, name, created_at, last_used_at`
+
+func scanWebAuthnCredential(row rowScanner) (*models.WebAuthnCredential, error) {
+	cred := &models.WebAuthnCredential{}
+	var signCount int64
+	err := row.Scan(
+		&cred.ID, &cred.UserID, &cred.CredentialID, &cred.PublicKey, &signCount, pq.Array(&cred.Transports), &cred.Name, &cred.CreatedAt, &cred.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cred.SignCount = uint32(signCount)
+	return cred, nil
+}
+
+func (r *dbRepository) RegisterWebAuthnCredential(ctx context.Context, cred *models.WebAuthnCredential) (*models.WebAuthnCredential, error) {
+	transports := cred.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+	created, err := scanWebAuthnCredential(r.db.QueryRowContext(ctx, `INSERT INTO homecloud.webauthn_credentials
+			(user_id, credential_id, public_key, sign_count, transports, name, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING `+webAuthnColumns,
+		cred.UserID, cred.CredentialID, cred.PublicKey, int64(cred.SignCount), pq.Array(transports), cred.Name,
+	))
+	if isUniqueViolation(err) {
+		return nil, errdefs.ErrWebAuthnCredentialExists
+	}
+	if isForeignKeyViolation(err) {
+		return nil, errdefs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetWebAuthnCredential ищет ключ по идентификатору аутентификатора - при входе пользователь ещё неизвестен
+func (r *dbRepository) GetWebAuthnCredential(ctx context.Context, credentialID []byte) (*models.WebAuthnCredential, error) {
+	cred, err := scanWebAuthnCredential(r.db.QueryRowContext(ctx, `SELECT `+webAuthnColumns+`
+		FROM homecloud.webauthn_credentials WHERE credential_id=$1`, credentialID))
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrWebAuthnCredentialNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+func (r *dbRepository) ListWebAuthnCredentials(ctx context.Context, userID string) ([]*models.WebAuthnCredential, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+webAuthnColumns+` FROM homecloud.webauthn_credentials
+		WHERE user_id=$1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var creds []*models.WebAuthnCredential
+	for rows.Next() {
+		cred, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		creds = append(creds, cred)
+	}
+	return creds, rows.Err()
+}
+
+func (r *dbRepository) RenameWebAuthnCredential(ctx context.Context, id, name string) (*models.WebAuthnCredential, error) {
+	cred, err := scanWebAuthnCredential(r.db.QueryRowContext(ctx, `UPDATE homecloud.webauthn_credentials SET name=$2
+		WHERE id=$1 RETURNING `+webAuthnColumns, id, name))
+	if err == sql.ErrNoRows {
+		return nil, errdefs.ErrWebAuthnCredentialNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cred, nil
+}
+
+func (r *dbRepository) DeleteWebAuthnCredential(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM homecloud.webauthn_credentials WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errdefs.ErrWebAuthnCredentialNotFound
+	}
+	return nil
+}
+
+// UpdateWebAuthnSignCount принимает счётчик подписей из успешной проверки входа.
+// Счётчик должен строго расти; если он не вырос, ключ, вероятно, скопирован - вход
+// отклоняется, сохранённое значение не меняется. Аутентификаторы без счётчика всегда
+// присылают 0, для них проверка не проводится.
+func (r *dbRepository) UpdateWebAuthnSignCount(ctx context.Context, credentialID []byte, signCount uint32) (*models.SignCountUpdate, error) {
+	cred, err := scanWebAuthnCredential(r.db.QueryRowContext(ctx, `UPDATE homecloud.webauthn_credentials
+		SET sign_count=$2, last_used_at=NOW()
+		WHERE credential_id=$1 AND ($2 > sign_count OR ($2 = 0 AND sign_count = 0))
+		RETURNING `+webAuthnColumns, credentialID, int64(signCount)))
+	if err == nil {
+		return &models.SignCountUpdate{Status: models.SignCountUpdated, Credential: cred}, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	cred, err = r.GetWebAuthnCredential(ctx, credentialID)
+	if err == errdefs.ErrWebAuthnCredentialNotFound {
+		return &models.SignCountUpdate{Status: models.SignCountNotFound}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.SignCountUpdate{Status: models.SignCountCloneDetected, Credential: cred}, nil
+}
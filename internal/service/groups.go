@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"homecloud--dbmanager-service/internal/interfaces"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+// GroupService implementation
+type groupService struct {
+	repo interfaces.DBRepository
+}
+
+func NewGroupService(repo interfaces.DBRepository) interfaces.GroupService {
+	return &groupService{repo: repo}
+}
+
+func (s *groupService) CreateGroup(ctx context.Context, group *models.Group) (string, error) {
+	return s.repo.CreateGroup(ctx, group)
+}
+
+func (s *groupService) GetGroup(ctx context.Context, id string) (*models.Group, error) {
+	return s.repo.GetGroup(ctx, id)
+}
+
+func (s *groupService) UpdateGroup(ctx context.Context, group *models.Group) error {
+	return s.repo.UpdateGroup(ctx, group)
+}
+
+func (s *groupService) DeleteGroup(ctx context.Context, id string) error {
+	return s.repo.DeleteGroup(ctx, id)
+}
+
+func (s *groupService) ListGroups(ctx context.Context, userID string) ([]*models.Group, error) {
+	return s.repo.ListGroups(ctx, userID)
+}
+
+func (s *groupService) AddGroupMember(ctx context.Context, member *models.GroupMember) error {
+	return s.repo.AddGroupMember(ctx, member)
+}
+
+func (s *groupService) RemoveGroupMember(ctx context.Context, groupID, memberID, memberType string) error {
+	return s.repo.RemoveGroupMember(ctx, groupID, memberID, memberType)
+}
+
+func (s *groupService) ListGroupMembers(ctx context.Context, groupID string) ([]*models.GroupMember, error) {
+	return s.repo.ListGroupMembers(ctx, groupID)
+}
+
+func (s *groupService) GetGroupMemberRole(ctx context.Context, groupID, memberID, memberType string) (string, error) {
+	return s.repo.GetGroupMemberRole(ctx, groupID, memberID, memberType)
+}
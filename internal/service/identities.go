@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"homecloud--dbmanager-service/internal/interfaces"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+// IdentityService implementation
+type identityService struct {
+	repo interfaces.DBRepository
+}
+
+func NewIdentityService(repo interfaces.DBRepository) interfaces.IdentityService {
+	return &identityService{repo: repo}
+}
+
+func (s *identityService) LinkIdentity(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error) {
+	return s.repo.LinkIdentity(ctx, identity)
+}
+
+func (s *identityService) UnlinkIdentity(ctx context.Context, userID, provider, subject string) error {
+	return s.repo.UnlinkIdentity(ctx, userID, provider, subject)
+}
+
+func (s *identityService) ListIdentities(ctx context.Context, userID string) ([]*models.UserIdentity, error) {
+	return s.repo.ListIdentities(ctx, userID)
+}
+
+func (s *identityService) GetUserByIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	return s.repo.GetUserByIdentity(ctx, provider, subject)
+}
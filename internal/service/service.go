@@ -29,20 +29,40 @@ func (s *userService) GetUserByEmail(ctx context.Context, email string) (*models
 	return s.repo.GetUserByEmail(ctx, email)
 }
 
-func (s *userService) UpdateUser(ctx context.Context, user *models.User) error {
-	return s.repo.UpdateUser(ctx, user)
+func (s *userService) ListUsers(ctx context.Context, filter models.UserFilter, sort models.UserSort, pageSize int, cursor string) (*models.UserPage, error) {
+	return s.repo.ListUsers(ctx, filter, sort, pageSize, cursor)
 }
 
-func (s *userService) UpdatePassword(ctx context.Context, id, passwordHash string) error {
-	return s.repo.UpdatePassword(ctx, id, passwordHash)
+func (s *userService) UpdateUser(ctx context.Context, user *models.User, fields []string, audit models.AuditInfo) error {
+	return s.repo.UpdateUser(ctx, user, fields, audit)
+}
+
+func (s *userService) UpdatePassword(ctx context.Context, id, passwordHash string, historyDepth int, audit models.AuditInfo) error {
+	return s.repo.UpdatePassword(ctx, id, passwordHash, historyDepth, audit)
+}
+
+func (s *userService) GetPasswordHistory(ctx context.Context, id string, limit int) ([]*models.PasswordHistoryEntry, error) {
+	return s.repo.GetPasswordHistory(ctx, id, limit)
+}
+
+func (s *userService) GetPreferences(ctx context.Context, userID string) (map[string]interface{}, error) {
+	return s.repo.GetPreferences(ctx, userID)
+}
+
+func (s *userService) SetPreferences(ctx context.Context, userID string, prefs map[string]interface{}) (map[string]interface{}, error) {
+	return s.repo.SetPreferences(ctx, userID, prefs)
+}
+
+func (s *userService) PatchPreferences(ctx context.Context, userID string, patch map[string]interface{}) (map[string]interface{}, error) {
+	return s.repo.PatchPreferences(ctx, userID, patch)
 }
 
 func (s *userService) UpdateUsername(ctx context.Context, id, username string) error {
 	return s.repo.UpdateUsername(ctx, id, username)
 }
 
-func (s *userService) UpdateEmailVerification(ctx context.Context, id string, isVerified bool) error {
-	return s.repo.UpdateEmailVerification(ctx, id, isVerified)
+func (s *userService) UpdateEmailVerification(ctx context.Context, id string, isVerified bool, audit models.AuditInfo) error {
+	return s.repo.UpdateEmailVerification(ctx, id, isVerified, audit)
 }
 
 func (s *userService) UpdateLastLogin(ctx context.Context, id string) error {
@@ -53,8 +73,44 @@ func (s *userService) UpdateFailedLoginAttempts(ctx context.Context, id string,
 	return s.repo.UpdateFailedLoginAttempts(ctx, id, attempts)
 }
 
-func (s *userService) UpdateLockedUntil(ctx context.Context, id string) error {
-	return s.repo.UpdateLockedUntil(ctx, id, time.Now())
+func (s *userService) UpdateLockedUntil(ctx context.Context, id string, lockedUntil *time.Time, audit models.AuditInfo) error {
+	return s.repo.UpdateLockedUntil(ctx, id, lockedUntil, audit)
+}
+
+func (s *userService) RecordFailedLogin(ctx context.Context, id string, policy models.LockoutPolicy, audit models.AuditInfo) (*models.LoginState, error) {
+	return s.repo.RecordFailedLogin(ctx, id, policy, audit)
+}
+
+func (s *userService) RecordSuccessfulLogin(ctx context.Context, id string, audit models.AuditInfo) (*models.LoginState, error) {
+	return s.repo.RecordSuccessfulLogin(ctx, id, audit)
+}
+
+func (s *userService) ListSecurityEvents(ctx context.Context, userID string, pageSize int, cursor string) (*models.SecurityEventPage, error) {
+	return s.repo.ListSecurityEvents(ctx, userID, pageSize, cursor)
+}
+
+func (s *userService) IssueToken(ctx context.Context, token *models.UserToken) (*models.UserToken, string, error) {
+	return s.repo.IssueToken(ctx, token)
+}
+
+func (s *userService) ConsumeToken(ctx context.Context, secret, purpose, newPasswordHash string, historyDepth int, audit models.AuditInfo) (*models.TokenConsumption, error) {
+	return s.repo.ConsumeToken(ctx, secret, purpose, newPasswordHash, historyDepth, audit)
+}
+
+func (s *userService) DeactivateUser(ctx context.Context, id string, gracePeriod time.Duration, audit models.AuditInfo) (*models.AccountDeletion, error) {
+	return s.repo.DeactivateUser(ctx, id, gracePeriod, audit)
+}
+
+func (s *userService) CancelUserDeletion(ctx context.Context, id string, audit models.AuditInfo) error {
+	return s.repo.CancelUserDeletion(ctx, id, audit)
+}
+
+func (s *userService) ListPendingErasures(ctx context.Context, limit int) ([]*models.AccountDeletion, error) {
+	return s.repo.ListPendingErasures(ctx, limit)
+}
+
+func (s *userService) EraseUser(ctx context.Context, id string) (*models.ErasureResult, error) {
+	return s.repo.EraseUser(ctx, id)
 }
 
 func (s *userService) UpdateStorageUsage(ctx context.Context, id string, usedSpace int64) error {
@@ -91,8 +147,8 @@ func (s *fileService) GetFileByPath(ctx context.Context, ownerID, path string) (
 	return s.repo.GetFileByPath(ctx, ownerID, path)
 }
 
-func (s *fileService) UpdateFile(ctx context.Context, file *models.File) error {
-	return s.repo.UpdateFile(ctx, file)
+func (s *fileService) UpdateFile(ctx context.Context, file *models.File, fields []string) error {
+	return s.repo.UpdateFile(ctx, file, fields)
 }
 
 func (s *fileService) DeleteFile(ctx context.Context, id string) error {
@@ -160,6 +216,22 @@ func (s *fileService) DeleteRevision(ctx context.Context, id string) error {
 	return s.repo.DeleteRevision(ctx, id)
 }
 
+func (s *fileService) SetRevisionLabel(ctx context.Context, fileID string, revisionID int64, label, description string) (*models.FileRevision, error) {
+	return s.repo.SetRevisionLabel(ctx, fileID, revisionID, label, description)
+}
+
+func (s *fileService) ClearRevisionLabel(ctx context.Context, fileID string, revisionID int64) error {
+	return s.repo.ClearRevisionLabel(ctx, fileID, revisionID)
+}
+
+func (s *fileService) GetRevisionByLabel(ctx context.Context, fileID, label string) (*models.FileRevision, error) {
+	return s.repo.GetRevisionByLabel(ctx, fileID, label)
+}
+
+func (s *fileService) PruneRevisions(ctx context.Context, fileID string, keepLatest int) ([]*models.FileRevision, error) {
+	return s.repo.PruneRevisions(ctx, fileID, keepLatest)
+}
+
 // File permission operations
 func (s *fileService) CreatePermission(ctx context.Context, permission *models.FilePermission) (string, error) {
 	return s.repo.CreatePermission(ctx, permission)
@@ -169,6 +241,10 @@ func (s *fileService) GetPermissions(ctx context.Context, fileID string) ([]*mod
 	return s.repo.GetPermissions(ctx, fileID)
 }
 
+func (s *fileService) GetPermission(ctx context.Context, id string) (*models.FilePermission, error) {
+	return s.repo.GetPermission(ctx, id)
+}
+
 func (s *fileService) UpdatePermission(ctx context.Context, permission *models.FilePermission) error {
 	return s.repo.UpdatePermission(ctx, permission)
 }
@@ -181,6 +257,18 @@ func (s *fileService) CheckPermission(ctx context.Context, fileID, userID, requi
 	return s.repo.CheckPermission(ctx, fileID, userID, requiredRole)
 }
 
+func (s *fileService) CheckPermissions(ctx context.Context, fileIDs []string, userID, requiredRole string) (map[string]bool, error) {
+	return s.repo.CheckPermissions(ctx, fileIDs, userID, requiredRole)
+}
+
+func (s *fileService) GetEffectivePermission(ctx context.Context, fileID, userID, linkID string) (*models.EffectivePermission, error) {
+	return s.repo.GetEffectivePermission(ctx, fileID, userID, linkID)
+}
+
+func (s *fileService) SweepExpiredPermissions(ctx context.Context) ([]*models.FilePermission, error) {
+	return s.repo.SweepExpiredPermissions(ctx)
+}
+
 // File metadata operations
 func (s *fileService) UpdateFileMetadata(ctx context.Context, fileID, metadata string) error {
 	return s.repo.UpdateFileMetadata(ctx, fileID, metadata)
@@ -211,6 +299,10 @@ func (s *fileService) RenameFile(ctx context.Context, fileID, newName string) er
 	return s.repo.RenameFile(ctx, fileID, newName)
 }
 
+func (s *fileService) TransferOwnership(ctx context.Context, fileID, newOwnerID, keepPreviousAsRole string) (*models.OwnershipTransfer, error) {
+	return s.repo.TransferOwnership(ctx, fileID, newOwnerID, keepPreviousAsRole)
+}
+
 // File integrity operations
 func (s *fileService) VerifyFileIntegrity(ctx context.Context, id string) (bool, error) {
 	return s.repo.VerifyFileIntegrity(ctx, id)
@@ -219,3 +311,41 @@ func (s *fileService) VerifyFileIntegrity(ctx context.Context, id string) (bool,
 func (s *fileService) CalculateFileChecksums(ctx context.Context, id string) (map[string]string, error) {
 	return s.repo.CalculateFileChecksums(ctx, id)
 }
+
+// Content deduplication operations
+func (s *fileService) FindBySHA256(ctx context.Context, ownerID, sha256 string) (*models.StorageBlob, error) {
+	return s.repo.FindBySHA256(ctx, ownerID, sha256)
+}
+
+func (s *fileService) FindDuplicates(ctx context.Context, ownerID string) ([]*models.DuplicateGroup, error) {
+	return s.repo.FindDuplicates(ctx, ownerID)
+}
+
+func (s *fileService) GetStorageBlob(ctx context.Context, storagePath string) (*models.StorageBlob, error) {
+	return s.repo.GetStorageBlob(ctx, storagePath)
+}
+
+func (s *fileService) ListUnreferencedBlobs(ctx context.Context, limit int) ([]*models.StorageBlob, error) {
+	return s.repo.ListUnreferencedBlobs(ctx, limit)
+}
+
+func (s *fileService) ReleaseBlob(ctx context.Context, storagePath string) (bool, error) {
+	return s.repo.ReleaseBlob(ctx, storagePath)
+}
+
+// Share link operations
+func (s *fileService) CreateShareLink(ctx context.Context, link *models.ShareLink) (string, string, error) {
+	return s.repo.CreateShareLink(ctx, link)
+}
+
+func (s *fileService) ListShareLinks(ctx context.Context, fileID string) ([]*models.ShareLink, error) {
+	return s.repo.ListShareLinks(ctx, fileID)
+}
+
+func (s *fileService) RevokeShareLink(ctx context.Context, id string) error {
+	return s.repo.RevokeShareLink(ctx, id)
+}
+
+func (s *fileService) ResolveShareLink(ctx context.Context, token string, passwordVerified bool) (*models.ShareLinkResolution, error) {
+	return s.repo.ResolveShareLink(ctx, token, passwordVerified)
+}
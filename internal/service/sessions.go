@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"homecloud--dbmanager-service/internal/interfaces"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+// SessionService implementation
+type sessionService struct {
+	repo interfaces.DBRepository
+}
+
+func NewSessionService(repo interfaces.DBRepository) interfaces.SessionService {
+	return &sessionService{repo: repo}
+}
+
+func (s *sessionService) CreateSession(ctx context.Context, session *models.Session) (*models.Session, string, error) {
+	return s.repo.CreateSession(ctx, session)
+}
+
+func (s *sessionService) RotateSession(ctx context.Context, refreshToken string, ipAddress, userAgent *string) (*models.SessionRotation, error) {
+	return s.repo.RotateSession(ctx, refreshToken, ipAddress, userAgent)
+}
+
+func (s *sessionService) ListSessions(ctx context.Context, userID string) ([]*models.Session, error) {
+	return s.repo.ListSessions(ctx, userID)
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, id string) error {
+	return s.repo.RevokeSession(ctx, id)
+}
+
+func (s *sessionService) RevokeAllSessions(ctx context.Context, userID, exceptSessionID string) (int, error) {
+	return s.repo.RevokeAllSessions(ctx, userID, exceptSessionID)
+}
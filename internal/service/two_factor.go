@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"homecloud--dbmanager-service/internal/interfaces"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+// TwoFactorService implementation
+type twoFactorService struct {
+	repo interfaces.DBRepository
+}
+
+func NewTwoFactorService(repo interfaces.DBRepository) interfaces.TwoFactorService {
+	return &twoFactorService{repo: repo}
+}
+
+func (s *twoFactorService) SaveTwoFactorSecret(ctx context.Context, userID string, encryptedSecret []byte) error {
+	return s.repo.SaveTwoFactorSecret(ctx, userID, encryptedSecret)
+}
+
+func (s *twoFactorService) GetTwoFactor(ctx context.Context, userID string) (*models.TwoFactor, error) {
+	return s.repo.GetTwoFactor(ctx, userID)
+}
+
+func (s *twoFactorService) ListTwoFactors(ctx context.Context, userIDs []string) (map[string]*models.TwoFactor, error) {
+	return s.repo.ListTwoFactors(ctx, userIDs)
+}
+
+func (s *twoFactorService) EnableTwoFactor(ctx context.Context, userID string, step int64) ([]string, error) {
+	return s.repo.EnableTwoFactor(ctx, userID, step)
+}
+
+func (s *twoFactorService) UseTwoFactorStep(ctx context.Context, userID string, step int64) (bool, error) {
+	return s.repo.UseTwoFactorStep(ctx, userID, step)
+}
+
+func (s *twoFactorService) ConsumeRecoveryCode(ctx context.Context, userID, code string) (bool, int, error) {
+	return s.repo.ConsumeRecoveryCode(ctx, userID, code)
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID string, step int64) ([]string, error) {
+	return s.repo.RegenerateRecoveryCodes(ctx, userID, step)
+}
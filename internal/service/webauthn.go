@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"homecloud--dbmanager-service/internal/interfaces"
+	"homecloud--dbmanager-service/internal/models"
+)
+
+// WebAuthnService implementation
+type webAuthnService struct {
+	repo interfaces.DBRepository
+}
+
+func NewWebAuthnService(repo interfaces.DBRepository) interfaces.WebAuthnService {
+	return &webAuthnService{repo: repo}
+}
+
+func (s *webAuthnService) RegisterWebAuthnCredential(ctx context.Context, cred *models.WebAuthnCredential) (*models.WebAuthnCredential, error) {
+	return s.repo.RegisterWebAuthnCredential(ctx, cred)
+}
+
+func (s *webAuthnService) GetWebAuthnCredential(ctx context.Context, credentialID []byte) (*models.WebAuthnCredential, error) {
+	return s.repo.GetWebAuthnCredential(ctx, credentialID)
+}
+
+func (s *webAuthnService) ListWebAuthnCredentials(ctx context.Context, userID string) ([]*models.WebAuthnCredential, error) {
+	return s.repo.ListWebAuthnCredentials(ctx, userID)
+}
+
+func (s *webAuthnService) RenameWebAuthnCredential(ctx context.Context, id, name string) (*models.WebAuthnCredential, error) {
+	return s.repo.RenameWebAuthnCredential(ctx, id, name)
+}
+
+func (s *webAuthnService) DeleteWebAuthnCredential(ctx context.Context, id string) error {
+	return s.repo.DeleteWebAuthnCredential(ctx, id)
+}
+
+func (s *webAuthnService) UpdateWebAuthnSignCount(ctx context.Context, credentialID []byte, signCount uint32) (*models.SignCountUpdate, error) {
+	return s.repo.UpdateWebAuthnSignCount(ctx, credentialID, signCount)
+}
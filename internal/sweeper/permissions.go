@@ -0,0 +1,59 @@
+package sweeper
+
+import (
+	"context"
+	"time"
+
+	"homecloud--dbmanager-service/internal/interfaces"
+	"homecloud--dbmanager-service/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+const defaultPermissionSweepInterval = time.Minute
+
+// PermissionSweeper периодически удаляет права с истёкшим сроком действия.
+// Проверки доступа истёкшие права не учитывают и без него, sweeper только
+// чистит таблицу и пишет журнал удалённых прав.
+type PermissionSweeper struct {
+	Repo     interfaces.DBRepository
+	Logger   *logger.Logger
+	Interval time.Duration
+}
+
+// Run работает до отмены ctx
+func (s *PermissionSweeper) Run(ctx context.Context) {
+	interval := s.Interval
+	if interval <= 0 {
+		interval = defaultPermissionSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PermissionSweeper) sweep(ctx context.Context) {
+	removed, err := s.Repo.SweepExpiredPermissions(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.Logger.Error(ctx, "failed to sweep expired permissions", zap.Error(err))
+		}
+		return
+	}
+	for _, p := range removed {
+		s.Logger.Info(ctx, "expired permission removed",
+			zap.String("permission_id", p.ID),
+			zap.String("file_id", p.FileID),
+			zap.String("grantee_type", p.GranteeType),
+			zap.String("role", p.Role),
+		)
+	}
+}
@@ -0,0 +1,106 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Секрет из RFC 6238, приложение B (SHA1)
+var rfcSecret = []byte("12345678901234567890")
+
+func TestCodeRFC6238Vectors(t *testing.T) {
+	// В RFC коды 8-значные, у нас 6 цифр - это младшие разряды того же значения
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		require.Equal(t, tt.want, got, "unix time %d", tt.unix)
+	}
+}
+
+func TestMatchSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	tests := []struct {
+		name     string
+		code     string
+		skew     int
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", Code(rfcSecret, current), 0, current, true},
+		{"previous step without skew", Code(rfcSecret, current-1), 0, 0, false},
+		{"previous step within skew", Code(rfcSecret, current-1), 1, current - 1, true},
+		{"next step within skew", Code(rfcSecret, current+1), 1, current + 1, true},
+		{"two steps ahead outside skew", Code(rfcSecret, current+2), 1, 0, false},
+		{"two steps back outside skew", Code(rfcSecret, current-2), 1, 0, false},
+		{"wrong length", "12345", 1, 0, false},
+		{"empty code", "", 1, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Match(rfcSecret, tt.code, now, tt.skew)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.wantStep, step)
+		})
+	}
+}
+
+func TestCipherRoundTrip(t *testing.T) {
+	c, err := NewCipher("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	require.NoError(t, err)
+
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	ciphertext, err := c.Encrypt(secret)
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext), string(secret))
+
+	plaintext, err := c.Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, secret, plaintext)
+}
+
+func TestCipherWrongKey(t *testing.T) {
+	c, err := NewCipher("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	require.NoError(t, err)
+	other, err := NewCipher("ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA=")
+	require.NoError(t, err)
+
+	ciphertext, err := c.Encrypt([]byte("secret"))
+	require.NoError(t, err)
+	_, err = other.Decrypt(ciphertext)
+	require.Error(t, err)
+
+	_, err = c.Decrypt(ciphertext[:4])
+	require.Error(t, err)
+}
+
+func TestNewCipherInvalidKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"not base64", "not a key!"},
+		{"too short", "c2hvcnQ="},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCipher(tt.key)
+			require.Error(t, err)
+		})
+	}
+}
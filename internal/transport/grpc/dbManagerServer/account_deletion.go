@@ -0,0 +1,110 @@
+package dbManagerServer
+
+import (
+	"context"
+	"time"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultDeletionGracePeriod  = 30 * 24 * time.Hour
+	defaultPendingErasuresLimit = 100
+)
+
+// Account deletion operations
+func (s *Server) DeactivateUser(ctx context.Context, req *protos.UserID) (*protos.AccountDeletion, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	gracePeriod := s.DeletionGracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultDeletionGracePeriod
+	}
+	audit, err := auditFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deletion, err := s.Repo.DeactivateUser(ctx, req.Id, gracePeriod, audit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	s.Logger.Info(ctx, "account deactivated, erasure scheduled",
+		zap.String("user_id", deletion.UserID), zap.Time("erase_after", deletion.EraseAfter))
+	return accountDeletionModelToProto(deletion), nil
+}
+
+func (s *Server) CancelUserDeletion(ctx context.Context, req *protos.UserID) (*emptypb.Empty, error) {
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	audit, err := auditFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.CancelUserDeletion(ctx, req.Id, audit); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListPendingErasures(ctx context.Context, req *protos.ListPendingErasuresRequest) (*protos.ListPendingErasuresResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultPendingErasuresLimit
+	}
+	deletions, err := s.Repo.ListPendingErasures(ctx, limit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoDeletions := make([]*protos.AccountDeletion, len(deletions))
+	for i, deletion := range deletions {
+		protoDeletions[i] = accountDeletionModelToProto(deletion)
+	}
+	return &protos.ListPendingErasuresResponse{Deletions: protoDeletions}, nil
+}
+
+func (s *Server) EraseUser(ctx context.Context, req *protos.UserID) (*protos.ErasureResult, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	result, err := s.Repo.EraseUser(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	s.Logger.Info(ctx, "account erased",
+		zap.String("user_id", result.UserID), zap.Int("files", result.FilesDeleted), zap.Int("storage_paths", len(result.StoragePaths)))
+
+	return &protos.ErasureResult{
+		UserId:             result.UserID,
+		FilesDeleted:       int32(result.FilesDeleted),
+		RevisionsDeleted:   int32(result.RevisionsDeleted),
+		PermissionsDeleted: int32(result.PermissionsDeleted),
+		SessionsDeleted:    int32(result.SessionsDeleted),
+		StoragePaths:       result.StoragePaths,
+		GroupsTransferred:  int32(result.GroupsTransferred),
+		GroupsDeleted:      int32(result.GroupsDeleted),
+	}, nil
+}
+
+func accountDeletionModelToProto(deletion *models.AccountDeletion) *protos.AccountDeletion {
+	return &protos.AccountDeletion{
+		UserId:        deletion.UserID,
+		DeactivatedAt: timestamppb.New(deletion.DeactivatedAt),
+		EraseAfter:    timestamppb.New(deletion.EraseAfter),
+	}
+}
@@ -0,0 +1,68 @@
+package dbManagerServer
+
+import (
+	"errors"
+	"regexp"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError переводит доменные ошибки из errdefs в gRPC-статусы.
+// Остальные ошибки возвращаются как есть.
+func toStatusError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, errdefs.ErrUserNotFound),
+		errors.Is(err, errdefs.ErrFileNotFound),
+		errors.Is(err, errdefs.ErrRevisionNotFound),
+		errors.Is(err, errdefs.ErrBlobNotFound),
+		errors.Is(err, errdefs.ErrGroupNotFound),
+		errors.Is(err, errdefs.ErrGroupMemberNotFound),
+		errors.Is(err, errdefs.ErrShareLinkNotFound),
+		errors.Is(err, errdefs.ErrPermissionNotFound),
+		errors.Is(err, errdefs.ErrSessionNotFound),
+		errors.Is(err, errdefs.ErrWebAuthnCredentialNotFound),
+		errors.Is(err, errdefs.ErrIdentityNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errdefs.ErrEmailExists),
+		errors.Is(err, errdefs.ErrUsernameExists),
+		errors.Is(err, errdefs.ErrRevisionLabelExists),
+		errors.Is(err, errdefs.ErrWebAuthnCredentialExists),
+		errors.Is(err, errdefs.ErrIdentityExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, errdefs.ErrGroupCycle),
+		errors.Is(err, errdefs.ErrLastGroupOwner),
+		errors.Is(err, errdefs.ErrTwoFactorNotEnrolled),
+		errors.Is(err, errdefs.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, errdefs.ErrLastLoginMethod),
+		errors.Is(err, errdefs.ErrDeletionNotScheduled),
+		errors.Is(err, errdefs.ErrErasureNotDue):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, errdefs.ErrUnknownRole),
+		errors.Is(err, errdefs.ErrInvalidCursor),
+		errors.Is(err, errdefs.ErrInvalidField),
+		errors.Is(err, errdefs.ErrEmptyMask),
+		errors.Is(err, errdefs.ErrInvalidSort):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errdefs.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return err
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// requireUUIDs проверяет, что все значения поля field - UUID.
+// Иначе запрос дошёл бы до базы и вернулся ошибкой приведения типа вместо InvalidArgument.
+func requireUUIDs(field string, values ...string) error {
+	for _, value := range values {
+		if !uuidPattern.MatchString(value) {
+			return status.Errorf(codes.InvalidArgument, "%s must be a valid id: %q", field, value)
+		}
+	}
+	return nil
+}
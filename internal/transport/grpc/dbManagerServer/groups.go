@@ -0,0 +1,223 @@
+package dbManagerServer
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Group operations
+func (s *Server) CreateGroup(ctx context.Context, req *protos.Group) (*protos.GroupID, error) {
+	if req.Name == "" || req.CreatedBy == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and created_by are required")
+	}
+	if err := requireUUIDs("created_by", req.CreatedBy); err != nil {
+		return nil, err
+	}
+	id, err := s.Repo.CreateGroup(ctx, protoToGroupModel(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.GroupID{Id: id}, nil
+}
+
+func (s *Server) GetGroup(ctx context.Context, req *protos.GroupID) (*protos.Group, error) {
+	group, err := s.Repo.GetGroup(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return groupModelToProto(group), nil
+}
+
+func (s *Server) UpdateGroup(ctx context.Context, req *protos.UpdateGroupRequest) (*emptypb.Empty, error) {
+	if req.Group.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if _, err := s.authorizeGroup(ctx, req.Group.Id, req.ActingUserId, models.GroupRoleOwner, models.GroupRoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.UpdateGroup(ctx, protoToGroupModel(req.Group)); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteGroup(ctx context.Context, req *protos.DeleteGroupRequest) (*emptypb.Empty, error) {
+	if _, err := s.authorizeGroup(ctx, req.Id, req.ActingUserId, models.GroupRoleOwner); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.DeleteGroup(ctx, req.Id); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListGroups(ctx context.Context, req *protos.ListGroupsRequest) (*protos.ListGroupsResponse, error) {
+	groups, err := s.Repo.ListGroups(ctx, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoGroups := make([]*protos.Group, len(groups))
+	for i, group := range groups {
+		protoGroups[i] = groupModelToProto(group)
+	}
+	return &protos.ListGroupsResponse{Groups: protoGroups}, nil
+}
+
+func (s *Server) AddGroupMember(ctx context.Context, req *protos.AddGroupMemberRequest) (*emptypb.Empty, error) {
+	if req.Member == nil {
+		return nil, status.Error(codes.InvalidArgument, "member is required")
+	}
+	member := protoToGroupMemberModel(req.Member)
+	if member.Role == "" {
+		member.Role = models.GroupRoleMember
+	}
+	switch {
+	case member.MemberType != models.GroupMemberUser && member.MemberType != models.GroupMemberGroup:
+		return nil, status.Error(codes.InvalidArgument, "member_type must be USER or GROUP")
+	case !slices.Contains(groupRoles, member.Role):
+		return nil, status.Error(codes.InvalidArgument, "role must be OWNER, ADMIN or MEMBER")
+	case member.MemberType == models.GroupMemberGroup && member.Role != models.GroupRoleMember:
+		return nil, status.Error(codes.InvalidArgument, "nested groups can only be MEMBER")
+	}
+	if err := requireUUIDs("group_id and member_id", member.GroupID, member.MemberID); err != nil {
+		return nil, err
+	}
+
+	actingRole, err := s.authorizeGroup(ctx, member.GroupID, req.ActingUserId, models.GroupRoleOwner, models.GroupRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if actingRole != models.GroupRoleOwner {
+		// ADMIN управляет только рядовыми участниками: не повышает до ADMIN/OWNER и не понижает их
+		currentRole, err := s.Repo.GetGroupMemberRole(ctx, member.GroupID, member.MemberID, member.MemberType)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		if member.Role != models.GroupRoleMember || currentRole == models.GroupRoleOwner || currentRole == models.GroupRoleAdmin {
+			return nil, status.Error(codes.PermissionDenied, "only a group OWNER can grant or change OWNER and ADMIN roles")
+		}
+	}
+
+	if err := s.Repo.AddGroupMember(ctx, member); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) RemoveGroupMember(ctx context.Context, req *protos.RemoveGroupMemberRequest) (*emptypb.Empty, error) {
+	// Выйти из группы можно без прав управления ею; последнего OWNER удалить не даст репозиторий
+	leaving := req.MemberType == models.GroupMemberUser && req.MemberId != "" && req.MemberId == req.ActingUserId
+	if !leaving {
+		actingRole, err := s.authorizeGroup(ctx, req.GroupId, req.ActingUserId, models.GroupRoleOwner, models.GroupRoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if actingRole != models.GroupRoleOwner {
+			currentRole, err := s.Repo.GetGroupMemberRole(ctx, req.GroupId, req.MemberId, req.MemberType)
+			if err != nil {
+				return nil, toStatusError(err)
+			}
+			if currentRole == models.GroupRoleOwner || currentRole == models.GroupRoleAdmin {
+				return nil, status.Error(codes.PermissionDenied, "only a group OWNER can remove OWNER and ADMIN members")
+			}
+		}
+	}
+	if err := s.Repo.RemoveGroupMember(ctx, req.GroupId, req.MemberId, req.MemberType); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListGroupMembers(ctx context.Context, req *protos.GroupID) (*protos.ListGroupMembersResponse, error) {
+	members, err := s.Repo.ListGroupMembers(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoMembers := make([]*protos.GroupMember, len(members))
+	for i, member := range members {
+		protoMembers[i] = &protos.GroupMember{
+			GroupId:    member.GroupID,
+			MemberId:   member.MemberID,
+			MemberType: member.MemberType,
+			Role:       member.Role,
+			CreatedAt:  timestamppb.New(member.CreatedAt),
+		}
+	}
+	return &protos.ListGroupMembersResponse{Members: protoMembers}, nil
+}
+
+var groupRoles = []string{models.GroupRoleOwner, models.GroupRoleAdmin, models.GroupRoleMember}
+
+// authorizeGroup проверяет, что actingUserID напрямую состоит в группе с одной из ролей roles,
+// и возвращает его роль. Иначе возвращает PermissionDenied.
+func (s *Server) authorizeGroup(ctx context.Context, groupID, actingUserID string, roles ...string) (string, error) {
+	if actingUserID == "" {
+		return "", status.Error(codes.InvalidArgument, "acting_user_id is required")
+	}
+	role, err := s.Repo.GetGroupMemberRole(ctx, groupID, actingUserID, models.GroupMemberUser)
+	if err != nil {
+		return "", toStatusError(err)
+	}
+	if !slices.Contains(roles, role) {
+		return "", status.Errorf(codes.PermissionDenied, "acting user must be %s of the group", strings.Join(roles, " or "))
+	}
+	return role, nil
+}
+
+func groupModelToProto(g *models.Group) *protos.Group {
+	if g == nil {
+		return nil
+	}
+
+	description := ""
+	if g.Description != nil {
+		description = *g.Description
+	}
+
+	return &protos.Group{
+		Id:          g.ID,
+		Name:        g.Name,
+		Description: description,
+		CreatedBy:   g.CreatedBy,
+		CreatedAt:   timestamppb.New(g.CreatedAt),
+		UpdatedAt:   timestamppb.New(g.UpdatedAt),
+	}
+}
+
+func protoToGroupModel(g *protos.Group) *models.Group {
+	if g == nil {
+		return nil
+	}
+
+	var description *string
+	if g.Description != "" {
+		description = &g.Description
+	}
+
+	return &models.Group{
+		ID:          g.Id,
+		Name:        g.Name,
+		Description: description,
+		CreatedBy:   g.CreatedBy,
+	}
+}
+
+func protoToGroupMemberModel(m *protos.GroupMember) *models.GroupMember {
+	return &models.GroupMember{
+		GroupID:    m.GroupId,
+		MemberID:   m.MemberId,
+		MemberType: m.MemberType,
+		Role:       m.Role,
+	}
+}
@@ -0,0 +1,108 @@
+package dbManagerServer
+
+import (
+	"context"
+	"strings"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// External identity operations
+func (s *Server) LinkIdentity(ctx context.Context, req *protos.UserIdentity) (*protos.UserIdentity, error) {
+	provider, err := identityKey(req.Provider, req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	identity, err := s.Repo.LinkIdentity(ctx, &models.UserIdentity{
+		Provider: provider,
+		Subject:  req.Subject,
+		UserID:   req.UserId,
+		Email:    stringPtrOrNil(req.Email),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return identityModelToProto(identity), nil
+}
+
+func (s *Server) UnlinkIdentity(ctx context.Context, req *protos.UnlinkIdentityRequest) (*emptypb.Empty, error) {
+	provider, err := identityKey(req.Provider, req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.UnlinkIdentity(ctx, req.UserId, provider, req.Subject); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListIdentities(ctx context.Context, req *protos.UserID) (*protos.ListIdentitiesResponse, error) {
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	identities, err := s.Repo.ListIdentities(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoIdentities := make([]*protos.UserIdentity, len(identities))
+	for i, identity := range identities {
+		protoIdentities[i] = identityModelToProto(identity)
+	}
+	return &protos.ListIdentitiesResponse{Identities: protoIdentities}, nil
+}
+
+func (s *Server) GetUserByIdentity(ctx context.Context, req *protos.IdentityKey) (*protos.User, error) {
+	provider, err := identityKey(req.Provider, req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.Repo.GetUserByIdentity(ctx, provider, req.Subject)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return userModelToProto(u), nil
+}
+
+// identityKey проверяет пару provider/subject и приводит имя провайдера к нижнему регистру.
+// subject сравнивается как есть: у провайдеров он чувствителен к регистру.
+func identityKey(provider, subject string) (string, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" || subject == "" {
+		return "", status.Error(codes.InvalidArgument, "provider and subject are required")
+	}
+	return provider, nil
+}
+
+func identityModelToProto(identity *models.UserIdentity) *protos.UserIdentity {
+	email := ""
+	if identity.Email != nil {
+		email = *identity.Email
+	}
+	return &protos.UserIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		UserId:   identity.UserID,
+		Email:    email,
+		LinkedAt: timestamppb.New(identity.LinkedAt),
+	}
+}
@@ -0,0 +1,36 @@
+package dbManagerServer
+
+import (
+	"context"
+
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetPasswordHistory возвращает последние хеши пароля: сервис аутентификации сверяет
+// с ними новый пароль, сами хеши солёные и здесь не сравниваются
+func (s *Server) GetPasswordHistory(ctx context.Context, req *protos.UserID) (*protos.PasswordHistoryResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	resp := &protos.PasswordHistoryResponse{Depth: int32(s.PasswordHistoryDepth)}
+	if s.PasswordHistoryDepth <= 0 {
+		return resp, nil
+	}
+
+	entries, err := s.Repo.GetPasswordHistory(ctx, req.Id, s.PasswordHistoryDepth)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp.Entries = make([]*protos.PasswordHistoryEntry, len(entries))
+	for i, entry := range entries {
+		resp.Entries[i] = &protos.PasswordHistoryEntry{
+			PasswordHash: entry.PasswordHash,
+			CreatedAt:    timestamppb.New(entry.CreatedAt),
+		}
+	}
+	return resp, nil
+}
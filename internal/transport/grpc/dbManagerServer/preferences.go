@@ -0,0 +1,132 @@
+package dbManagerServer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// preferenceField описывает допустимое значение ключа настроек:
+// строку из enum или под pattern, bool или вложенный объект с полями fields
+type preferenceField struct {
+	kind    string
+	enum    []string
+	pattern *regexp.Regexp
+	fields  map[string]preferenceField
+}
+
+const (
+	preferenceString = "string"
+	preferenceBool   = "bool"
+	preferenceObject = "object"
+)
+
+var preferencesSchema = map[string]preferenceField{
+	"theme":    {kind: preferenceString, enum: []string{"light", "dark", "system"}},
+	"language": {kind: preferenceString, pattern: regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)},
+	"default_sort": {kind: preferenceObject, fields: map[string]preferenceField{
+		"field":     {kind: preferenceString, enum: []string{"name", "created_at", "updated_at", "size"}},
+		"direction": {kind: preferenceString, enum: []string{"asc", "desc"}},
+	}},
+	"notifications": {kind: preferenceObject, fields: map[string]preferenceField{
+		"email":   {kind: preferenceBool},
+		"push":    {kind: preferenceBool},
+		"shares":  {kind: preferenceBool},
+		"storage": {kind: preferenceBool},
+	}},
+}
+
+// Preferences operations
+func (s *Server) GetPreferences(ctx context.Context, req *protos.UserID) (*protos.Preferences, error) {
+	prefs, err := s.Repo.GetPreferences(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return preferencesToProto(req.Id, prefs)
+}
+
+func (s *Server) SetPreferences(ctx context.Context, req *protos.SetPreferencesRequest) (*protos.Preferences, error) {
+	prefs := req.Preferences.AsMap()
+	if err := validatePreferences(prefs, preferencesSchema, "", false); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	saved, err := s.Repo.SetPreferences(ctx, req.UserId, prefs)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return preferencesToProto(req.UserId, saved)
+}
+
+func (s *Server) PatchPreferences(ctx context.Context, req *protos.PatchPreferencesRequest) (*protos.Preferences, error) {
+	patch := req.Patch.AsMap()
+	// Каждый ключ схемы проверяется независимо, поэтому корректный патч к корректному
+	// документу даёт корректный документ
+	if err := validatePreferences(patch, preferencesSchema, "", true); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	saved, err := s.Repo.PatchPreferences(ctx, req.UserId, patch)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return preferencesToProto(req.UserId, saved)
+}
+
+// validatePreferences сверяет документ со схемой. allowNull разрешает null (удаление ключа в патче).
+func validatePreferences(doc map[string]interface{}, schema map[string]preferenceField, prefix string, allowNull bool) error {
+	for key, value := range doc {
+		path := prefix + key
+		field, ok := schema[key]
+		if !ok {
+			return fmt.Errorf("unknown preference %q", path)
+		}
+		if value == nil {
+			if allowNull {
+				continue
+			}
+			return fmt.Errorf("preference %q must not be null", path)
+		}
+
+		switch field.kind {
+		case preferenceString:
+			str, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("preference %q must be a string", path)
+			}
+			if field.enum != nil && !slices.Contains(field.enum, str) {
+				return fmt.Errorf("preference %q must be one of %s", path, strings.Join(field.enum, ", "))
+			}
+			if field.pattern != nil && !field.pattern.MatchString(str) {
+				return fmt.Errorf("preference %q has invalid format", path)
+			}
+		case preferenceBool:
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("preference %q must be a boolean", path)
+			}
+		case preferenceObject:
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("preference %q must be an object", path)
+			}
+			if err := validatePreferences(obj, field.fields, path+".", allowNull); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func preferencesToProto(userID string, prefs map[string]interface{}) (*protos.Preferences, error) {
+	doc, err := structpb.NewStruct(prefs)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.Preferences{UserId: userID, Preferences: doc}, nil
+}
@@ -0,0 +1,50 @@
+package dbManagerServer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePreferences(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       map[string]interface{}
+		allowNull bool
+		wantErr   string
+	}{
+		{
+			name: "valid document",
+			doc: map[string]interface{}{
+				"theme":         "dark",
+				"language":      "en-US",
+				"default_sort":  map[string]interface{}{"field": "size", "direction": "desc"},
+				"notifications": map[string]interface{}{"email": true, "push": false},
+			},
+		},
+		{name: "empty document", doc: map[string]interface{}{}},
+		{name: "two-letter language", doc: map[string]interface{}{"language": "ru"}},
+		{name: "unknown key", doc: map[string]interface{}{"font": "serif"}, wantErr: `unknown preference "font"`},
+		{name: "unknown nested key", doc: map[string]interface{}{"notifications": map[string]interface{}{"sms": true}}, wantErr: `unknown preference "notifications.sms"`},
+		{name: "value outside enum", doc: map[string]interface{}{"theme": "blue"}, wantErr: `preference "theme" must be one of light, dark, system`},
+		{name: "pattern mismatch", doc: map[string]interface{}{"language": "english"}, wantErr: `preference "language" has invalid format`},
+		{name: "string expected", doc: map[string]interface{}{"theme": 1.0}, wantErr: `preference "theme" must be a string`},
+		{name: "bool expected", doc: map[string]interface{}{"notifications": map[string]interface{}{"push": "yes"}}, wantErr: `preference "notifications.push" must be a boolean`},
+		{name: "object expected", doc: map[string]interface{}{"default_sort": "name"}, wantErr: `preference "default_sort" must be an object`},
+		{name: "nested enum", doc: map[string]interface{}{"default_sort": map[string]interface{}{"direction": "up"}}, wantErr: `preference "default_sort.direction" must be one of asc, desc`},
+		{name: "null in document", doc: map[string]interface{}{"theme": nil}, wantErr: `preference "theme" must not be null`},
+		{name: "null in patch", doc: map[string]interface{}{"theme": nil}, allowNull: true},
+		{name: "nested null in patch", doc: map[string]interface{}{"default_sort": map[string]interface{}{"field": nil}}, allowNull: true},
+		{name: "unknown key in patch", doc: map[string]interface{}{"font": nil}, allowNull: true, wantErr: `unknown preference "font"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePreferences(tt.doc, preferencesSchema, "", tt.allowNull)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
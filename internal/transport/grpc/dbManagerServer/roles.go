@@ -0,0 +1,60 @@
+package dbManagerServer
+
+import (
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var permissionRoleToProto = map[string]protos.PermissionRole{
+	models.RoleReader:    protos.PermissionRole_PERMISSION_ROLE_READER,
+	models.RoleCommenter: protos.PermissionRole_PERMISSION_ROLE_COMMENTER,
+	models.RoleWriter:    protos.PermissionRole_PERMISSION_ROLE_WRITER,
+	models.RoleFileOwner: protos.PermissionRole_PERMISSION_ROLE_FILE_OWNER,
+	models.RoleOrganizer: protos.PermissionRole_PERMISSION_ROLE_ORGANIZER,
+	models.RoleOwner:     protos.PermissionRole_PERMISSION_ROLE_OWNER,
+}
+
+var permissionRoleNames = map[protos.PermissionRole]string{
+	protos.PermissionRole_PERMISSION_ROLE_READER:     models.RoleReader,
+	protos.PermissionRole_PERMISSION_ROLE_COMMENTER:  models.RoleCommenter,
+	protos.PermissionRole_PERMISSION_ROLE_WRITER:     models.RoleWriter,
+	protos.PermissionRole_PERMISSION_ROLE_FILE_OWNER: models.RoleFileOwner,
+	protos.PermissionRole_PERMISSION_ROLE_ORGANIZER:  models.RoleOrganizer,
+	protos.PermissionRole_PERMISSION_ROLE_OWNER:      models.RoleOwner,
+}
+
+var granteeTypeToProto = map[string]protos.GranteeType{
+	models.GranteeUser:   protos.GranteeType_GRANTEE_TYPE_USER,
+	models.GranteeGroup:  protos.GranteeType_GRANTEE_TYPE_GROUP,
+	models.GranteeDomain: protos.GranteeType_GRANTEE_TYPE_DOMAIN,
+	models.GranteeAnyone: protos.GranteeType_GRANTEE_TYPE_ANYONE,
+}
+
+var granteeTypeNames = map[protos.GranteeType]string{
+	protos.GranteeType_GRANTEE_TYPE_USER:   models.GranteeUser,
+	protos.GranteeType_GRANTEE_TYPE_GROUP:  models.GranteeGroup,
+	protos.GranteeType_GRANTEE_TYPE_DOMAIN: models.GranteeDomain,
+	protos.GranteeType_GRANTEE_TYPE_ANYONE: models.GranteeAnyone,
+}
+
+// roleFromProto переводит роль из запроса в значение для базы.
+// UNSPECIFIED и неизвестные значения отклоняются с InvalidArgument.
+func roleFromProto(role protos.PermissionRole, field string) (string, error) {
+	name, ok := permissionRoleNames[role]
+	if !ok {
+		return "", status.Errorf(codes.InvalidArgument, "unknown %s: %v", field, role)
+	}
+	return name, nil
+}
+
+// granteeTypeFromProto - то же для типа получателя
+func granteeTypeFromProto(granteeType protos.GranteeType) (string, error) {
+	name, ok := granteeTypeNames[granteeType]
+	if !ok {
+		return "", status.Errorf(codes.InvalidArgument, "unknown grantee_kind: %v", granteeType)
+	}
+	return name, nil
+}
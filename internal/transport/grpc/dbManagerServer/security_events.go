@@ -0,0 +1,112 @@
+package dbManagerServer
+
+import (
+	"context"
+	"strings"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultSecurityEventsPageSize = 50
+	maxSecurityEventsPageSize     = 500
+)
+
+var securityEventTypeToProto = map[string]protos.SecurityEventType{
+	models.SecurityEventPasswordChanged:    protos.SecurityEventType_SECURITY_EVENT_TYPE_PASSWORD_CHANGED,
+	models.SecurityEventEmailVerified:      protos.SecurityEventType_SECURITY_EVENT_TYPE_EMAIL_VERIFIED,
+	models.SecurityEventEmailUnverified:    protos.SecurityEventType_SECURITY_EVENT_TYPE_EMAIL_UNVERIFIED,
+	models.SecurityEventAccountLocked:      protos.SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_LOCKED,
+	models.SecurityEventAccountUnlocked:    protos.SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_UNLOCKED,
+	models.SecurityEventRoleChanged:        protos.SecurityEventType_SECURITY_EVENT_TYPE_ROLE_CHANGED,
+	models.SecurityEventAccountActivated:   protos.SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_ACTIVATED,
+	models.SecurityEventAccountDeactivated: protos.SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_DEACTIVATED,
+	models.SecurityEventLoginSucceeded:     protos.SecurityEventType_SECURITY_EVENT_TYPE_LOGIN_SUCCEEDED,
+	models.SecurityEventLoginFailed:        protos.SecurityEventType_SECURITY_EVENT_TYPE_LOGIN_FAILED,
+	models.SecurityEventLoginBlocked:       protos.SecurityEventType_SECURITY_EVENT_TYPE_LOGIN_BLOCKED,
+	models.SecurityEventAccountErased:      protos.SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_ERASED,
+}
+
+// auditFromContext достаёт из метаданных запроса, кто и откуда выполняет действие.
+// Вызывающий сервис передаёт x-actor-id, IP и user agent конечного клиента.
+// Собственный user-agent gRPC не используется: в нём клиент вызывающего сервиса, а не браузер.
+func auditFromContext(ctx context.Context) (models.AuditInfo, error) {
+	var audit models.AuditInfo
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return audit, nil
+	}
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if values := md.Get(key); len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+		return ""
+	}
+
+	if actorID := first("x-actor-id"); actorID != "" {
+		if !uuidPattern.MatchString(actorID) {
+			return audit, status.Error(codes.InvalidArgument, "x-actor-id must be a user id")
+		}
+		audit.ActorID = &actorID
+	}
+	// x-forwarded-for: "client, proxy1, proxy2" - нужен адрес клиента
+	ip, _, _ := strings.Cut(first("x-forwarded-for", "x-real-ip"), ",")
+	audit.IPAddress = stringPtrOrNil(strings.TrimSpace(ip))
+	audit.UserAgent = stringPtrOrNil(first("x-user-agent"))
+	return audit, nil
+}
+
+func (s *Server) ListSecurityEvents(ctx context.Context, req *protos.ListSecurityEventsRequest) (*protos.ListSecurityEventsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case pageSize == 0:
+		pageSize = defaultSecurityEventsPageSize
+	case pageSize > maxSecurityEventsPageSize:
+		pageSize = maxSecurityEventsPageSize
+	}
+
+	page, err := s.Repo.ListSecurityEvents(ctx, req.UserId, pageSize, req.PageToken)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &protos.ListSecurityEventsResponse{
+		Events:        make([]*protos.SecurityEvent, len(page.Events)),
+		NextPageToken: page.NextCursor,
+	}
+	for i, event := range page.Events {
+		if resp.Events[i], err = securityEventModelToProto(event); err != nil {
+			return nil, status.Errorf(codes.Internal, "security event %d: %v", event.ID, err)
+		}
+	}
+	return resp, nil
+}
+
+func securityEventModelToProto(event *models.SecurityEvent) (*protos.SecurityEvent, error) {
+	details, err := structpb.NewStruct(event.Details)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.SecurityEvent{
+		Id:        event.ID,
+		UserId:    event.UserID,
+		EventType: securityEventTypeToProto[event.EventType],
+		ActorId:   event.ActorID,
+		IpAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		Details:   details,
+		CreatedAt: timeToProto(&event.CreatedAt),
+	}, nil
+}
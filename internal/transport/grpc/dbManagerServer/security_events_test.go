@@ -0,0 +1,76 @@
+package dbManagerServer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuditFromContext(t *testing.T) {
+	const actorID = "8c9d5f0e-1a2b-4c3d-8e9f-0a1b2c3d4e5f"
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		wantActor     string
+		wantIP        string
+		wantUserAgent string
+		wantCode      codes.Code
+	}{
+		{name: "no metadata"},
+		{
+			name:          "all fields",
+			md:            metadata.Pairs("x-actor-id", actorID, "x-forwarded-for", "203.0.113.7", "x-user-agent", "Mozilla/5.0"),
+			wantActor:     actorID,
+			wantIP:        "203.0.113.7",
+			wantUserAgent: "Mozilla/5.0",
+		},
+		{
+			name:   "client address from forwarded chain",
+			md:     metadata.Pairs("x-forwarded-for", "203.0.113.7, 10.0.0.1, 10.0.0.2"),
+			wantIP: "203.0.113.7",
+		},
+		{
+			name:   "x-real-ip fallback",
+			md:     metadata.Pairs("x-real-ip", "198.51.100.1"),
+			wantIP: "198.51.100.1",
+		},
+		{
+			name: "grpc user-agent is not the client",
+			md:   metadata.Pairs("user-agent", "grpc-go/1.70.0"),
+		},
+		{
+			name:     "actor id must be a uuid",
+			md:       metadata.Pairs("x-actor-id", "admin"),
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			audit, err := auditFromContext(ctx)
+			if tt.wantCode != codes.OK {
+				require.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantActor, deref(audit.ActorID))
+			require.Equal(t, tt.wantIP, deref(audit.IPAddress))
+			require.Equal(t, tt.wantUserAgent, deref(audit.UserAgent))
+		})
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
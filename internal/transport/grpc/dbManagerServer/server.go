@@ -3,24 +3,38 @@ package dbManagerServer
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
+	"homecloud--dbmanager-service/internal/errdefs"
 	"homecloud--dbmanager-service/internal/interfaces"
 	"homecloud--dbmanager-service/internal/logger"
 	"homecloud--dbmanager-service/internal/models"
+	"homecloud--dbmanager-service/internal/totp"
 	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Server struct {
 	protos.UnimplementedDBServiceServer
-	Repo   interfaces.DBRepository
-	Logger *logger.Logger
+	Repo    interfaces.DBRepository
+	Logger  *logger.Logger
+	Lockout models.LockoutPolicy
+	// DeletionGracePeriod - срок между DeactivateUser и стиранием данных
+	DeletionGracePeriod time.Duration
+	// PasswordHistoryDepth - сколько последних паролей нельзя использовать повторно
+	PasswordHistoryDepth int
+	// TwoFactor шифрует TOTP-секреты; nil - 2FA не настроена
+	TwoFactor       *totp.Cipher
+	TwoFactorIssuer string
 }
 
 func userModelToProto(u *models.User) *protos.User {
@@ -61,34 +75,6 @@ func protoToUserModel(u *protos.User) *models.User {
 	}
 }
 
-// Helper functions for preferences conversion
-func convertPreferencesToMap(prefs map[string]interface{}) map[string]string {
-	if prefs == nil {
-		return nil
-	}
-	result := make(map[string]string)
-	for k, v := range prefs {
-		if str, ok := v.(string); ok {
-			result[k] = str
-		} else {
-			// Convert other types to string representation
-			result[k] = fmt.Sprintf("%v", v)
-		}
-	}
-	return result
-}
-
-func convertMapToPreferences(prefs map[string]string) map[string]interface{} {
-	if prefs == nil {
-		return nil
-	}
-	result := make(map[string]interface{})
-	for k, v := range prefs {
-		result[k] = v
-	}
-	return result
-}
-
 func timeToProto(t *time.Time) *timestamppb.Timestamp {
 	if t == nil {
 		return nil
@@ -104,10 +90,18 @@ func protoToTime(ts *timestamppb.Timestamp) *time.Time {
 	return &t
 }
 
+// stringPtrOrNil - пустая строка из запроса означает отсутствие значения
+func stringPtrOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 func (s *Server) CreateUser(ctx context.Context, req *protos.User) (*protos.UserID, error) {
 	id, err := s.Repo.CreateUser(ctx, protoToUserModel(req))
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	return &protos.UserID{Id: id}, nil
 }
@@ -139,17 +133,28 @@ func (s *Server) GetUserByEmail(ctx context.Context, req *protos.EmailRequest) (
 	return userModelToProto(u), nil
 }
 
-func (s *Server) UpdateUser(ctx context.Context, req *protos.User) (*emptypb.Empty, error) {
-	if err := s.Repo.UpdateUser(ctx, protoToUserModel(req)); err != nil {
+func (s *Server) UpdateUser(ctx context.Context, req *protos.UpdateUserRequest) (*emptypb.Empty, error) {
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+	audit, err := auditFromContext(ctx)
+	if err != nil {
 		return nil, err
 	}
+	if err := s.Repo.UpdateUser(ctx, protoToUserModel(req.User), req.UpdateMask.GetPaths(), audit); err != nil {
+		return nil, toStatusError(err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) UpdatePassword(ctx context.Context, req *protos.UpdatePasswordRequest) (*emptypb.Empty, error) {
-	if err := s.Repo.UpdatePassword(ctx, req.Id, req.PasswordHash); err != nil {
+	audit, err := auditFromContext(ctx)
+	if err != nil {
 		return nil, err
 	}
+	if err := s.Repo.UpdatePassword(ctx, req.Id, req.PasswordHash, s.PasswordHistoryDepth, audit); err != nil {
+		return nil, toStatusError(err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
@@ -161,9 +166,13 @@ func (s *Server) UpdateUsername(ctx context.Context, req *protos.UpdateUsernameR
 }
 
 func (s *Server) UpdateEmailVerification(ctx context.Context, req *protos.UpdateEmailVerificationRequest) (*emptypb.Empty, error) {
-	if err := s.Repo.UpdateEmailVerification(ctx, req.Id, req.IsVerified); err != nil {
+	audit, err := auditFromContext(ctx)
+	if err != nil {
 		return nil, err
 	}
+	if err := s.Repo.UpdateEmailVerification(ctx, req.Id, req.IsVerified, audit); err != nil {
+		return nil, toStatusError(err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
@@ -182,12 +191,52 @@ func (s *Server) UpdateFailedLoginAttempts(ctx context.Context, req *protos.Upda
 }
 
 func (s *Server) UpdateLockedUntil(ctx context.Context, req *protos.UpdateLockedUntilRequest) (*emptypb.Empty, error) {
-	if err := s.Repo.UpdateLockedUntil(ctx, req.Id, req.LockedUntil.AsTime()); err != nil {
+	audit, err := auditFromContext(ctx)
+	if err != nil {
 		return nil, err
 	}
+	if err := s.Repo.UpdateLockedUntil(ctx, req.Id, protoToTime(req.LockedUntil), audit); err != nil {
+		return nil, toStatusError(err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
+func (s *Server) RecordFailedLogin(ctx context.Context, req *protos.UserID) (*protos.LoginState, error) {
+	audit, err := auditFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.Repo.RecordFailedLogin(ctx, req.Id, s.Lockout, audit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if state.Locked {
+		s.Logger.Info(ctx, "user locked after failed logins",
+			zap.String("user_id", req.Id), zap.Int32("attempts", state.FailedLoginAttempts), zap.Timep("locked_until", state.LockedUntil))
+	}
+	return loginStateModelToProto(state), nil
+}
+
+func (s *Server) RecordSuccessfulLogin(ctx context.Context, req *protos.UserID) (*protos.LoginState, error) {
+	audit, err := auditFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.Repo.RecordSuccessfulLogin(ctx, req.Id, audit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return loginStateModelToProto(state), nil
+}
+
+func loginStateModelToProto(st *models.LoginState) *protos.LoginState {
+	return &protos.LoginState{
+		FailedLoginAttempts: st.FailedLoginAttempts,
+		Locked:              st.Locked,
+		LockedUntil:         timeToProto(st.LockedUntil),
+	}
+}
+
 func (s *Server) UpdateStorageUsage(ctx context.Context, req *protos.UpdateStorageUsageRequest) (*emptypb.Empty, error) {
 	if err := s.Repo.UpdateStorageUsage(ctx, req.Id, req.UsedSpace); err != nil {
 		return nil, err
@@ -243,9 +292,12 @@ func (s *Server) GetFileByPath(ctx context.Context, req *protos.GetFileByPathReq
 	return fileModelToProto(file), nil
 }
 
-func (s *Server) UpdateFile(ctx context.Context, req *protos.File) (*emptypb.Empty, error) {
-	if err := s.Repo.UpdateFile(ctx, protoToFileModel(req)); err != nil {
-		return nil, err
+func (s *Server) UpdateFile(ctx context.Context, req *protos.UpdateFileRequest) (*emptypb.Empty, error) {
+	if req.File == nil {
+		return nil, status.Error(codes.InvalidArgument, "file is required")
+	}
+	if err := s.Repo.UpdateFile(ctx, protoToFileModel(req.File), req.UpdateMask.GetPaths()); err != nil {
+		return nil, toStatusError(err)
 	}
 	return &emptypb.Empty{}, nil
 }
@@ -439,13 +491,78 @@ func (s *Server) DeleteRevision(ctx context.Context, req *protos.RevisionID) (*e
 	return &emptypb.Empty{}, nil
 }
 
+func (s *Server) SetRevisionLabel(ctx context.Context, req *protos.SetRevisionLabelRequest) (*protos.FileRevision, error) {
+	if req.Label == "" {
+		return nil, status.Error(codes.InvalidArgument, "label is required")
+	}
+	revision, err := s.Repo.SetRevisionLabel(ctx, req.FileId, req.RevisionId, req.Label, req.Description)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return fileRevisionModelToProto(revision), nil
+}
+
+func (s *Server) ClearRevisionLabel(ctx context.Context, req *protos.GetRevisionRequest) (*emptypb.Empty, error) {
+	if err := s.Repo.ClearRevisionLabel(ctx, req.FileId, req.RevisionId); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetRevisionByLabel(ctx context.Context, req *protos.GetRevisionByLabelRequest) (*protos.FileRevision, error) {
+	revision, err := s.Repo.GetRevisionByLabel(ctx, req.FileId, req.Label)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return fileRevisionModelToProto(revision), nil
+}
+
+func (s *Server) PruneRevisions(ctx context.Context, req *protos.PruneRevisionsRequest) (*protos.ListRevisionsResponse, error) {
+	if req.KeepLatest < 0 {
+		return nil, status.Error(codes.InvalidArgument, "keep_latest must not be negative")
+	}
+	if err := requireUUIDs("file_id", req.FileId); err != nil {
+		return nil, err
+	}
+	revisions, err := s.Repo.PruneRevisions(ctx, req.FileId, int(req.KeepLatest))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoRevisions := make([]*protos.FileRevision, len(revisions))
+	for i, revision := range revisions {
+		protoRevisions[i] = fileRevisionModelToProto(revision)
+	}
+
+	return &protos.ListRevisionsResponse{Revisions: protoRevisions}, nil
+}
+
 // File permission operations
-func (s *Server) CreatePermission(ctx context.Context, req *protos.FilePermission) (*protos.PermissionID, error) {
-	permission := protoToFilePermissionModel(req)
-	id, err := s.Repo.CreatePermission(ctx, permission)
+func (s *Server) CreatePermission(ctx context.Context, req *protos.CreatePermissionRequest) (*protos.PermissionID, error) {
+	fp := req.Permission
+	if fp == nil || req.ActingUserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "permission and acting_user_id are required")
+	}
+	permission, err := protoToFilePermissionModel(fp)
 	if err != nil {
 		return nil, err
 	}
+	if err := validateGrantee(permission); err != nil {
+		return nil, err
+	}
+	if fp.ExpiresAt != nil && !fp.ExpiresAt.AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+	}
+	if err := requireUUIDs("file_id and acting_user_id", permission.FileID, req.ActingUserId); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeShare(ctx, permission.FileID, req.ActingUserId, permission.Role); err != nil {
+		return nil, err
+	}
+	id, err := s.Repo.CreatePermission(ctx, permission)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
 	return &protos.PermissionID{Id: id}, nil
 }
 
@@ -463,28 +580,173 @@ func (s *Server) GetPermissions(ctx context.Context, req *protos.FileID) (*proto
 	return &protos.ListPermissionsResponse{Permissions: protoPermissions}, nil
 }
 
-func (s *Server) UpdatePermission(ctx context.Context, req *protos.FilePermission) (*emptypb.Empty, error) {
-	if err := s.Repo.UpdatePermission(ctx, protoToFilePermissionModel(req)); err != nil {
+func (s *Server) UpdatePermission(ctx context.Context, req *protos.UpdatePermissionRequest) (*emptypb.Empty, error) {
+	fp := req.Permission
+	if fp == nil || req.ActingUserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "permission and acting_user_id are required")
+	}
+	permission, err := protoToFilePermissionModel(fp)
+	if err != nil {
 		return nil, err
 	}
+	if err := validateGrantee(permission); err != nil {
+		return nil, err
+	}
+	if fp.ExpiresAt != nil && !fp.ExpiresAt.AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+	}
+	if err := requireUUIDs("id and acting_user_id", fp.Id, req.ActingUserId); err != nil {
+		return nil, err
+	}
+	current, err := s.Repo.GetPermission(ctx, fp.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	// Нельзя ни понизить чужое право сильнее своего, ни поднять право выше своего
+	if err := s.authorizeShare(ctx, current.FileID, req.ActingUserId, current.Role, permission.Role); err != nil {
+		return nil, err
+	}
+	permission.FileID = current.FileID
+	if err := s.Repo.UpdatePermission(ctx, permission); err != nil {
+		return nil, toStatusError(err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
-func (s *Server) DeletePermission(ctx context.Context, req *protos.PermissionID) (*emptypb.Empty, error) {
-	if err := s.Repo.DeletePermission(ctx, req.Id); err != nil {
+func (s *Server) DeletePermission(ctx context.Context, req *protos.DeletePermissionRequest) (*emptypb.Empty, error) {
+	if req.Id == "" || req.ActingUserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "id and acting_user_id are required")
+	}
+	if err := requireUUIDs("id and acting_user_id", req.Id, req.ActingUserId); err != nil {
 		return nil, err
 	}
+	current, err := s.Repo.GetPermission(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if err := s.authorizeShare(ctx, current.FileID, req.ActingUserId, current.Role); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.DeletePermission(ctx, req.Id); err != nil {
+		return nil, toStatusError(err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) CheckPermission(ctx context.Context, req *protos.CheckPermissionRequest) (*protos.PermissionResponse, error) {
-	hasPermission, err := s.Repo.CheckPermission(ctx, req.FileId, req.UserId, req.RequiredRole)
+	requiredRole, err := roleFromProto(req.MinimumRole, "minimum_role")
 	if err != nil {
 		return nil, err
 	}
+	hasPermission, err := s.Repo.CheckPermission(ctx, req.FileId, req.UserId, requiredRole)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
 	return &protos.PermissionResponse{HasPermission: hasPermission}, nil
 }
 
+func (s *Server) CheckPermissions(ctx context.Context, req *protos.CheckPermissionsRequest) (*protos.CheckPermissionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if err := requireUUIDs("file_ids", req.FileIds...); err != nil {
+		return nil, err
+	}
+	requiredRole, err := roleFromProto(req.MinimumRole, "minimum_role")
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := s.Repo.CheckPermissions(ctx, req.FileIds, req.UserId, requiredRole)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.CheckPermissionsResponse{Permissions: permissions}, nil
+}
+
+func (s *Server) GetEffectivePermission(ctx context.Context, req *protos.GetEffectivePermissionRequest) (*protos.EffectivePermission, error) {
+	if req.FileId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "file_id and user_id are required")
+	}
+	if err := requireUUIDs("file_id and user_id", req.FileId, req.UserId); err != nil {
+		return nil, err
+	}
+	if req.ShareLinkId != "" {
+		if err := requireUUIDs("share_link_id", req.ShareLinkId); err != nil {
+			return nil, err
+		}
+	}
+	effective, err := s.Repo.GetEffectivePermission(ctx, req.FileId, req.UserId, req.ShareLinkId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	sources := make([]*protos.PermissionSource, len(effective.Sources))
+	for i, src := range effective.Sources {
+		sources[i] = permissionSourceModelToProto(src)
+	}
+	return &protos.EffectivePermission{
+		FileId:     effective.FileID,
+		UserId:     effective.UserID,
+		Role:       permissionRoleToProto[effective.Role],
+		AllowShare: effective.AllowShare,
+		Sources:    sources,
+	}, nil
+}
+
+// authorizeShare проверяет, что actingUserID может управлять доступом к файлу: у него есть
+// основание доступа с allow_share, и ни одна из roles не выше роли этого основания.
+// Иначе возвращает PermissionDenied с причиной.
+func (s *Server) authorizeShare(ctx context.Context, fileID, actingUserID string, roles ...string) error {
+	effective, err := s.Repo.GetEffectivePermission(ctx, fileID, actingUserID, "")
+	if err != nil {
+		return toStatusError(err)
+	}
+	if effective.Role == "" {
+		return status.Error(codes.PermissionDenied, "acting user has no access to the file")
+	}
+
+	// Источники отсортированы по силе роли: первый с allow_share задаёт потолок
+	shareRole := ""
+	for _, src := range effective.Sources {
+		if src.AllowShare {
+			shareRole = src.Role
+			break
+		}
+	}
+	if shareRole == "" {
+		return status.Error(codes.PermissionDenied, "acting user is not allowed to share the file")
+	}
+	for _, role := range roles {
+		if models.RoleRank(role) > models.RoleRank(shareRole) {
+			return status.Errorf(codes.PermissionDenied, "acting user cannot grant or manage role %s above own role %s", role, shareRole)
+		}
+	}
+	return nil
+}
+
+// validateGrantee проверяет, что поля получателя соответствуют grantee_type
+func validateGrantee(p *models.FilePermission) error {
+	switch p.GranteeType {
+	case models.GranteeUser, models.GranteeGroup:
+		if p.GranteeID == nil || p.Domain != nil {
+			return status.Errorf(codes.InvalidArgument, "%s grantee requires grantee_id and no domain", p.GranteeType)
+		}
+		return requireUUIDs("grantee_id", *p.GranteeID)
+	case models.GranteeDomain:
+		if p.GranteeID != nil || p.Domain == nil || strings.Contains(*p.Domain, "@") {
+			return status.Error(codes.InvalidArgument, "DOMAIN grantee requires a bare domain and no grantee_id")
+		}
+	case models.GranteeAnyone:
+		if p.GranteeID != nil || p.Domain != nil {
+			return status.Error(codes.InvalidArgument, "ANYONE grantee takes neither grantee_id nor domain")
+		}
+	}
+	return nil
+}
+
 // File metadata operations
 func (s *Server) UpdateFileMetadata(ctx context.Context, req *protos.UpdateFileMetadataRequest) (*emptypb.Empty, error) {
 	if err := s.Repo.UpdateFileMetadata(ctx, req.FileId, req.Metadata); err != nil {
@@ -538,6 +800,32 @@ func (s *Server) RenameFile(ctx context.Context, req *protos.RenameFileRequest)
 	return &emptypb.Empty{}, nil
 }
 
+func (s *Server) TransferOwnership(ctx context.Context, req *protos.TransferOwnershipRequest) (*protos.TransferOwnershipResponse, error) {
+	if req.FileId == "" || req.NewOwnerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "file_id and new_owner_id are required")
+	}
+	if err := requireUUIDs("file_id and new_owner_id", req.FileId, req.NewOwnerId); err != nil {
+		return nil, err
+	}
+	var keepRole string
+	switch req.KeepPreviousAsRole {
+	case protos.PermissionRole_PERMISSION_ROLE_UNSPECIFIED:
+	case protos.PermissionRole_PERMISSION_ROLE_READER, protos.PermissionRole_PERMISSION_ROLE_COMMENTER, protos.PermissionRole_PERMISSION_ROLE_WRITER:
+		keepRole = permissionRoleNames[req.KeepPreviousAsRole]
+	default:
+		return nil, status.Error(codes.InvalidArgument, "keep_previous_as_role must be READER, COMMENTER or WRITER")
+	}
+	transfer, err := s.Repo.TransferOwnership(ctx, req.FileId, req.NewOwnerId, keepRole)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.TransferOwnershipResponse{
+		PreviousOwnerId:  transfer.PreviousOwnerID,
+		FilesTransferred: int32(transfer.FilesTransferred),
+		BytesTransferred: transfer.BytesTransferred,
+	}, nil
+}
+
 // File integrity operations
 func (s *Server) VerifyFileIntegrity(ctx context.Context, req *protos.FileID) (*protos.IntegrityResponse, error) {
 	isVerified, err := s.Repo.VerifyFileIntegrity(ctx, req.Id)
@@ -654,15 +942,18 @@ func fileRevisionModelToProto(fr *models.FileRevision) *protos.FileRevision {
 	}
 
 	return &protos.FileRevision{
-		Id:          fr.ID,
-		FileId:      fr.FileID,
-		RevisionId:  fr.RevisionID,
-		Md5Checksum: safeString(fr.MD5Checksum),
-		Size:        fr.Size,
-		CreatedAt:   timestamppb.New(fr.CreatedAt),
-		StoragePath: fr.StoragePath,
-		MimeType:    safeString(fr.MimeType),
-		UserId:      safeString(fr.UserID),
+		Id:             fr.ID,
+		FileId:         fr.FileID,
+		RevisionId:     fr.RevisionID,
+		Md5Checksum:    safeString(fr.MD5Checksum),
+		Sha256Checksum: safeString(fr.SHA256Checksum),
+		Size:           fr.Size,
+		CreatedAt:      timestamppb.New(fr.CreatedAt),
+		StoragePath:    fr.StoragePath,
+		MimeType:       safeString(fr.MimeType),
+		UserId:         safeString(fr.UserID),
+		Label:          safeString(fr.Label),
+		Description:    safeString(fr.Description),
 	}
 }
 
@@ -680,15 +971,18 @@ func protoToFileRevisionModel(fr *protos.FileRevision) *models.FileRevision {
 	}
 
 	return &models.FileRevision{
-		ID:          fr.Id,
-		FileID:      fr.FileId,
-		RevisionID:  fr.RevisionId,
-		MD5Checksum: safeStringPtr(fr.Md5Checksum),
-		Size:        fr.Size,
-		CreatedAt:   fr.CreatedAt.AsTime(),
-		StoragePath: fr.StoragePath,
-		MimeType:    safeStringPtr(fr.MimeType),
-		UserID:      safeStringPtr(fr.UserId),
+		ID:             fr.Id,
+		FileID:         fr.FileId,
+		RevisionID:     fr.RevisionId,
+		MD5Checksum:    safeStringPtr(fr.Md5Checksum),
+		SHA256Checksum: safeStringPtr(fr.Sha256Checksum),
+		Size:           fr.Size,
+		CreatedAt:      fr.CreatedAt.AsTime(),
+		StoragePath:    fr.StoragePath,
+		MimeType:       safeStringPtr(fr.MimeType),
+		UserID:         safeStringPtr(fr.UserId),
+		Label:          safeStringPtr(fr.Label),
+		Description:    safeStringPtr(fr.Description),
 	}
 }
 
@@ -706,21 +1000,54 @@ func filePermissionModelToProto(fp *models.FilePermission) *protos.FilePermissio
 	}
 
 	return &protos.FilePermission{
-		Id:          fp.ID,
-		FileId:      fp.FileID,
-		GranteeId:   safeString(fp.GranteeID),
-		GranteeType: fp.GranteeType,
-		Role:        fp.Role,
-		AllowShare:  fp.AllowShare,
-		CreatedAt:   timestamppb.New(fp.CreatedAt),
+		Id:             fp.ID,
+		FileId:         fp.FileID,
+		GranteeId:      safeString(fp.GranteeID),
+		GranteeKind:    granteeTypeToProto[fp.GranteeType],
+		PermissionRole: permissionRoleToProto[fp.Role],
+		AllowShare:     fp.AllowShare,
+		CreatedAt:      timestamppb.New(fp.CreatedAt),
+		Inherited:      fp.Inherited,
+		InheritedFrom:  safeString(fp.InheritedFrom),
+		Domain:         safeString(fp.Domain),
+		ExpiresAt:      timeToProto(fp.ExpiresAt),
 	}
 }
 
-func protoToFilePermissionModel(fp *protos.FilePermission) *models.FilePermission {
-	if fp == nil {
+func permissionSourceModelToProto(src *models.PermissionSource) *protos.PermissionSource {
+	if src == nil {
 		return nil
 	}
 
+	safeString := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	return &protos.PermissionSource{
+		Type:         src.Type,
+		Role:         permissionRoleToProto[src.Role],
+		AllowShare:   src.AllowShare,
+		PermissionId: safeString(src.PermissionID),
+		FileId:       src.FileID,
+		FileName:     src.FileName,
+		Inherited:    src.Inherited,
+		GroupId:      safeString(src.GroupID),
+		GroupName:    safeString(src.GroupName),
+		Domain:       safeString(src.Domain),
+		ExpiresAt:    timeToProto(src.ExpiresAt),
+	}
+}
+
+// protoToFilePermissionModel переводит право из запроса в модель.
+// Неизвестные role и grantee_type отклоняются с InvalidArgument.
+func protoToFilePermissionModel(fp *protos.FilePermission) (*models.FilePermission, error) {
+	if fp == nil {
+		return nil, nil
+	}
+
 	// Helper function to safely convert string to *string
 	safeStringPtr := func(s string) *string {
 		if s == "" {
@@ -729,15 +1056,26 @@ func protoToFilePermissionModel(fp *protos.FilePermission) *models.FilePermissio
 		return &s
 	}
 
+	role, err := roleFromProto(fp.PermissionRole, "permission_role")
+	if err != nil {
+		return nil, err
+	}
+	granteeType, err := granteeTypeFromProto(fp.GranteeKind)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.FilePermission{
 		ID:          fp.Id,
 		FileID:      fp.FileId,
 		GranteeID:   safeStringPtr(fp.GranteeId),
-		GranteeType: fp.GranteeType,
-		Role:        fp.Role,
+		GranteeType: granteeType,
+		Domain:      safeStringPtr(strings.ToLower(fp.Domain)),
+		Role:        role,
 		AllowShare:  fp.AllowShare,
 		CreatedAt:   fp.CreatedAt.AsTime(),
-	}
+		ExpiresAt:   protoToTime(fp.ExpiresAt),
+	}, nil
 }
 
 func (s *Server) GetUserExtendedInfo(ctx context.Context, req *protos.UserID) (*protos.UserExtendedInfo, error) {
@@ -752,6 +1090,26 @@ func (s *Server) GetUserExtendedInfo(ctx context.Context, req *protos.UserID) (*
 		return nil, nil
 	}
 
+	resp, err := s.userExtendedInfo(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	s.Logger.Debug(ctx, "GetUserExtendedInfo response", zap.Any("response", resp))
+	return resp, nil
+}
+
+// userExtendedInfo вычисляет поля UserExtendedInfo для пользователя u
+func (s *Server) userExtendedInfo(ctx context.Context, u *models.User) (*protos.UserExtendedInfo, error) {
+	tf, err := s.Repo.GetTwoFactor(ctx, u.ID)
+	if err != nil && !errors.Is(err, errdefs.ErrTwoFactorNotEnrolled) {
+		s.Logger.Error(ctx, "GetTwoFactor failed", zap.Error(err))
+		return nil, err
+	}
+	return buildUserExtendedInfo(u, tf), nil
+}
+
+// buildUserExtendedInfo вычисляет расширенную информацию; tf == nil - 2FA не настроена
+func buildUserExtendedInfo(u *models.User, tf *models.TwoFactor) *protos.UserExtendedInfo {
 	// Вычисляемые поля
 	var (
 		usagePct                             float64
@@ -786,11 +1144,20 @@ func (s *Server) GetUserExtendedInfo(ctx context.Context, req *protos.UserID) (*
 		accountStatus = "active"
 	}
 
+	twoFactorEnabled := tf != nil && tf.Enabled
+
 	if !u.IsEmailVerified {
 		securityStatus = "needs_verification"
 		recommendations = append(recommendations, "Подтвердите email")
-	} else {
+	} else if twoFactorEnabled {
 		securityStatus = "secure"
+	} else {
+		securityStatus = "no_2fa"
+	}
+	if !twoFactorEnabled {
+		recommendations = append(recommendations, "Включите 2FA")
+	} else if tf.RecoveryCodesRemaining == 0 {
+		recommendations = append(recommendations, "Создайте новые резервные коды 2FA")
 	}
 
 	if u.FailedLoginAttempts > 3 {
@@ -812,7 +1179,7 @@ func (s *Server) GetUserExtendedInfo(ctx context.Context, req *protos.UserID) (*
 	metadata["email"] = u.Email
 	metadata["username"] = u.Username
 
-	resp := &protos.UserExtendedInfo{
+	return &protos.UserExtendedInfo{
 		User:                      userModelToProto(u),
 		StorageUsagePercentage:    usagePct,
 		StorageUsageFormatted:     usageFmt,
@@ -827,8 +1194,6 @@ func (s *Server) GetUserExtendedInfo(ctx context.Context, req *protos.UserID) (*
 		Recommendations:           recommendations,
 		Metadata:                  metadata,
 	}
-	s.Logger.Debug(ctx, "GetUserExtendedInfo response", zap.Any("response", resp))
-	return resp, nil
 }
 
 // formatBytes - форматирует байты в строку (например, 1.5 GB)
@@ -0,0 +1,31 @@
+package dbManagerServer
+
+import (
+	"testing"
+	"time"
+
+	"homecloud--dbmanager-service/internal/models"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildUserExtendedInfoSecurityStatus(t *testing.T) {
+	verified := &models.User{IsEmailVerified: true, CreatedAt: time.Now()}
+
+	info := buildUserExtendedInfo(verified, nil)
+	require.Equal(t, "no_2fa", info.SecurityStatus)
+	require.Contains(t, info.Recommendations, "Включите 2FA")
+
+	info = buildUserExtendedInfo(verified, &models.TwoFactor{Enabled: false})
+	require.Equal(t, "no_2fa", info.SecurityStatus)
+
+	info = buildUserExtendedInfo(verified, &models.TwoFactor{Enabled: true, RecoveryCodesRemaining: 3})
+	require.Equal(t, "secure", info.SecurityStatus)
+	require.NotContains(t, info.Recommendations, "Включите 2FA")
+
+	info = buildUserExtendedInfo(verified, &models.TwoFactor{Enabled: true})
+	require.Contains(t, info.Recommendations, "Создайте новые резервные коды 2FA")
+
+	info = buildUserExtendedInfo(&models.User{CreatedAt: time.Now()}, &models.TwoFactor{Enabled: true, RecoveryCodesRemaining: 3})
+	require.Equal(t, "needs_verification", info.SecurityStatus)
+}
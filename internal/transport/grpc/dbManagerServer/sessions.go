@@ -0,0 +1,140 @@
+package dbManagerServer
+
+import (
+	"context"
+	"time"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var rotateSessionStatusToProto = map[string]protos.RotateSessionStatus{
+	models.SessionRotated:       protos.RotateSessionStatus_ROTATE_SESSION_STATUS_ROTATED,
+	models.SessionNotFound:      protos.RotateSessionStatus_ROTATE_SESSION_STATUS_NOT_FOUND,
+	models.SessionExpired:       protos.RotateSessionStatus_ROTATE_SESSION_STATUS_EXPIRED,
+	models.SessionRevoked:       protos.RotateSessionStatus_ROTATE_SESSION_STATUS_REVOKED,
+	models.SessionReuseDetected: protos.RotateSessionStatus_ROTATE_SESSION_STATUS_REUSE_DETECTED,
+}
+
+// Session operations
+func (s *Server) CreateSession(ctx context.Context, req *protos.CreateSessionRequest) (*protos.SessionTokenResponse, error) {
+	switch {
+	case req.UserId == "":
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	case req.ExpiresAt == nil || !req.ExpiresAt.AsTime().After(time.Now()):
+		return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	session, token, err := s.Repo.CreateSession(ctx, &models.Session{
+		UserID:     req.UserId,
+		DeviceName: stringPtrOrNil(req.DeviceName),
+		IPAddress:  stringPtrOrNil(req.IpAddress),
+		UserAgent:  stringPtrOrNil(req.UserAgent),
+		ExpiresAt:  req.ExpiresAt.AsTime(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.SessionTokenResponse{Session: sessionModelToProto(session), RefreshToken: token}, nil
+}
+
+func (s *Server) RotateSession(ctx context.Context, req *protos.RotateSessionRequest) (*protos.RotateSessionResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+	rotation, err := s.Repo.RotateSession(ctx, req.RefreshToken, stringPtrOrNil(req.IpAddress), stringPtrOrNil(req.UserAgent))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if rotation.Status == models.SessionReuseDetected {
+		s.Logger.Info(ctx, "refresh token reuse detected, session family revoked",
+			zap.String("user_id", rotation.Session.UserID), zap.String("family_id", rotation.Session.FamilyID))
+	}
+
+	return &protos.RotateSessionResponse{
+		Status:       rotateSessionStatusToProto[rotation.Status],
+		Session:      sessionModelToProto(rotation.Session),
+		RefreshToken: rotation.RefreshToken,
+	}, nil
+}
+
+func (s *Server) ListSessions(ctx context.Context, req *protos.UserID) (*protos.ListSessionsResponse, error) {
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	sessions, err := s.Repo.ListSessions(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoSessions := make([]*protos.Session, len(sessions))
+	for i, session := range sessions {
+		protoSessions[i] = sessionModelToProto(session)
+	}
+	return &protos.ListSessionsResponse{Sessions: protoSessions}, nil
+}
+
+func (s *Server) RevokeSession(ctx context.Context, req *protos.SessionID) (*emptypb.Empty, error) {
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.RevokeSession(ctx, req.Id); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) RevokeAllSessions(ctx context.Context, req *protos.RevokeAllSessionsRequest) (*protos.RevokeAllSessionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if req.ExceptSessionId != "" {
+		if err := requireUUIDs("except_session_id", req.ExceptSessionId); err != nil {
+			return nil, err
+		}
+	}
+	revoked, err := s.Repo.RevokeAllSessions(ctx, req.UserId, req.ExceptSessionId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.RevokeAllSessionsResponse{Revoked: int32(revoked)}, nil
+}
+
+func sessionModelToProto(sess *models.Session) *protos.Session {
+	if sess == nil {
+		return nil
+	}
+
+	safeString := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	return &protos.Session{
+		Id:            sess.ID,
+		FamilyId:      sess.FamilyID,
+		UserId:        sess.UserID,
+		DeviceName:    safeString(sess.DeviceName),
+		IpAddress:     safeString(sess.IPAddress),
+		UserAgent:     safeString(sess.UserAgent),
+		CreatedAt:     timestamppb.New(sess.CreatedAt),
+		LastUsedAt:    timestamppb.New(sess.LastUsedAt),
+		ExpiresAt:     timestamppb.New(sess.ExpiresAt),
+		RevokedAt:     timeToProto(sess.RevokedAt),
+		RevokedReason: safeString(sess.RevokedReason),
+	}
+}
@@ -0,0 +1,135 @@
+package dbManagerServer
+
+import (
+	"context"
+	"time"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var shareLinkStatusToProto = map[string]protos.ShareLinkStatus{
+	models.ShareLinkValid:            protos.ShareLinkStatus_SHARE_LINK_STATUS_VALID,
+	models.ShareLinkNotFound:         protos.ShareLinkStatus_SHARE_LINK_STATUS_NOT_FOUND,
+	models.ShareLinkRevoked:          protos.ShareLinkStatus_SHARE_LINK_STATUS_REVOKED,
+	models.ShareLinkExpired:          protos.ShareLinkStatus_SHARE_LINK_STATUS_EXPIRED,
+	models.ShareLinkUseLimitReached:  protos.ShareLinkStatus_SHARE_LINK_STATUS_USE_LIMIT_REACHED,
+	models.ShareLinkPasswordRequired: protos.ShareLinkStatus_SHARE_LINK_STATUS_PASSWORD_REQUIRED,
+	models.ShareLinkFileUnavailable:  protos.ShareLinkStatus_SHARE_LINK_STATUS_FILE_UNAVAILABLE,
+}
+
+// Share link operations
+func (s *Server) CreateShareLink(ctx context.Context, req *protos.ShareLink) (*protos.CreateShareLinkResponse, error) {
+	switch {
+	case req.FileId == "" || req.CreatedBy == "":
+		return nil, status.Error(codes.InvalidArgument, "file_id and created_by are required")
+	case req.Role != protos.PermissionRole_PERMISSION_ROLE_READER && req.Role != protos.PermissionRole_PERMISSION_ROLE_COMMENTER && req.Role != protos.PermissionRole_PERMISSION_ROLE_WRITER:
+		return nil, status.Error(codes.InvalidArgument, "role must be READER, COMMENTER or WRITER")
+	case req.MaxUses < 0:
+		return nil, status.Error(codes.InvalidArgument, "max_uses must not be negative")
+	case req.ExpiresAt != nil && !req.ExpiresAt.AsTime().After(time.Now()):
+		return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+	}
+	if err := requireUUIDs("file_id and created_by", req.FileId, req.CreatedBy); err != nil {
+		return nil, err
+	}
+
+	link := &models.ShareLink{
+		FileID:    req.FileId,
+		Role:      permissionRoleNames[req.Role],
+		CreatedBy: req.CreatedBy,
+		ExpiresAt: protoToTime(req.ExpiresAt),
+	}
+	if req.PasswordHash != "" {
+		link.PasswordHash = &req.PasswordHash
+	}
+	if req.MaxUses > 0 {
+		link.MaxUses = &req.MaxUses
+	}
+
+	id, token, err := s.Repo.CreateShareLink(ctx, link)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.CreateShareLinkResponse{Id: id, Token: token}, nil
+}
+
+func (s *Server) ListShareLinks(ctx context.Context, req *protos.FileID) (*protos.ListShareLinksResponse, error) {
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	links, err := s.Repo.ListShareLinks(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoLinks := make([]*protos.ShareLink, len(links))
+	for i, link := range links {
+		protoLinks[i] = shareLinkModelToProto(link)
+	}
+	return &protos.ListShareLinksResponse{Links: protoLinks}, nil
+}
+
+func (s *Server) RevokeShareLink(ctx context.Context, req *protos.ShareLinkID) (*emptypb.Empty, error) {
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.RevokeShareLink(ctx, req.Id); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ResolveShareLink(ctx context.Context, req *protos.ResolveShareLinkRequest) (*protos.ResolveShareLinkResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	resolution, err := s.Repo.ResolveShareLink(ctx, req.Token, req.PasswordVerified)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &protos.ResolveShareLinkResponse{Status: shareLinkStatusToProto[resolution.Status]}
+	switch resolution.Status {
+	case models.ShareLinkValid:
+		resp.FileId = resolution.Link.FileID
+		resp.Role = permissionRoleToProto[resolution.Link.Role]
+		resp.LinkId = resolution.Link.ID
+	case models.ShareLinkPasswordRequired:
+		resp.LinkId = resolution.Link.ID
+		if resolution.Link.PasswordHash != nil {
+			resp.PasswordHash = *resolution.Link.PasswordHash
+		}
+	}
+	return resp, nil
+}
+
+func shareLinkModelToProto(l *models.ShareLink) *protos.ShareLink {
+	if l == nil {
+		return nil
+	}
+
+	// Хеш пароля наружу не отдаём, достаточно признака его наличия
+	link := &protos.ShareLink{
+		Id:          l.ID,
+		FileId:      l.FileID,
+		Role:        permissionRoleToProto[l.Role],
+		CreatedBy:   l.CreatedBy,
+		CreatedAt:   timestamppb.New(l.CreatedAt),
+		ExpiresAt:   timeToProto(l.ExpiresAt),
+		UseCount:    l.UseCount,
+		LastUsedAt:  timeToProto(l.LastUsedAt),
+		Revoked:     l.Revoked,
+		RevokedAt:   timeToProto(l.RevokedAt),
+		HasPassword: l.PasswordHash != nil,
+	}
+	if l.MaxUses != nil {
+		link.MaxUses = *l.MaxUses
+	}
+	return link
+}
@@ -0,0 +1,113 @@
+package dbManagerServer
+
+import (
+	"context"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultUnreferencedBlobsLimit = 100
+
+// Content deduplication operations
+func (s *Server) FindBySHA256(ctx context.Context, req *protos.FindBySHA256Request) (*protos.StorageBlob, error) {
+	if req.Sha256Checksum == "" {
+		return nil, status.Error(codes.InvalidArgument, "sha256_checksum is required")
+	}
+	if req.OwnerId != "" {
+		if err := requireUUIDs("owner_id", req.OwnerId); err != nil {
+			return nil, err
+		}
+	}
+	blob, err := s.Repo.FindBySHA256(ctx, req.OwnerId, req.Sha256Checksum)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return storageBlobModelToProto(blob), nil
+}
+
+func (s *Server) FindDuplicates(ctx context.Context, req *protos.FindDuplicatesRequest) (*protos.FindDuplicatesResponse, error) {
+	if err := requireUUIDs("owner_id", req.OwnerId); err != nil {
+		return nil, err
+	}
+	groups, err := s.Repo.FindDuplicates(ctx, req.OwnerId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &protos.FindDuplicatesResponse{Groups: make([]*protos.DuplicateGroup, len(groups))}
+	for i, group := range groups {
+		protoFiles := make([]*protos.File, len(group.Files))
+		for j, file := range group.Files {
+			protoFiles[j] = fileModelToProto(file)
+		}
+		resp.Groups[i] = &protos.DuplicateGroup{
+			Sha256Checksum: group.SHA256Checksum,
+			Size:           group.Size,
+			Files:          protoFiles,
+			WastedBytes:    group.WastedBytes,
+		}
+		resp.TotalWastedBytes += group.WastedBytes
+	}
+	return resp, nil
+}
+
+func (s *Server) GetStorageBlob(ctx context.Context, req *protos.StoragePathRequest) (*protos.StorageBlob, error) {
+	blob, err := s.Repo.GetStorageBlob(ctx, req.StoragePath)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return storageBlobModelToProto(blob), nil
+}
+
+func (s *Server) ListUnreferencedBlobs(ctx context.Context, req *protos.ListUnreferencedBlobsRequest) (*protos.ListStorageBlobsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultUnreferencedBlobsLimit
+	}
+	blobs, err := s.Repo.ListUnreferencedBlobs(ctx, limit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoBlobs := make([]*protos.StorageBlob, len(blobs))
+	for i, blob := range blobs {
+		protoBlobs[i] = storageBlobModelToProto(blob)
+	}
+	return &protos.ListStorageBlobsResponse{Blobs: protoBlobs}, nil
+}
+
+func (s *Server) ReleaseBlob(ctx context.Context, req *protos.StoragePathRequest) (*protos.ReleaseBlobResponse, error) {
+	if req.StoragePath == "" {
+		return nil, status.Error(codes.InvalidArgument, "storage_path is required")
+	}
+	released, err := s.Repo.ReleaseBlob(ctx, req.StoragePath)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.ReleaseBlobResponse{Released: released}, nil
+}
+
+func storageBlobModelToProto(b *models.StorageBlob) *protos.StorageBlob {
+	if b == nil {
+		return nil
+	}
+
+	sha256 := ""
+	if b.SHA256Checksum != nil {
+		sha256 = *b.SHA256Checksum
+	}
+
+	return &protos.StorageBlob{
+		StoragePath:    b.StoragePath,
+		Sha256Checksum: sha256,
+		Size:           b.Size,
+		RefCount:       b.RefCount,
+		CreatedAt:      timestamppb.New(b.CreatedAt),
+		ReleasedAt:     timeToProto(b.ReleasedAt),
+	}
+}
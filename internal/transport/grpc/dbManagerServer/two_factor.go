@@ -0,0 +1,156 @@
+package dbManagerServer
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"homecloud--dbmanager-service/internal/errdefs"
+	"homecloud--dbmanager-service/internal/models"
+	"homecloud--dbmanager-service/internal/totp"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Допустимое расхождение часов клиента - один интервал в каждую сторону
+const totpSkew = 1
+
+// Two-factor operations
+func (s *Server) EnrollTwoFactor(ctx context.Context, req *protos.UserID) (*protos.EnrollTwoFactorResponse, error) {
+	if s.TwoFactor == nil {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor encryption key is not configured")
+	}
+	u, err := s.Repo.GetUserByID(ctx, req.Id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, toStatusError(errdefs.ErrUserNotFound)
+		}
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.TwoFactor.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.SaveTwoFactorSecret(ctx, u.ID, encrypted); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.EnrollTwoFactorResponse{
+		Secret:     totp.EncodeSecret(secret),
+		OtpauthUrl: totp.URL(secret, s.TwoFactorIssuer, u.Email),
+	}, nil
+}
+
+func (s *Server) ConfirmTwoFactor(ctx context.Context, req *protos.TwoFactorCodeRequest) (*protos.ConfirmTwoFactorResponse, error) {
+	tf, secret, err := s.loadTwoFactor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, toStatusError(errdefs.ErrTwoFactorAlreadyEnabled)
+	}
+	step, ok := totp.Match(secret, req.Code, time.Now(), totpSkew)
+	if !ok {
+		return &protos.ConfirmTwoFactorResponse{Confirmed: false}, nil
+	}
+
+	recoveryCodes, err := s.Repo.EnableTwoFactor(ctx, req.UserId, step)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	s.Logger.Info(ctx, "two-factor authentication enabled", zap.String("user_id", req.UserId))
+	return &protos.ConfirmTwoFactorResponse{Confirmed: true, RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *Server) VerifyTwoFactor(ctx context.Context, req *protos.TwoFactorCodeRequest) (*protos.VerifyTwoFactorResponse, error) {
+	tf, secret, err := s.loadTwoFactor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !tf.Enabled {
+		return nil, toStatusError(errdefs.ErrTwoFactorNotEnrolled)
+	}
+	step, ok := totp.Match(secret, req.Code, time.Now(), totpSkew)
+	if !ok {
+		return &protos.VerifyTwoFactorResponse{Valid: false}, nil
+	}
+
+	// Код уже принимался - повтор отклоняем
+	valid, err := s.Repo.UseTwoFactorStep(ctx, req.UserId, step)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.VerifyTwoFactorResponse{Valid: valid}, nil
+}
+
+func (s *Server) ConsumeRecoveryCode(ctx context.Context, req *protos.TwoFactorCodeRequest) (*protos.ConsumeRecoveryCodeResponse, error) {
+	if req.UserId == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and code are required")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	valid, remaining, err := s.Repo.ConsumeRecoveryCode(ctx, req.UserId, req.Code)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if valid {
+		s.Logger.Info(ctx, "recovery code used", zap.String("user_id", req.UserId), zap.Int("remaining", remaining))
+	}
+	return &protos.ConsumeRecoveryCodeResponse{Valid: valid, Remaining: int32(remaining)}, nil
+}
+
+// RegenerateRecoveryCodes заменяет резервные коды по действующему TOTP-коду
+func (s *Server) RegenerateRecoveryCodes(ctx context.Context, req *protos.TwoFactorCodeRequest) (*protos.RegenerateRecoveryCodesResponse, error) {
+	tf, secret, err := s.loadTwoFactor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !tf.Enabled {
+		return nil, toStatusError(errdefs.ErrTwoFactorNotEnrolled)
+	}
+	step, ok := totp.Match(secret, req.Code, time.Now(), totpSkew)
+	if !ok {
+		return &protos.RegenerateRecoveryCodesResponse{Valid: false}, nil
+	}
+
+	recoveryCodes, err := s.Repo.RegenerateRecoveryCodes(ctx, req.UserId, step)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if recoveryCodes == nil {
+		return &protos.RegenerateRecoveryCodesResponse{Valid: false}, nil
+	}
+	s.Logger.Info(ctx, "recovery codes regenerated", zap.String("user_id", req.UserId))
+	return &protos.RegenerateRecoveryCodesResponse{Valid: true, RecoveryCodes: recoveryCodes}, nil
+}
+
+// loadTwoFactor читает настройки 2FA пользователя и расшифровывает секрет
+func (s *Server) loadTwoFactor(ctx context.Context, req *protos.TwoFactorCodeRequest) (*models.TwoFactor, []byte, error) {
+	if req.UserId == "" || req.Code == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "user_id and code are required")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, nil, err
+	}
+	if s.TwoFactor == nil {
+		return nil, nil, status.Error(codes.FailedPrecondition, "two-factor encryption key is not configured")
+	}
+	tf, err := s.Repo.GetTwoFactor(ctx, req.UserId)
+	if err != nil {
+		return nil, nil, toStatusError(err)
+	}
+	secret, err := s.TwoFactor.Decrypt(tf.EncryptedSecret)
+	if err != nil {
+		s.Logger.Error(ctx, "failed to decrypt two-factor secret", zap.String("user_id", req.UserId), zap.Error(err))
+		return nil, nil, err
+	}
+	return tf, secret, nil
+}
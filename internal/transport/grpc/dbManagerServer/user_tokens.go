@@ -0,0 +1,88 @@
+package dbManagerServer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var userTokenPurposeNames = map[protos.UserTokenPurpose]string{
+	protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION: models.TokenPurposeEmailVerification,
+	protos.UserTokenPurpose_USER_TOKEN_PURPOSE_PASSWORD_RESET:     models.TokenPurposePasswordReset,
+	protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_CHANGE:       models.TokenPurposeEmailChange,
+}
+
+var consumeTokenStatusToProto = map[string]protos.ConsumeTokenStatus{
+	models.TokenConsumed:    protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_CONSUMED,
+	models.TokenNotFound:    protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_NOT_FOUND,
+	models.TokenExpired:     protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_EXPIRED,
+	models.TokenAlreadyUsed: protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_ALREADY_USED,
+}
+
+// One-time token operations
+func (s *Server) IssueToken(ctx context.Context, req *protos.IssueTokenRequest) (*protos.IssueTokenResponse, error) {
+	purpose, ok := userTokenPurposeNames[req.Purpose]
+	switch {
+	case req.UserId == "":
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	case !ok:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown purpose: %v", req.Purpose)
+	case req.ExpiresAt == nil || !req.ExpiresAt.AsTime().After(time.Now()):
+		return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+	case (purpose == models.TokenPurposeEmailChange) != (req.NewEmail != ""):
+		return nil, status.Error(codes.InvalidArgument, "new_email is required for EMAIL_CHANGE and only for it")
+	case req.NewEmail != "" && !strings.Contains(req.NewEmail, "@"):
+		return nil, status.Error(codes.InvalidArgument, "new_email is not a valid email")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	token, secret, err := s.Repo.IssueToken(ctx, &models.UserToken{
+		UserID:    req.UserId,
+		Purpose:   purpose,
+		NewEmail:  stringPtrOrNil(req.NewEmail),
+		ExpiresAt: req.ExpiresAt.AsTime(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &protos.IssueTokenResponse{Id: token.ID, Token: secret, ExpiresAt: timestamppb.New(token.ExpiresAt)}, nil
+}
+
+func (s *Server) ConsumeToken(ctx context.Context, req *protos.ConsumeTokenRequest) (*protos.ConsumeTokenResponse, error) {
+	purpose, ok := userTokenPurposeNames[req.Purpose]
+	switch {
+	case req.Token == "":
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	case !ok:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown purpose: %v", req.Purpose)
+	case purpose == models.TokenPurposePasswordReset && req.NewPasswordHash == "":
+		return nil, status.Error(codes.InvalidArgument, "new_password_hash is required for PASSWORD_RESET")
+	}
+
+	audit, err := auditFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	consumption, err := s.Repo.ConsumeToken(ctx, req.Token, purpose, req.NewPasswordHash, s.PasswordHistoryDepth, audit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &protos.ConsumeTokenResponse{Status: consumeTokenStatusToProto[consumption.Status]}
+	if consumption.Status == models.TokenConsumed {
+		resp.UserId = consumption.Token.UserID
+		if consumption.Token.NewEmail != nil {
+			resp.NewEmail = *consumption.Token.NewEmail
+		}
+	}
+	return resp, nil
+}
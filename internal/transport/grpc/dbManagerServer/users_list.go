@@ -0,0 +1,83 @@
+package dbManagerServer
+
+import (
+	"context"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultUsersPageSize = 50
+	maxUsersPageSize     = 500
+)
+
+var userSortFieldNames = map[protos.UserSortField]string{
+	protos.UserSortField_USER_SORT_FIELD_UNSPECIFIED:   models.UserSortCreatedAt,
+	protos.UserSortField_USER_SORT_FIELD_CREATED_AT:    models.UserSortCreatedAt,
+	protos.UserSortField_USER_SORT_FIELD_LAST_LOGIN_AT: models.UserSortLastLoginAt,
+	protos.UserSortField_USER_SORT_FIELD_EMAIL:         models.UserSortEmail,
+	protos.UserSortField_USER_SORT_FIELD_USERNAME:      models.UserSortUsername,
+	protos.UserSortField_USER_SORT_FIELD_USED_SPACE:    models.UserSortUsedSpace,
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *protos.ListUsersRequest) (*protos.ListUsersResponse, error) {
+	sortField, ok := userSortFieldNames[req.SortBy]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown sort_by %v", req.SortBy)
+	}
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case pageSize == 0:
+		pageSize = defaultUsersPageSize
+	case pageSize > maxUsersPageSize:
+		pageSize = maxUsersPageSize
+	}
+
+	filter := models.UserFilter{
+		Role:            req.Role,
+		IsActive:        req.IsActive,
+		IsEmailVerified: req.IsEmailVerified,
+		Locked:          req.IsLocked,
+		QuotaExceeded:   req.IsQuotaExceeded,
+		CreatedAfter:    protoToTime(req.CreatedAfter),
+		CreatedBefore:   protoToTime(req.CreatedBefore),
+		LastLoginAfter:  protoToTime(req.LastLoginAfter),
+		LastLoginBefore: protoToTime(req.LastLoginBefore),
+		Search:          req.Search,
+	}
+	page, err := s.Repo.ListUsers(ctx, filter, models.UserSort{Field: sortField, Descending: req.Descending}, pageSize, req.PageToken)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &protos.ListUsersResponse{
+		Users:         make([]*protos.User, len(page.Users)),
+		NextPageToken: page.NextCursor,
+		Total:         page.Total,
+	}
+	for i, u := range page.Users {
+		resp.Users[i] = userModelToProto(u)
+	}
+	if req.IncludeExtendedInfo {
+		// 2FA всей страницы одним запросом, остальное вычисляется из строки пользователя
+		userIDs := make([]string, len(page.Users))
+		for i, u := range page.Users {
+			userIDs[i] = u.ID
+		}
+		twoFactors, err := s.Repo.ListTwoFactors(ctx, userIDs)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		resp.ExtendedInfo = make([]*protos.UserExtendedInfo, len(page.Users))
+		for i, u := range page.Users {
+			resp.ExtendedInfo[i] = buildUserExtendedInfo(u, twoFactors[u.ID])
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,141 @@
+package dbManagerServer
+
+import (
+	"context"
+	"strings"
+
+	"homecloud--dbmanager-service/internal/models"
+	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var signCountStatusToProto = map[string]protos.WebAuthnSignCountStatus{
+	models.SignCountUpdated:       protos.WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_UPDATED,
+	models.SignCountNotFound:      protos.WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_NOT_FOUND,
+	models.SignCountCloneDetected: protos.WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_CLONE_DETECTED,
+}
+
+// WebAuthn credential operations
+func (s *Server) RegisterWebAuthnCredential(ctx context.Context, req *protos.WebAuthnCredential) (*protos.WebAuthnCredential, error) {
+	name := strings.TrimSpace(req.Name)
+	switch {
+	case req.UserId == "":
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	case len(req.CredentialId) == 0:
+		return nil, status.Error(codes.InvalidArgument, "credential_id is required")
+	case len(req.PublicKey) == 0:
+		return nil, status.Error(codes.InvalidArgument, "public_key is required")
+	case name == "":
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if err := requireUUIDs("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	cred, err := s.Repo.RegisterWebAuthnCredential(ctx, &models.WebAuthnCredential{
+		UserID:       req.UserId,
+		CredentialID: req.CredentialId,
+		PublicKey:    req.PublicKey,
+		SignCount:    req.SignCount,
+		Transports:   req.Transports,
+		Name:         name,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return webAuthnCredentialModelToProto(cred), nil
+}
+
+func (s *Server) GetWebAuthnCredential(ctx context.Context, req *protos.GetWebAuthnCredentialRequest) (*protos.WebAuthnCredential, error) {
+	if len(req.CredentialId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "credential_id is required")
+	}
+	cred, err := s.Repo.GetWebAuthnCredential(ctx, req.CredentialId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return webAuthnCredentialModelToProto(cred), nil
+}
+
+func (s *Server) ListWebAuthnCredentials(ctx context.Context, req *protos.UserID) (*protos.ListWebAuthnCredentialsResponse, error) {
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	creds, err := s.Repo.ListWebAuthnCredentials(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoCreds := make([]*protos.WebAuthnCredential, len(creds))
+	for i, cred := range creds {
+		protoCreds[i] = webAuthnCredentialModelToProto(cred)
+	}
+	return &protos.ListWebAuthnCredentialsResponse{Credentials: protoCreds}, nil
+}
+
+func (s *Server) RenameWebAuthnCredential(ctx context.Context, req *protos.RenameWebAuthnCredentialRequest) (*protos.WebAuthnCredential, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	cred, err := s.Repo.RenameWebAuthnCredential(ctx, req.Id, name)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return webAuthnCredentialModelToProto(cred), nil
+}
+
+func (s *Server) DeleteWebAuthnCredential(ctx context.Context, req *protos.WebAuthnCredentialID) (*emptypb.Empty, error) {
+	if err := requireUUIDs("id", req.Id); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.DeleteWebAuthnCredential(ctx, req.Id); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) UpdateWebAuthnSignCount(ctx context.Context, req *protos.UpdateWebAuthnSignCountRequest) (*protos.UpdateWebAuthnSignCountResponse, error) {
+	if len(req.CredentialId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "credential_id is required")
+	}
+	update, err := s.Repo.UpdateWebAuthnSignCount(ctx, req.CredentialId, req.SignCount)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if update.Status == models.SignCountCloneDetected {
+		s.Logger.Info(ctx, "webauthn sign count regression, possible cloned authenticator",
+			zap.String("user_id", update.Credential.UserID), zap.String("credential", update.Credential.ID),
+			zap.Uint32("stored", update.Credential.SignCount), zap.Uint32("presented", req.SignCount))
+	}
+
+	return &protos.UpdateWebAuthnSignCountResponse{
+		Status:     signCountStatusToProto[update.Status],
+		Credential: webAuthnCredentialModelToProto(update.Credential),
+	}, nil
+}
+
+func webAuthnCredentialModelToProto(cred *models.WebAuthnCredential) *protos.WebAuthnCredential {
+	if cred == nil {
+		return nil
+	}
+	return &protos.WebAuthnCredential{
+		Id:           cred.ID,
+		UserId:       cred.UserID,
+		CredentialId: cred.CredentialID,
+		PublicKey:    cred.PublicKey,
+		SignCount:    cred.SignCount,
+		Transports:   cred.Transports,
+		Name:         cred.Name,
+		CreatedAt:    timestamppb.New(cred.CreatedAt),
+		LastUsedAt:   timeToProto(cred.LastUsedAt),
+	}
+}
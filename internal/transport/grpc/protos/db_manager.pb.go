@@ -10,6 +10,8 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -23,6 +25,544 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Одноразовые токены пользователя
+type UserTokenPurpose int32
+
+const (
+	UserTokenPurpose_USER_TOKEN_PURPOSE_UNSPECIFIED        UserTokenPurpose = 0
+	UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION UserTokenPurpose = 1
+	UserTokenPurpose_USER_TOKEN_PURPOSE_PASSWORD_RESET     UserTokenPurpose = 2
+	UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_CHANGE       UserTokenPurpose = 3
+)
+
+// Enum value maps for UserTokenPurpose.
+var (
+	UserTokenPurpose_name = map[int32]string{
+		0: "USER_TOKEN_PURPOSE_UNSPECIFIED",
+		1: "USER_TOKEN_PURPOSE_EMAIL_VERIFICATION",
+		2: "USER_TOKEN_PURPOSE_PASSWORD_RESET",
+		3: "USER_TOKEN_PURPOSE_EMAIL_CHANGE",
+	}
+	UserTokenPurpose_value = map[string]int32{
+		"USER_TOKEN_PURPOSE_UNSPECIFIED":        0,
+		"USER_TOKEN_PURPOSE_EMAIL_VERIFICATION": 1,
+		"USER_TOKEN_PURPOSE_PASSWORD_RESET":     2,
+		"USER_TOKEN_PURPOSE_EMAIL_CHANGE":       3,
+	}
+)
+
+func (x UserTokenPurpose) Enum() *UserTokenPurpose {
+	p := new(UserTokenPurpose)
+	*p = x
+	return p
+}
+
+func (x UserTokenPurpose) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserTokenPurpose) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[0].Descriptor()
+}
+
+func (UserTokenPurpose) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[0]
+}
+
+func (x UserTokenPurpose) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserTokenPurpose.Descriptor instead.
+func (UserTokenPurpose) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{0}
+}
+
+type ConsumeTokenStatus int32
+
+const (
+	ConsumeTokenStatus_CONSUME_TOKEN_STATUS_UNSPECIFIED  ConsumeTokenStatus = 0
+	ConsumeTokenStatus_CONSUME_TOKEN_STATUS_CONSUMED     ConsumeTokenStatus = 1 // Токен использован, действие применено
+	ConsumeTokenStatus_CONSUME_TOKEN_STATUS_NOT_FOUND    ConsumeTokenStatus = 2
+	ConsumeTokenStatus_CONSUME_TOKEN_STATUS_EXPIRED      ConsumeTokenStatus = 3
+	ConsumeTokenStatus_CONSUME_TOKEN_STATUS_ALREADY_USED ConsumeTokenStatus = 4
+)
+
+// Enum value maps for ConsumeTokenStatus.
+var (
+	ConsumeTokenStatus_name = map[int32]string{
+		0: "CONSUME_TOKEN_STATUS_UNSPECIFIED",
+		1: "CONSUME_TOKEN_STATUS_CONSUMED",
+		2: "CONSUME_TOKEN_STATUS_NOT_FOUND",
+		3: "CONSUME_TOKEN_STATUS_EXPIRED",
+		4: "CONSUME_TOKEN_STATUS_ALREADY_USED",
+	}
+	ConsumeTokenStatus_value = map[string]int32{
+		"CONSUME_TOKEN_STATUS_UNSPECIFIED":  0,
+		"CONSUME_TOKEN_STATUS_CONSUMED":     1,
+		"CONSUME_TOKEN_STATUS_NOT_FOUND":    2,
+		"CONSUME_TOKEN_STATUS_EXPIRED":      3,
+		"CONSUME_TOKEN_STATUS_ALREADY_USED": 4,
+	}
+)
+
+func (x ConsumeTokenStatus) Enum() *ConsumeTokenStatus {
+	p := new(ConsumeTokenStatus)
+	*p = x
+	return p
+}
+
+func (x ConsumeTokenStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConsumeTokenStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[1].Descriptor()
+}
+
+func (ConsumeTokenStatus) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[1]
+}
+
+func (x ConsumeTokenStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConsumeTokenStatus.Descriptor instead.
+func (ConsumeTokenStatus) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{1}
+}
+
+// Роли доступа от самой слабой к самой сильной
+type PermissionRole int32
+
+const (
+	PermissionRole_PERMISSION_ROLE_UNSPECIFIED PermissionRole = 0
+	PermissionRole_PERMISSION_ROLE_READER      PermissionRole = 1
+	PermissionRole_PERMISSION_ROLE_COMMENTER   PermissionRole = 2
+	PermissionRole_PERMISSION_ROLE_WRITER      PermissionRole = 3
+	PermissionRole_PERMISSION_ROLE_FILE_OWNER  PermissionRole = 4
+	PermissionRole_PERMISSION_ROLE_ORGANIZER   PermissionRole = 5
+	PermissionRole_PERMISSION_ROLE_OWNER       PermissionRole = 6
+)
+
+// Enum value maps for PermissionRole.
+var (
+	PermissionRole_name = map[int32]string{
+		0: "PERMISSION_ROLE_UNSPECIFIED",
+		1: "PERMISSION_ROLE_READER",
+		2: "PERMISSION_ROLE_COMMENTER",
+		3: "PERMISSION_ROLE_WRITER",
+		4: "PERMISSION_ROLE_FILE_OWNER",
+		5: "PERMISSION_ROLE_ORGANIZER",
+		6: "PERMISSION_ROLE_OWNER",
+	}
+	PermissionRole_value = map[string]int32{
+		"PERMISSION_ROLE_UNSPECIFIED": 0,
+		"PERMISSION_ROLE_READER":      1,
+		"PERMISSION_ROLE_COMMENTER":   2,
+		"PERMISSION_ROLE_WRITER":      3,
+		"PERMISSION_ROLE_FILE_OWNER":  4,
+		"PERMISSION_ROLE_ORGANIZER":   5,
+		"PERMISSION_ROLE_OWNER":       6,
+	}
+)
+
+func (x PermissionRole) Enum() *PermissionRole {
+	p := new(PermissionRole)
+	*p = x
+	return p
+}
+
+func (x PermissionRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PermissionRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[2].Descriptor()
+}
+
+func (PermissionRole) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[2]
+}
+
+func (x PermissionRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PermissionRole.Descriptor instead.
+func (PermissionRole) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{2}
+}
+
+type GranteeType int32
+
+const (
+	GranteeType_GRANTEE_TYPE_UNSPECIFIED GranteeType = 0
+	GranteeType_GRANTEE_TYPE_USER        GranteeType = 1
+	GranteeType_GRANTEE_TYPE_GROUP       GranteeType = 2
+	GranteeType_GRANTEE_TYPE_DOMAIN      GranteeType = 3
+	GranteeType_GRANTEE_TYPE_ANYONE      GranteeType = 4
+)
+
+// Enum value maps for GranteeType.
+var (
+	GranteeType_name = map[int32]string{
+		0: "GRANTEE_TYPE_UNSPECIFIED",
+		1: "GRANTEE_TYPE_USER",
+		2: "GRANTEE_TYPE_GROUP",
+		3: "GRANTEE_TYPE_DOMAIN",
+		4: "GRANTEE_TYPE_ANYONE",
+	}
+	GranteeType_value = map[string]int32{
+		"GRANTEE_TYPE_UNSPECIFIED": 0,
+		"GRANTEE_TYPE_USER":        1,
+		"GRANTEE_TYPE_GROUP":       2,
+		"GRANTEE_TYPE_DOMAIN":      3,
+		"GRANTEE_TYPE_ANYONE":      4,
+	}
+)
+
+func (x GranteeType) Enum() *GranteeType {
+	p := new(GranteeType)
+	*p = x
+	return p
+}
+
+func (x GranteeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GranteeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[3].Descriptor()
+}
+
+func (GranteeType) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[3]
+}
+
+func (x GranteeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GranteeType.Descriptor instead.
+func (GranteeType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{3}
+}
+
+type ShareLinkStatus int32
+
+const (
+	ShareLinkStatus_SHARE_LINK_STATUS_UNSPECIFIED       ShareLinkStatus = 0
+	ShareLinkStatus_SHARE_LINK_STATUS_VALID             ShareLinkStatus = 1
+	ShareLinkStatus_SHARE_LINK_STATUS_NOT_FOUND         ShareLinkStatus = 2
+	ShareLinkStatus_SHARE_LINK_STATUS_REVOKED           ShareLinkStatus = 3
+	ShareLinkStatus_SHARE_LINK_STATUS_EXPIRED           ShareLinkStatus = 4
+	ShareLinkStatus_SHARE_LINK_STATUS_USE_LIMIT_REACHED ShareLinkStatus = 5
+	ShareLinkStatus_SHARE_LINK_STATUS_PASSWORD_REQUIRED ShareLinkStatus = 6 // Проверьте пароль и повторите с password_verified
+	ShareLinkStatus_SHARE_LINK_STATUS_FILE_UNAVAILABLE  ShareLinkStatus = 7 // Файл в корзине
+)
+
+// Enum value maps for ShareLinkStatus.
+var (
+	ShareLinkStatus_name = map[int32]string{
+		0: "SHARE_LINK_STATUS_UNSPECIFIED",
+		1: "SHARE_LINK_STATUS_VALID",
+		2: "SHARE_LINK_STATUS_NOT_FOUND",
+		3: "SHARE_LINK_STATUS_REVOKED",
+		4: "SHARE_LINK_STATUS_EXPIRED",
+		5: "SHARE_LINK_STATUS_USE_LIMIT_REACHED",
+		6: "SHARE_LINK_STATUS_PASSWORD_REQUIRED",
+		7: "SHARE_LINK_STATUS_FILE_UNAVAILABLE",
+	}
+	ShareLinkStatus_value = map[string]int32{
+		"SHARE_LINK_STATUS_UNSPECIFIED":       0,
+		"SHARE_LINK_STATUS_VALID":             1,
+		"SHARE_LINK_STATUS_NOT_FOUND":         2,
+		"SHARE_LINK_STATUS_REVOKED":           3,
+		"SHARE_LINK_STATUS_EXPIRED":           4,
+		"SHARE_LINK_STATUS_USE_LIMIT_REACHED": 5,
+		"SHARE_LINK_STATUS_PASSWORD_REQUIRED": 6,
+		"SHARE_LINK_STATUS_FILE_UNAVAILABLE":  7,
+	}
+)
+
+func (x ShareLinkStatus) Enum() *ShareLinkStatus {
+	p := new(ShareLinkStatus)
+	*p = x
+	return p
+}
+
+func (x ShareLinkStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShareLinkStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[4].Descriptor()
+}
+
+func (ShareLinkStatus) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[4]
+}
+
+func (x ShareLinkStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShareLinkStatus.Descriptor instead.
+func (ShareLinkStatus) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{4}
+}
+
+type RotateSessionStatus int32
+
+const (
+	RotateSessionStatus_ROTATE_SESSION_STATUS_UNSPECIFIED    RotateSessionStatus = 0
+	RotateSessionStatus_ROTATE_SESSION_STATUS_ROTATED        RotateSessionStatus = 1
+	RotateSessionStatus_ROTATE_SESSION_STATUS_NOT_FOUND      RotateSessionStatus = 2
+	RotateSessionStatus_ROTATE_SESSION_STATUS_EXPIRED        RotateSessionStatus = 3
+	RotateSessionStatus_ROTATE_SESSION_STATUS_REVOKED        RotateSessionStatus = 4
+	RotateSessionStatus_ROTATE_SESSION_STATUS_REUSE_DETECTED RotateSessionStatus = 5 // Токен уже обменян: все сессии семьи отозваны
+)
+
+// Enum value maps for RotateSessionStatus.
+var (
+	RotateSessionStatus_name = map[int32]string{
+		0: "ROTATE_SESSION_STATUS_UNSPECIFIED",
+		1: "ROTATE_SESSION_STATUS_ROTATED",
+		2: "ROTATE_SESSION_STATUS_NOT_FOUND",
+		3: "ROTATE_SESSION_STATUS_EXPIRED",
+		4: "ROTATE_SESSION_STATUS_REVOKED",
+		5: "ROTATE_SESSION_STATUS_REUSE_DETECTED",
+	}
+	RotateSessionStatus_value = map[string]int32{
+		"ROTATE_SESSION_STATUS_UNSPECIFIED":    0,
+		"ROTATE_SESSION_STATUS_ROTATED":        1,
+		"ROTATE_SESSION_STATUS_NOT_FOUND":      2,
+		"ROTATE_SESSION_STATUS_EXPIRED":        3,
+		"ROTATE_SESSION_STATUS_REVOKED":        4,
+		"ROTATE_SESSION_STATUS_REUSE_DETECTED": 5,
+	}
+)
+
+func (x RotateSessionStatus) Enum() *RotateSessionStatus {
+	p := new(RotateSessionStatus)
+	*p = x
+	return p
+}
+
+func (x RotateSessionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RotateSessionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[5].Descriptor()
+}
+
+func (RotateSessionStatus) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[5]
+}
+
+func (x RotateSessionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RotateSessionStatus.Descriptor instead.
+func (RotateSessionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{5}
+}
+
+type WebAuthnSignCountStatus int32
+
+const (
+	WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_UNSPECIFIED    WebAuthnSignCountStatus = 0
+	WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_UPDATED        WebAuthnSignCountStatus = 1
+	WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_NOT_FOUND      WebAuthnSignCountStatus = 2
+	WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_CLONE_DETECTED WebAuthnSignCountStatus = 3 // Счётчик не вырос: возможна копия ключа, вход отклонить
+)
+
+// Enum value maps for WebAuthnSignCountStatus.
+var (
+	WebAuthnSignCountStatus_name = map[int32]string{
+		0: "WEB_AUTHN_SIGN_COUNT_STATUS_UNSPECIFIED",
+		1: "WEB_AUTHN_SIGN_COUNT_STATUS_UPDATED",
+		2: "WEB_AUTHN_SIGN_COUNT_STATUS_NOT_FOUND",
+		3: "WEB_AUTHN_SIGN_COUNT_STATUS_CLONE_DETECTED",
+	}
+	WebAuthnSignCountStatus_value = map[string]int32{
+		"WEB_AUTHN_SIGN_COUNT_STATUS_UNSPECIFIED":    0,
+		"WEB_AUTHN_SIGN_COUNT_STATUS_UPDATED":        1,
+		"WEB_AUTHN_SIGN_COUNT_STATUS_NOT_FOUND":      2,
+		"WEB_AUTHN_SIGN_COUNT_STATUS_CLONE_DETECTED": 3,
+	}
+)
+
+func (x WebAuthnSignCountStatus) Enum() *WebAuthnSignCountStatus {
+	p := new(WebAuthnSignCountStatus)
+	*p = x
+	return p
+}
+
+func (x WebAuthnSignCountStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebAuthnSignCountStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[6].Descriptor()
+}
+
+func (WebAuthnSignCountStatus) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[6]
+}
+
+func (x WebAuthnSignCountStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebAuthnSignCountStatus.Descriptor instead.
+func (WebAuthnSignCountStatus) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{6}
+}
+
+type UserSortField int32
+
+const (
+	UserSortField_USER_SORT_FIELD_UNSPECIFIED   UserSortField = 0 // = CREATED_AT
+	UserSortField_USER_SORT_FIELD_CREATED_AT    UserSortField = 1
+	UserSortField_USER_SORT_FIELD_LAST_LOGIN_AT UserSortField = 2 // Не входившие ни разу - в начале при возрастании
+	UserSortField_USER_SORT_FIELD_EMAIL         UserSortField = 3
+	UserSortField_USER_SORT_FIELD_USERNAME      UserSortField = 4
+	UserSortField_USER_SORT_FIELD_USED_SPACE    UserSortField = 5
+)
+
+// Enum value maps for UserSortField.
+var (
+	UserSortField_name = map[int32]string{
+		0: "USER_SORT_FIELD_UNSPECIFIED",
+		1: "USER_SORT_FIELD_CREATED_AT",
+		2: "USER_SORT_FIELD_LAST_LOGIN_AT",
+		3: "USER_SORT_FIELD_EMAIL",
+		4: "USER_SORT_FIELD_USERNAME",
+		5: "USER_SORT_FIELD_USED_SPACE",
+	}
+	UserSortField_value = map[string]int32{
+		"USER_SORT_FIELD_UNSPECIFIED":   0,
+		"USER_SORT_FIELD_CREATED_AT":    1,
+		"USER_SORT_FIELD_LAST_LOGIN_AT": 2,
+		"USER_SORT_FIELD_EMAIL":         3,
+		"USER_SORT_FIELD_USERNAME":      4,
+		"USER_SORT_FIELD_USED_SPACE":    5,
+	}
+)
+
+func (x UserSortField) Enum() *UserSortField {
+	p := new(UserSortField)
+	*p = x
+	return p
+}
+
+func (x UserSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[7].Descriptor()
+}
+
+func (UserSortField) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[7]
+}
+
+func (x UserSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserSortField.Descriptor instead.
+func (UserSortField) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{7}
+}
+
+// Журнал событий безопасности. Кто и откуда выполнил действие, берётся из метаданных
+// запроса: x-actor-id, x-forwarded-for (или x-real-ip), x-user-agent.
+type SecurityEventType int32
+
+const (
+	SecurityEventType_SECURITY_EVENT_TYPE_UNSPECIFIED         SecurityEventType = 0
+	SecurityEventType_SECURITY_EVENT_TYPE_PASSWORD_CHANGED    SecurityEventType = 1
+	SecurityEventType_SECURITY_EVENT_TYPE_EMAIL_VERIFIED      SecurityEventType = 2
+	SecurityEventType_SECURITY_EVENT_TYPE_EMAIL_UNVERIFIED    SecurityEventType = 3
+	SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_LOCKED      SecurityEventType = 4
+	SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_UNLOCKED    SecurityEventType = 5
+	SecurityEventType_SECURITY_EVENT_TYPE_ROLE_CHANGED        SecurityEventType = 6
+	SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_ACTIVATED   SecurityEventType = 7
+	SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_DEACTIVATED SecurityEventType = 8
+	SecurityEventType_SECURITY_EVENT_TYPE_LOGIN_SUCCEEDED     SecurityEventType = 9
+	SecurityEventType_SECURITY_EVENT_TYPE_LOGIN_FAILED        SecurityEventType = 10
+	SecurityEventType_SECURITY_EVENT_TYPE_LOGIN_BLOCKED       SecurityEventType = 11 // Вход отклонён: аккаунт заблокирован
+	SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_ERASED      SecurityEventType = 12 // Данные стёрты, прошлые события обезличены
+)
+
+// Enum value maps for SecurityEventType.
+var (
+	SecurityEventType_name = map[int32]string{
+		0:  "SECURITY_EVENT_TYPE_UNSPECIFIED",
+		1:  "SECURITY_EVENT_TYPE_PASSWORD_CHANGED",
+		2:  "SECURITY_EVENT_TYPE_EMAIL_VERIFIED",
+		3:  "SECURITY_EVENT_TYPE_EMAIL_UNVERIFIED",
+		4:  "SECURITY_EVENT_TYPE_ACCOUNT_LOCKED",
+		5:  "SECURITY_EVENT_TYPE_ACCOUNT_UNLOCKED",
+		6:  "SECURITY_EVENT_TYPE_ROLE_CHANGED",
+		7:  "SECURITY_EVENT_TYPE_ACCOUNT_ACTIVATED",
+		8:  "SECURITY_EVENT_TYPE_ACCOUNT_DEACTIVATED",
+		9:  "SECURITY_EVENT_TYPE_LOGIN_SUCCEEDED",
+		10: "SECURITY_EVENT_TYPE_LOGIN_FAILED",
+		11: "SECURITY_EVENT_TYPE_LOGIN_BLOCKED",
+		12: "SECURITY_EVENT_TYPE_ACCOUNT_ERASED",
+	}
+	SecurityEventType_value = map[string]int32{
+		"SECURITY_EVENT_TYPE_UNSPECIFIED":         0,
+		"SECURITY_EVENT_TYPE_PASSWORD_CHANGED":    1,
+		"SECURITY_EVENT_TYPE_EMAIL_VERIFIED":      2,
+		"SECURITY_EVENT_TYPE_EMAIL_UNVERIFIED":    3,
+		"SECURITY_EVENT_TYPE_ACCOUNT_LOCKED":      4,
+		"SECURITY_EVENT_TYPE_ACCOUNT_UNLOCKED":    5,
+		"SECURITY_EVENT_TYPE_ROLE_CHANGED":        6,
+		"SECURITY_EVENT_TYPE_ACCOUNT_ACTIVATED":   7,
+		"SECURITY_EVENT_TYPE_ACCOUNT_DEACTIVATED": 8,
+		"SECURITY_EVENT_TYPE_LOGIN_SUCCEEDED":     9,
+		"SECURITY_EVENT_TYPE_LOGIN_FAILED":        10,
+		"SECURITY_EVENT_TYPE_LOGIN_BLOCKED":       11,
+		"SECURITY_EVENT_TYPE_ACCOUNT_ERASED":      12,
+	}
+)
+
+func (x SecurityEventType) Enum() *SecurityEventType {
+	p := new(SecurityEventType)
+	*p = x
+	return p
+}
+
+func (x SecurityEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SecurityEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_transport_grpc_protos_db_manager_proto_enumTypes[8].Descriptor()
+}
+
+func (SecurityEventType) Type() protoreflect.EnumType {
+	return &file_internal_transport_grpc_protos_db_manager_proto_enumTypes[8]
+}
+
+func (x SecurityEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SecurityEventType.Descriptor instead.
+func (SecurityEventType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{8}
+}
+
 // Message definitions for Users
 type User struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
@@ -186,7 +726,7 @@ type UserExtendedInfo struct {
 	DaysSinceLastLogin        int32   `protobuf:"varint,7,opt,name=days_since_last_login,json=daysSinceLastLogin,proto3" json:"days_since_last_login,omitempty"`                   // Дней с последнего входа
 	DaysSinceCreated          int32   `protobuf:"varint,8,opt,name=days_since_created,json=daysSinceCreated,proto3" json:"days_since_created,omitempty"`                           // Дней с создания аккаунта
 	AccountStatus             string  `protobuf:"bytes,9,opt,name=account_status,json=accountStatus,proto3" json:"account_status,omitempty"`                                       // Статус аккаунта (active, locked, inactive, etc.)
-	SecurityStatus            string  `protobuf:"bytes,10,opt,name=security_status,json=securityStatus,proto3" json:"security_status,omitempty"`                                   // Статус безопасности (secure, needs_verification, etc.)
+	SecurityStatus            string  `protobuf:"bytes,10,opt,name=security_status,json=securityStatus,proto3" json:"security_status,omitempty"`                                   // Статус безопасности (secure, no_2fa, needs_verification, etc.)
 	// Дополнительная аналитика
 	Warnings        []string          `protobuf:"bytes,11,rep,name=warnings,proto3" json:"warnings,omitempty"`                                                                           // Предупреждения (например, "Почти закончилось место")
 	Recommendations []string          `protobuf:"bytes,12,rep,name=recommendations,proto3" json:"recommendations,omitempty"`                                                             // Рекомендации (например, "Включите 2FA")
@@ -500,6 +1040,58 @@ func (x *UpdatePasswordRequest) GetPasswordHash() string {
 	return ""
 }
 
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // Обязательна; password_hash меняется только через UpdatePassword
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type UpdateUsernameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -510,7 +1102,7 @@ type UpdateUsernameRequest struct {
 
 func (x *UpdateUsernameRequest) Reset() {
 	*x = UpdateUsernameRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[6]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -522,7 +1114,7 @@ func (x *UpdateUsernameRequest) String() string {
 func (*UpdateUsernameRequest) ProtoMessage() {}
 
 func (x *UpdateUsernameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[6]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,7 +1127,7 @@ func (x *UpdateUsernameRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUsernameRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUsernameRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{6}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateUsernameRequest) GetId() string {
@@ -562,7 +1154,7 @@ type UpdateEmailVerificationRequest struct {
 
 func (x *UpdateEmailVerificationRequest) Reset() {
 	*x = UpdateEmailVerificationRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[7]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -574,7 +1166,7 @@ func (x *UpdateEmailVerificationRequest) String() string {
 func (*UpdateEmailVerificationRequest) ProtoMessage() {}
 
 func (x *UpdateEmailVerificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[7]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -587,7 +1179,7 @@ func (x *UpdateEmailVerificationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateEmailVerificationRequest.ProtoReflect.Descriptor instead.
 func (*UpdateEmailVerificationRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{7}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateEmailVerificationRequest) GetId() string {
@@ -614,7 +1206,7 @@ type UpdateFailedLoginAttemptsRequest struct {
 
 func (x *UpdateFailedLoginAttemptsRequest) Reset() {
 	*x = UpdateFailedLoginAttemptsRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[8]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -626,7 +1218,7 @@ func (x *UpdateFailedLoginAttemptsRequest) String() string {
 func (*UpdateFailedLoginAttemptsRequest) ProtoMessage() {}
 
 func (x *UpdateFailedLoginAttemptsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[8]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -639,7 +1231,7 @@ func (x *UpdateFailedLoginAttemptsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateFailedLoginAttemptsRequest.ProtoReflect.Descriptor instead.
 func (*UpdateFailedLoginAttemptsRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{8}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateFailedLoginAttemptsRequest) GetId() string {
@@ -659,14 +1251,14 @@ func (x *UpdateFailedLoginAttemptsRequest) GetAttempts() int32 {
 type UpdateLockedUntilRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	LockedUntil   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"`
+	LockedUntil   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"` // Не задано - снять блокировку
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateLockedUntilRequest) Reset() {
 	*x = UpdateLockedUntilRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[9]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -678,7 +1270,7 @@ func (x *UpdateLockedUntilRequest) String() string {
 func (*UpdateLockedUntilRequest) ProtoMessage() {}
 
 func (x *UpdateLockedUntilRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[9]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -691,7 +1283,7 @@ func (x *UpdateLockedUntilRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateLockedUntilRequest.ProtoReflect.Descriptor instead.
 func (*UpdateLockedUntilRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{9}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateLockedUntilRequest) GetId() string {
@@ -708,17 +1300,326 @@ func (x *UpdateLockedUntilRequest) GetLockedUntil() *timestamppb.Timestamp {
 	return nil
 }
 
-type UpdateStorageUsageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UsedSpace     int64                  `protobuf:"varint,2,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
+// Состояние после RecordFailedLogin / RecordSuccessfulLogin
+type LoginState struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	FailedLoginAttempts int32                  `protobuf:"varint,1,opt,name=failed_login_attempts,json=failedLoginAttempts,proto3" json:"failed_login_attempts,omitempty"`
+	Locked              bool                   `protobuf:"varint,2,opt,name=locked,proto3" json:"locked,omitempty"` // Вход запрещён до locked_until
+	LockedUntil         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *LoginState) Reset() {
+	*x = LoginState{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginState) ProtoMessage() {}
+
+func (x *LoginState) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginState.ProtoReflect.Descriptor instead.
+func (*LoginState) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LoginState) GetFailedLoginAttempts() int32 {
+	if x != nil {
+		return x.FailedLoginAttempts
+	}
+	return 0
+}
+
+func (x *LoginState) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *LoginState) GetLockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return nil
+}
+
+type IssueTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Purpose       UserTokenPurpose       `protobuf:"varint,2,opt,name=purpose,proto3,enum=dbservice.UserTokenPurpose" json:"purpose,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	NewEmail      string                 `protobuf:"bytes,4,opt,name=new_email,json=newEmail,proto3" json:"new_email,omitempty"` // Обязателен для USER_TOKEN_PURPOSE_EMAIL_CHANGE
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTokenRequest) Reset() {
+	*x = IssueTokenRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTokenRequest) ProtoMessage() {}
+
+func (x *IssueTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueTokenRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IssueTokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IssueTokenRequest) GetPurpose() UserTokenPurpose {
+	if x != nil {
+		return x.Purpose
+	}
+	return UserTokenPurpose_USER_TOKEN_PURPOSE_UNSPECIFIED
+}
+
+func (x *IssueTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *IssueTokenRequest) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+type IssueTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // Возвращается один раз, в базе хранится только хеш
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTokenResponse) Reset() {
+	*x = IssueTokenResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTokenResponse) ProtoMessage() {}
+
+func (x *IssueTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueTokenResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *IssueTokenResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IssueTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *IssueTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ConsumeTokenRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Token           string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Purpose         UserTokenPurpose       `protobuf:"varint,2,opt,name=purpose,proto3,enum=dbservice.UserTokenPurpose" json:"purpose,omitempty"`
+	NewPasswordHash string                 `protobuf:"bytes,3,opt,name=new_password_hash,json=newPasswordHash,proto3" json:"new_password_hash,omitempty"` // Обязателен для USER_TOKEN_PURPOSE_PASSWORD_RESET
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConsumeTokenRequest) Reset() {
+	*x = ConsumeTokenRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeTokenRequest) ProtoMessage() {}
+
+func (x *ConsumeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeTokenRequest.ProtoReflect.Descriptor instead.
+func (*ConsumeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConsumeTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConsumeTokenRequest) GetPurpose() UserTokenPurpose {
+	if x != nil {
+		return x.Purpose
+	}
+	return UserTokenPurpose_USER_TOKEN_PURPOSE_UNSPECIFIED
+}
+
+func (x *ConsumeTokenRequest) GetNewPasswordHash() string {
+	if x != nil {
+		return x.NewPasswordHash
+	}
+	return ""
+}
+
+type ConsumeTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        ConsumeTokenStatus     `protobuf:"varint,1,opt,name=status,proto3,enum=dbservice.ConsumeTokenStatus" json:"status,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	NewEmail      string                 `protobuf:"bytes,3,opt,name=new_email,json=newEmail,proto3" json:"new_email,omitempty"` // Для USER_TOKEN_PURPOSE_EMAIL_CHANGE
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumeTokenResponse) Reset() {
+	*x = ConsumeTokenResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeTokenResponse) ProtoMessage() {}
+
+func (x *ConsumeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeTokenResponse.ProtoReflect.Descriptor instead.
+func (*ConsumeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ConsumeTokenResponse) GetStatus() ConsumeTokenStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ConsumeTokenStatus_CONSUME_TOKEN_STATUS_UNSPECIFIED
+}
+
+func (x *ConsumeTokenResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConsumeTokenResponse) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+type UpdateStorageUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UsedSpace     int64                  `protobuf:"varint,2,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateStorageUsageRequest) Reset() {
 	*x = UpdateStorageUsageRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[10]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -730,7 +1631,7 @@ func (x *UpdateStorageUsageRequest) String() string {
 func (*UpdateStorageUsageRequest) ProtoMessage() {}
 
 func (x *UpdateStorageUsageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[10]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -743,7 +1644,7 @@ func (x *UpdateStorageUsageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateStorageUsageRequest.ProtoReflect.Descriptor instead.
 func (*UpdateStorageUsageRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{10}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UpdateStorageUsageRequest) GetId() string {
@@ -769,7 +1670,7 @@ type ExistsResponse struct {
 
 func (x *ExistsResponse) Reset() {
 	*x = ExistsResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[11]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -781,7 +1682,7 @@ func (x *ExistsResponse) String() string {
 func (*ExistsResponse) ProtoMessage() {}
 
 func (x *ExistsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[11]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,7 +1695,7 @@ func (x *ExistsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExistsResponse.ProtoReflect.Descriptor instead.
 func (*ExistsResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{11}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ExistsResponse) GetExists() bool {
@@ -838,7 +1739,7 @@ type File struct {
 
 func (x *File) Reset() {
 	*x = File{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[12]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -850,7 +1751,7 @@ func (x *File) String() string {
 func (*File) ProtoMessage() {}
 
 func (x *File) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[12]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -863,7 +1764,7 @@ func (x *File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use File.ProtoReflect.Descriptor instead.
 func (*File) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{12}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *File) GetId() string {
@@ -1050,7 +1951,7 @@ type FileID struct {
 
 func (x *FileID) Reset() {
 	*x = FileID{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[13]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1062,7 +1963,7 @@ func (x *FileID) String() string {
 func (*FileID) ProtoMessage() {}
 
 func (x *FileID) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[13]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1075,7 +1976,7 @@ func (x *FileID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileID.ProtoReflect.Descriptor instead.
 func (*FileID) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{13}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *FileID) GetId() string {
@@ -1095,7 +1996,7 @@ type GetFileByPathRequest struct {
 
 func (x *GetFileByPathRequest) Reset() {
 	*x = GetFileByPathRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[14]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1107,7 +2008,7 @@ func (x *GetFileByPathRequest) String() string {
 func (*GetFileByPathRequest) ProtoMessage() {}
 
 func (x *GetFileByPathRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[14]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1120,7 +2021,7 @@ func (x *GetFileByPathRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFileByPathRequest.ProtoReflect.Descriptor instead.
 func (*GetFileByPathRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{14}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetFileByPathRequest) GetOwnerId() string {
@@ -1153,7 +2054,7 @@ type ListFilesRequest struct {
 
 func (x *ListFilesRequest) Reset() {
 	*x = ListFilesRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[15]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1165,7 +2066,7 @@ func (x *ListFilesRequest) String() string {
 func (*ListFilesRequest) ProtoMessage() {}
 
 func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[15]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1178,7 +2079,7 @@ func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFilesRequest.ProtoReflect.Descriptor instead.
 func (*ListFilesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{15}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ListFilesRequest) GetParentId() string {
@@ -1249,7 +2150,7 @@ type ListFilesResponse struct {
 
 func (x *ListFilesResponse) Reset() {
 	*x = ListFilesResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[16]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1261,7 +2162,7 @@ func (x *ListFilesResponse) String() string {
 func (*ListFilesResponse) ProtoMessage() {}
 
 func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[16]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1274,7 +2175,7 @@ func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
 func (*ListFilesResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{16}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ListFilesResponse) GetFiles() []*File {
@@ -1315,7 +2216,7 @@ type ListFilesByParentRequest struct {
 
 func (x *ListFilesByParentRequest) Reset() {
 	*x = ListFilesByParentRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[17]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1327,7 +2228,7 @@ func (x *ListFilesByParentRequest) String() string {
 func (*ListFilesByParentRequest) ProtoMessage() {}
 
 func (x *ListFilesByParentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[17]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1340,7 +2241,7 @@ func (x *ListFilesByParentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFilesByParentRequest.ProtoReflect.Descriptor instead.
 func (*ListFilesByParentRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{17}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListFilesByParentRequest) GetOwnerId() string {
@@ -1366,7 +2267,7 @@ type ListStarredFilesRequest struct {
 
 func (x *ListStarredFilesRequest) Reset() {
 	*x = ListStarredFilesRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[18]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1378,7 +2279,7 @@ func (x *ListStarredFilesRequest) String() string {
 func (*ListStarredFilesRequest) ProtoMessage() {}
 
 func (x *ListStarredFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[18]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1391,7 +2292,7 @@ func (x *ListStarredFilesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListStarredFilesRequest.ProtoReflect.Descriptor instead.
 func (*ListStarredFilesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{18}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ListStarredFilesRequest) GetOwnerId() string {
@@ -1410,7 +2311,7 @@ type ListTrashedFilesRequest struct {
 
 func (x *ListTrashedFilesRequest) Reset() {
 	*x = ListTrashedFilesRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[19]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1422,7 +2323,7 @@ func (x *ListTrashedFilesRequest) String() string {
 func (*ListTrashedFilesRequest) ProtoMessage() {}
 
 func (x *ListTrashedFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[19]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1435,7 +2336,7 @@ func (x *ListTrashedFilesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTrashedFilesRequest.ProtoReflect.Descriptor instead.
 func (*ListTrashedFilesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{19}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ListTrashedFilesRequest) GetOwnerId() string {
@@ -1455,7 +2356,7 @@ type SearchFilesRequest struct {
 
 func (x *SearchFilesRequest) Reset() {
 	*x = SearchFilesRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[20]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1467,7 +2368,7 @@ func (x *SearchFilesRequest) String() string {
 func (*SearchFilesRequest) ProtoMessage() {}
 
 func (x *SearchFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[20]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1480,7 +2381,7 @@ func (x *SearchFilesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchFilesRequest.ProtoReflect.Descriptor instead.
 func (*SearchFilesRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{20}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *SearchFilesRequest) GetOwnerId() string {
@@ -1506,7 +2407,7 @@ type FileSizeResponse struct {
 
 func (x *FileSizeResponse) Reset() {
 	*x = FileSizeResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[21]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1518,7 +2419,7 @@ func (x *FileSizeResponse) String() string {
 func (*FileSizeResponse) ProtoMessage() {}
 
 func (x *FileSizeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[21]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1531,7 +2432,7 @@ func (x *FileSizeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileSizeResponse.ProtoReflect.Descriptor instead.
 func (*FileSizeResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{21}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *FileSizeResponse) GetSize() int64 {
@@ -1541,6 +2442,58 @@ func (x *FileSizeResponse) GetSize() int64 {
 	return 0
 }
 
+type UpdateFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // Обязательна; owner_id меняется только через TransferOwnership
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFileRequest) Reset() {
+	*x = UpdateFileRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFileRequest) ProtoMessage() {}
+
+func (x *UpdateFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFileRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateFileRequest) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *UpdateFileRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type UpdateFileSizeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1551,7 +2504,7 @@ type UpdateFileSizeRequest struct {
 
 func (x *UpdateFileSizeRequest) Reset() {
 	*x = UpdateFileSizeRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[22]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1563,7 +2516,7 @@ func (x *UpdateFileSizeRequest) String() string {
 func (*UpdateFileSizeRequest) ProtoMessage() {}
 
 func (x *UpdateFileSizeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[22]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1576,7 +2529,7 @@ func (x *UpdateFileSizeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateFileSizeRequest.ProtoReflect.Descriptor instead.
 func (*UpdateFileSizeRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{22}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *UpdateFileSizeRequest) GetId() string {
@@ -1603,7 +2556,7 @@ type GetFileTreeRequest struct {
 
 func (x *GetFileTreeRequest) Reset() {
 	*x = GetFileTreeRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[23]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1615,7 +2568,7 @@ func (x *GetFileTreeRequest) String() string {
 func (*GetFileTreeRequest) ProtoMessage() {}
 
 func (x *GetFileTreeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[23]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1628,7 +2581,7 @@ func (x *GetFileTreeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFileTreeRequest.ProtoReflect.Descriptor instead.
 func (*GetFileTreeRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{23}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *GetFileTreeRequest) GetOwnerId() string {
@@ -1647,23 +2600,26 @@ func (x *GetFileTreeRequest) GetRootId() string {
 
 // Message definitions for File Revisions
 type FileRevision struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	RevisionId    int64                  `protobuf:"varint,3,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
-	Md5Checksum   string                 `protobuf:"bytes,4,opt,name=md5_checksum,json=md5Checksum,proto3" json:"md5_checksum,omitempty"`
-	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	StoragePath   string                 `protobuf:"bytes,7,opt,name=storage_path,json=storagePath,proto3" json:"storage_path,omitempty"`
-	MimeType      string                 `protobuf:"bytes,8,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
-	UserId        string                 `protobuf:"bytes,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileId         string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	RevisionId     int64                  `protobuf:"varint,3,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
+	Md5Checksum    string                 `protobuf:"bytes,4,opt,name=md5_checksum,json=md5Checksum,proto3" json:"md5_checksum,omitempty"`
+	Size           int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StoragePath    string                 `protobuf:"bytes,7,opt,name=storage_path,json=storagePath,proto3" json:"storage_path,omitempty"`
+	MimeType       string                 `protobuf:"bytes,8,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	UserId         string                 `protobuf:"bytes,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Label          string                 `protobuf:"bytes,10,opt,name=label,proto3" json:"label,omitempty"`             // Метка ревизии (например, "final draft")
+	Description    string                 `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"` // Описание метки
+	Sha256Checksum string                 `protobuf:"bytes,12,opt,name=sha256_checksum,json=sha256Checksum,proto3" json:"sha256_checksum,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *FileRevision) Reset() {
 	*x = FileRevision{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[24]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1675,7 +2631,7 @@ func (x *FileRevision) String() string {
 func (*FileRevision) ProtoMessage() {}
 
 func (x *FileRevision) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[24]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1688,7 +2644,7 @@ func (x *FileRevision) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileRevision.ProtoReflect.Descriptor instead.
 func (*FileRevision) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{24}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *FileRevision) GetId() string {
@@ -1754,6 +2710,27 @@ func (x *FileRevision) GetUserId() string {
 	return ""
 }
 
+func (x *FileRevision) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *FileRevision) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FileRevision) GetSha256Checksum() string {
+	if x != nil {
+		return x.Sha256Checksum
+	}
+	return ""
+}
+
 type RevisionID struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1763,7 +2740,7 @@ type RevisionID struct {
 
 func (x *RevisionID) Reset() {
 	*x = RevisionID{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[25]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1775,7 +2752,7 @@ func (x *RevisionID) String() string {
 func (*RevisionID) ProtoMessage() {}
 
 func (x *RevisionID) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[25]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1788,7 +2765,7 @@ func (x *RevisionID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevisionID.ProtoReflect.Descriptor instead.
 func (*RevisionID) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{25}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *RevisionID) GetId() string {
@@ -1807,7 +2784,7 @@ type ListRevisionsResponse struct {
 
 func (x *ListRevisionsResponse) Reset() {
 	*x = ListRevisionsResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[26]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1819,7 +2796,7 @@ func (x *ListRevisionsResponse) String() string {
 func (*ListRevisionsResponse) ProtoMessage() {}
 
 func (x *ListRevisionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[26]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1832,7 +2809,7 @@ func (x *ListRevisionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRevisionsResponse.ProtoReflect.Descriptor instead.
 func (*ListRevisionsResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{26}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ListRevisionsResponse) GetRevisions() []*FileRevision {
@@ -1852,7 +2829,7 @@ type GetRevisionRequest struct {
 
 func (x *GetRevisionRequest) Reset() {
 	*x = GetRevisionRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[27]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1864,7 +2841,7 @@ func (x *GetRevisionRequest) String() string {
 func (*GetRevisionRequest) ProtoMessage() {}
 
 func (x *GetRevisionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[27]
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1877,7 +2854,7 @@ func (x *GetRevisionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRevisionRequest.ProtoReflect.Descriptor instead.
 func (*GetRevisionRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{27}
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetRevisionRequest) GetFileId() string {
@@ -1894,35 +2871,31 @@ func (x *GetRevisionRequest) GetRevisionId() int64 {
 	return 0
 }
 
-// Message definitions for File Permissions
-type FilePermission struct {
+type SetRevisionLabelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	GranteeId     string                 `protobuf:"bytes,3,opt,name=grantee_id,json=granteeId,proto3" json:"grantee_id,omitempty"`
-	GranteeType   string                 `protobuf:"bytes,4,opt,name=grantee_type,json=granteeType,proto3" json:"grantee_type,omitempty"`
-	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
-	AllowShare    bool                   `protobuf:"varint,6,opt,name=allow_share,json=allowShare,proto3" json:"allow_share,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	RevisionId    int64                  `protobuf:"varint,2,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FilePermission) Reset() {
-	*x = FilePermission{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[28]
+func (x *SetRevisionLabelRequest) Reset() {
+	*x = SetRevisionLabelRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FilePermission) String() string {
+func (x *SetRevisionLabelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FilePermission) ProtoMessage() {}
+func (*SetRevisionLabelRequest) ProtoMessage() {}
 
-func (x *FilePermission) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[28]
+func (x *SetRevisionLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1933,82 +2906,62 @@ func (x *FilePermission) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FilePermission.ProtoReflect.Descriptor instead.
-func (*FilePermission) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{28}
-}
-
-func (x *FilePermission) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use SetRevisionLabelRequest.ProtoReflect.Descriptor instead.
+func (*SetRevisionLabelRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *FilePermission) GetFileId() string {
+func (x *SetRevisionLabelRequest) GetFileId() string {
 	if x != nil {
 		return x.FileId
 	}
 	return ""
 }
 
-func (x *FilePermission) GetGranteeId() string {
+func (x *SetRevisionLabelRequest) GetRevisionId() int64 {
 	if x != nil {
-		return x.GranteeId
+		return x.RevisionId
 	}
-	return ""
+	return 0
 }
 
-func (x *FilePermission) GetGranteeType() string {
+func (x *SetRevisionLabelRequest) GetLabel() string {
 	if x != nil {
-		return x.GranteeType
+		return x.Label
 	}
 	return ""
 }
 
-func (x *FilePermission) GetRole() string {
+func (x *SetRevisionLabelRequest) GetDescription() string {
 	if x != nil {
-		return x.Role
+		return x.Description
 	}
 	return ""
 }
 
-func (x *FilePermission) GetAllowShare() bool {
-	if x != nil {
-		return x.AllowShare
-	}
-	return false
-}
-
-func (x *FilePermission) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-type PermissionID struct {
+type GetRevisionByLabelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PermissionID) Reset() {
-	*x = PermissionID{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[29]
+func (x *GetRevisionByLabelRequest) Reset() {
+	*x = GetRevisionByLabelRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PermissionID) String() string {
+func (x *GetRevisionByLabelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PermissionID) ProtoMessage() {}
+func (*GetRevisionByLabelRequest) ProtoMessage() {}
 
-func (x *PermissionID) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[29]
+func (x *GetRevisionByLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2019,40 +2972,49 @@ func (x *PermissionID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PermissionID.ProtoReflect.Descriptor instead.
-func (*PermissionID) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use GetRevisionByLabelRequest.ProtoReflect.Descriptor instead.
+func (*GetRevisionByLabelRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *PermissionID) GetId() string {
+func (x *GetRevisionByLabelRequest) GetFileId() string {
 	if x != nil {
-		return x.Id
+		return x.FileId
 	}
 	return ""
 }
 
-type ListPermissionsResponse struct {
+func (x *GetRevisionByLabelRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// Удаляет старые ревизии без метки, оставляя keep_latest последних
+type PruneRevisionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Permissions   []*FilePermission      `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	KeepLatest    int32                  `protobuf:"varint,2,opt,name=keep_latest,json=keepLatest,proto3" json:"keep_latest,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPermissionsResponse) Reset() {
-	*x = ListPermissionsResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[30]
+func (x *PruneRevisionsRequest) Reset() {
+	*x = PruneRevisionsRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPermissionsResponse) String() string {
+func (x *PruneRevisionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPermissionsResponse) ProtoMessage() {}
+func (*PruneRevisionsRequest) ProtoMessage() {}
 
-func (x *ListPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[30]
+func (x *PruneRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2063,42 +3025,57 @@ func (x *ListPermissionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPermissionsResponse.ProtoReflect.Descriptor instead.
-func (*ListPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use PruneRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*PruneRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ListPermissionsResponse) GetPermissions() []*FilePermission {
+func (x *PruneRevisionsRequest) GetFileId() string {
 	if x != nil {
-		return x.Permissions
+		return x.FileId
 	}
-	return nil
+	return ""
 }
 
-type CheckPermissionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	RequiredRole  string                 `protobuf:"bytes,3,opt,name=required_role,json=requiredRole,proto3" json:"required_role,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PruneRevisionsRequest) GetKeepLatest() int32 {
+	if x != nil {
+		return x.KeepLatest
+	}
+	return 0
 }
 
-func (x *CheckPermissionRequest) Reset() {
-	*x = CheckPermissionRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[31]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+type FilePermission struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileId         string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	GranteeId      string                 `protobuf:"bytes,3,opt,name=grantee_id,json=granteeId,proto3" json:"grantee_id,omitempty"`
+	AllowShare     bool                   `protobuf:"varint,6,opt,name=allow_share,json=allowShare,proto3" json:"allow_share,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Inherited      bool                   `protobuf:"varint,8,opt,name=inherited,proto3" json:"inherited,omitempty"`                             // Право унаследовано от папки-предка
+	InheritedFrom  string                 `protobuf:"bytes,9,opt,name=inherited_from,json=inheritedFrom,proto3" json:"inherited_from,omitempty"` // ID папки, на которую выдано право
+	Domain         string                 `protobuf:"bytes,10,opt,name=domain,proto3" json:"domain,omitempty"`                                   // Домен email для grantee_type = DOMAIN
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`            // Не задано - бессрочно
+	GranteeKind    GranteeType            `protobuf:"varint,12,opt,name=grantee_kind,json=granteeKind,proto3,enum=dbservice.GranteeType" json:"grantee_kind,omitempty"`
+	PermissionRole PermissionRole         `protobuf:"varint,13,opt,name=permission_role,json=permissionRole,proto3,enum=dbservice.PermissionRole" json:"permission_role,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *CheckPermissionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *FilePermission) Reset() {
+	*x = FilePermission{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (*CheckPermissionRequest) ProtoMessage() {}
+func (x *FilePermission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (x *CheckPermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[31]
+func (*FilePermission) ProtoMessage() {}
+
+func (x *FilePermission) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2109,54 +3086,110 @@ func (x *CheckPermissionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckPermissionRequest.ProtoReflect.Descriptor instead.
-func (*CheckPermissionRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use FilePermission.ProtoReflect.Descriptor instead.
+func (*FilePermission) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *CheckPermissionRequest) GetFileId() string {
+func (x *FilePermission) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FilePermission) GetFileId() string {
 	if x != nil {
 		return x.FileId
 	}
 	return ""
 }
 
-func (x *CheckPermissionRequest) GetUserId() string {
+func (x *FilePermission) GetGranteeId() string {
 	if x != nil {
-		return x.UserId
+		return x.GranteeId
+	}
+	return ""
+}
+
+func (x *FilePermission) GetAllowShare() bool {
+	if x != nil {
+		return x.AllowShare
+	}
+	return false
+}
+
+func (x *FilePermission) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FilePermission) GetInherited() bool {
+	if x != nil {
+		return x.Inherited
+	}
+	return false
+}
+
+func (x *FilePermission) GetInheritedFrom() string {
+	if x != nil {
+		return x.InheritedFrom
 	}
 	return ""
 }
 
-func (x *CheckPermissionRequest) GetRequiredRole() string {
+func (x *FilePermission) GetDomain() string {
 	if x != nil {
-		return x.RequiredRole
+		return x.Domain
 	}
 	return ""
 }
 
-type PermissionResponse struct {
+func (x *FilePermission) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *FilePermission) GetGranteeKind() GranteeType {
+	if x != nil {
+		return x.GranteeKind
+	}
+	return GranteeType_GRANTEE_TYPE_UNSPECIFIED
+}
+
+func (x *FilePermission) GetPermissionRole() PermissionRole {
+	if x != nil {
+		return x.PermissionRole
+	}
+	return PermissionRole_PERMISSION_ROLE_UNSPECIFIED
+}
+
+type PermissionID struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	HasPermission bool                   `protobuf:"varint,1,opt,name=has_permission,json=hasPermission,proto3" json:"has_permission,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PermissionResponse) Reset() {
-	*x = PermissionResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[32]
+func (x *PermissionID) Reset() {
+	*x = PermissionID{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PermissionResponse) String() string {
+func (x *PermissionID) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PermissionResponse) ProtoMessage() {}
+func (*PermissionID) ProtoMessage() {}
 
-func (x *PermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[32]
+func (x *PermissionID) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2167,42 +3200,4646 @@ func (x *PermissionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PermissionResponse.ProtoReflect.Descriptor instead.
-func (*PermissionResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use PermissionID.ProtoReflect.Descriptor instead.
+func (*PermissionID) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *PermissionResponse) GetHasPermission() bool {
+func (x *PermissionID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// acting_user_id - пользователь, который выдаёт, меняет или отзывает право.
+// Ему нужен allow_share, а роль права не может быть выше его собственной.
+type CreatePermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permission    *FilePermission        `protobuf:"bytes,1,opt,name=permission,proto3" json:"permission,omitempty"`
+	ActingUserId  string                 `protobuf:"bytes,2,opt,name=acting_user_id,json=actingUserId,proto3" json:"acting_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePermissionRequest) Reset() {
+	*x = CreatePermissionRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePermissionRequest) ProtoMessage() {}
+
+func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePermissionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreatePermissionRequest) GetPermission() *FilePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return nil
+}
+
+func (x *CreatePermissionRequest) GetActingUserId() string {
+	if x != nil {
+		return x.ActingUserId
+	}
+	return ""
+}
+
+type UpdatePermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permission    *FilePermission        `protobuf:"bytes,1,opt,name=permission,proto3" json:"permission,omitempty"`
+	ActingUserId  string                 `protobuf:"bytes,2,opt,name=acting_user_id,json=actingUserId,proto3" json:"acting_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePermissionRequest) Reset() {
+	*x = UpdatePermissionRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePermissionRequest) ProtoMessage() {}
+
+func (x *UpdatePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePermissionRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UpdatePermissionRequest) GetPermission() *FilePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return nil
+}
+
+func (x *UpdatePermissionRequest) GetActingUserId() string {
+	if x != nil {
+		return x.ActingUserId
+	}
+	return ""
+}
+
+type DeletePermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActingUserId  string                 `protobuf:"bytes,2,opt,name=acting_user_id,json=actingUserId,proto3" json:"acting_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePermissionRequest) Reset() {
+	*x = DeletePermissionRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePermissionRequest) ProtoMessage() {}
+
+func (x *DeletePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePermissionRequest.ProtoReflect.Descriptor instead.
+func (*DeletePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeletePermissionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeletePermissionRequest) GetActingUserId() string {
+	if x != nil {
+		return x.ActingUserId
+	}
+	return ""
+}
+
+type ListPermissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permissions   []*FilePermission      `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPermissionsResponse) Reset() {
+	*x = ListPermissionsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPermissionsResponse) ProtoMessage() {}
+
+func (x *ListPermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPermissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListPermissionsResponse) GetPermissions() []*FilePermission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type CheckPermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MinimumRole   PermissionRole         `protobuf:"varint,4,opt,name=minimum_role,json=minimumRole,proto3,enum=dbservice.PermissionRole" json:"minimum_role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionRequest) Reset() {
+	*x = CheckPermissionRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionRequest) ProtoMessage() {}
+
+func (x *CheckPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionRequest.ProtoReflect.Descriptor instead.
+func (*CheckPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CheckPermissionRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *CheckPermissionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckPermissionRequest) GetMinimumRole() PermissionRole {
+	if x != nil {
+		return x.MinimumRole
+	}
+	return PermissionRole_PERMISSION_ROLE_UNSPECIFIED
+}
+
+type PermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HasPermission bool                   `protobuf:"varint,1,opt,name=has_permission,json=hasPermission,proto3" json:"has_permission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PermissionResponse) Reset() {
+	*x = PermissionResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionResponse) ProtoMessage() {}
+
+func (x *PermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionResponse.ProtoReflect.Descriptor instead.
+func (*PermissionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PermissionResponse) GetHasPermission() bool {
+	if x != nil {
+		return x.HasPermission
+	}
+	return false
+}
+
+type CheckPermissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileIds       []string               `protobuf:"bytes,2,rep,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	MinimumRole   PermissionRole         `protobuf:"varint,3,opt,name=minimum_role,json=minimumRole,proto3,enum=dbservice.PermissionRole" json:"minimum_role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionsRequest) Reset() {
+	*x = CheckPermissionsRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionsRequest) ProtoMessage() {}
+
+func (x *CheckPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionsRequest.ProtoReflect.Descriptor instead.
+func (*CheckPermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CheckPermissionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckPermissionsRequest) GetFileIds() []string {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+func (x *CheckPermissionsRequest) GetMinimumRole() PermissionRole {
+	if x != nil {
+		return x.MinimumRole
+	}
+	return PermissionRole_PERMISSION_ROLE_UNSPECIFIED
+}
+
+type CheckPermissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permissions   map[string]bool        `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // file_id -> есть ли доступ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPermissionsResponse) Reset() {
+	*x = CheckPermissionsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPermissionsResponse) ProtoMessage() {}
+
+func (x *CheckPermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPermissionsResponse.ProtoReflect.Descriptor instead.
+func (*CheckPermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CheckPermissionsResponse) GetPermissions() map[string]bool {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type GetEffectivePermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShareLinkId   string                 `protobuf:"bytes,3,opt,name=share_link_id,json=shareLinkId,proto3" json:"share_link_id,omitempty"` // link_id из ResolveShareLink, если доступ открыт по ссылке
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEffectivePermissionRequest) Reset() {
+	*x = GetEffectivePermissionRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectivePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectivePermissionRequest) ProtoMessage() {}
+
+func (x *GetEffectivePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectivePermissionRequest.ProtoReflect.Descriptor instead.
+func (*GetEffectivePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetEffectivePermissionRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetEffectivePermissionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetEffectivePermissionRequest) GetShareLinkId() string {
+	if x != nil {
+		return x.ShareLinkId
+	}
+	return ""
+}
+
+// Основание доступа к файлу
+type PermissionSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // OWNER, USER, GROUP, DOMAIN, ANYONE, LINK
+	Role          PermissionRole         `protobuf:"varint,2,opt,name=role,proto3,enum=dbservice.PermissionRole" json:"role,omitempty"`
+	AllowShare    bool                   `protobuf:"varint,3,opt,name=allow_share,json=allowShare,proto3" json:"allow_share,omitempty"`
+	PermissionId  string                 `protobuf:"bytes,4,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"` // ID права или ссылки (LINK)
+	FileId        string                 `protobuf:"bytes,5,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`                   // Файл или папка, на которую выдан доступ
+	FileName      string                 `protobuf:"bytes,6,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Inherited     bool                   `protobuf:"varint,7,opt,name=inherited,proto3" json:"inherited,omitempty"` // Выдан на папку-предка
+	GroupId       string                 `protobuf:"bytes,8,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	GroupName     string                 `protobuf:"bytes,9,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Domain        string                 `protobuf:"bytes,10,opt,name=domain,proto3" json:"domain,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PermissionSource) Reset() {
+	*x = PermissionSource{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionSource) ProtoMessage() {}
+
+func (x *PermissionSource) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionSource.ProtoReflect.Descriptor instead.
+func (*PermissionSource) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PermissionSource) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PermissionSource) GetRole() PermissionRole {
+	if x != nil {
+		return x.Role
+	}
+	return PermissionRole_PERMISSION_ROLE_UNSPECIFIED
+}
+
+func (x *PermissionSource) GetAllowShare() bool {
+	if x != nil {
+		return x.AllowShare
+	}
+	return false
+}
+
+func (x *PermissionSource) GetPermissionId() string {
+	if x != nil {
+		return x.PermissionId
+	}
+	return ""
+}
+
+func (x *PermissionSource) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *PermissionSource) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *PermissionSource) GetInherited() bool {
+	if x != nil {
+		return x.Inherited
+	}
+	return false
+}
+
+func (x *PermissionSource) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *PermissionSource) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *PermissionSource) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *PermissionSource) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type EffectivePermission struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          PermissionRole         `protobuf:"varint,3,opt,name=role,proto3,enum=dbservice.PermissionRole" json:"role,omitempty"` // UNSPECIFIED, если доступа нет
+	AllowShare    bool                   `protobuf:"varint,4,opt,name=allow_share,json=allowShare,proto3" json:"allow_share,omitempty"`
+	Sources       []*PermissionSource    `protobuf:"bytes,5,rep,name=sources,proto3" json:"sources,omitempty"` // От самого сильного к самому слабому
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EffectivePermission) Reset() {
+	*x = EffectivePermission{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EffectivePermission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EffectivePermission) ProtoMessage() {}
+
+func (x *EffectivePermission) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EffectivePermission.ProtoReflect.Descriptor instead.
+func (*EffectivePermission) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *EffectivePermission) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *EffectivePermission) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EffectivePermission) GetRole() PermissionRole {
+	if x != nil {
+		return x.Role
+	}
+	return PermissionRole_PERMISSION_ROLE_UNSPECIFIED
+}
+
+func (x *EffectivePermission) GetAllowShare() bool {
+	if x != nil {
+		return x.AllowShare
+	}
+	return false
+}
+
+func (x *EffectivePermission) GetSources() []*PermissionSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// File metadata operations
+type UpdateFileMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Metadata      string                 `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFileMetadataRequest) Reset() {
+	*x = UpdateFileMetadataRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFileMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFileMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateFileMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFileMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFileMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *UpdateFileMetadataRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UpdateFileMetadataRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+type FileMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      string                 `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileMetadataResponse) Reset() {
+	*x = FileMetadataResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileMetadataResponse) ProtoMessage() {}
+
+func (x *FileMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileMetadataResponse.ProtoReflect.Descriptor instead.
+func (*FileMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *FileMetadataResponse) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+// File operations (star, move, copy, rename)
+type MoveFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	NewParentId   string                 `protobuf:"bytes,2,opt,name=new_parent_id,json=newParentId,proto3" json:"new_parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFileRequest) Reset() {
+	*x = MoveFileRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFileRequest) ProtoMessage() {}
+
+func (x *MoveFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFileRequest.ProtoReflect.Descriptor instead.
+func (*MoveFileRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *MoveFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *MoveFileRequest) GetNewParentId() string {
+	if x != nil {
+		return x.NewParentId
+	}
+	return ""
+}
+
+type CopyFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	NewParentId   string                 `protobuf:"bytes,2,opt,name=new_parent_id,json=newParentId,proto3" json:"new_parent_id,omitempty"`
+	NewName       string                 `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyFileRequest) Reset() {
+	*x = CopyFileRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFileRequest) ProtoMessage() {}
+
+func (x *CopyFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFileRequest.ProtoReflect.Descriptor instead.
+func (*CopyFileRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CopyFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *CopyFileRequest) GetNewParentId() string {
+	if x != nil {
+		return x.NewParentId
+	}
+	return ""
+}
+
+func (x *CopyFileRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+type RenameFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	NewName       string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameFileRequest) Reset() {
+	*x = RenameFileRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameFileRequest) ProtoMessage() {}
+
+func (x *RenameFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameFileRequest.ProtoReflect.Descriptor instead.
+func (*RenameFileRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RenameFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *RenameFileRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+type TransferOwnershipRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	FileId             string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	NewOwnerId         string                 `protobuf:"bytes,2,opt,name=new_owner_id,json=newOwnerId,proto3" json:"new_owner_id,omitempty"`
+	KeepPreviousAsRole PermissionRole         `protobuf:"varint,3,opt,name=keep_previous_as_role,json=keepPreviousAsRole,proto3,enum=dbservice.PermissionRole" json:"keep_previous_as_role,omitempty"` // READER, COMMENTER или WRITER; UNSPECIFIED - не оставлять доступ
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipRequest) Reset() {
+	*x = TransferOwnershipRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *TransferOwnershipRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetNewOwnerId() string {
+	if x != nil {
+		return x.NewOwnerId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetKeepPreviousAsRole() PermissionRole {
+	if x != nil {
+		return x.KeepPreviousAsRole
+	}
+	return PermissionRole_PERMISSION_ROLE_UNSPECIFIED
+}
+
+type TransferOwnershipResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PreviousOwnerId  string                 `protobuf:"bytes,1,opt,name=previous_owner_id,json=previousOwnerId,proto3" json:"previous_owner_id,omitempty"`
+	FilesTransferred int32                  `protobuf:"varint,2,opt,name=files_transferred,json=filesTransferred,proto3" json:"files_transferred,omitempty"` // Файлы и папки, у которых сменился владелец
+	BytesTransferred int64                  `protobuf:"varint,3,opt,name=bytes_transferred,json=bytesTransferred,proto3" json:"bytes_transferred,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipResponse) Reset() {
+	*x = TransferOwnershipResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipResponse) ProtoMessage() {}
+
+func (x *TransferOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *TransferOwnershipResponse) GetPreviousOwnerId() string {
+	if x != nil {
+		return x.PreviousOwnerId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipResponse) GetFilesTransferred() int32 {
+	if x != nil {
+		return x.FilesTransferred
+	}
+	return 0
+}
+
+func (x *TransferOwnershipResponse) GetBytesTransferred() int64 {
+	if x != nil {
+		return x.BytesTransferred
+	}
+	return 0
+}
+
+// File integrity operations
+type IntegrityResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	IsIntegrityVerified bool                   `protobuf:"varint,1,opt,name=is_integrity_verified,json=isIntegrityVerified,proto3" json:"is_integrity_verified,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *IntegrityResponse) Reset() {
+	*x = IntegrityResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntegrityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrityResponse) ProtoMessage() {}
+
+func (x *IntegrityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrityResponse.ProtoReflect.Descriptor instead.
+func (*IntegrityResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *IntegrityResponse) GetIsIntegrityVerified() bool {
+	if x != nil {
+		return x.IsIntegrityVerified
+	}
+	return false
+}
+
+type ChecksumsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checksums     map[string]string      `protobuf:"bytes,1,rep,name=checksums,proto3" json:"checksums,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecksumsResponse) Reset() {
+	*x = ChecksumsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecksumsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecksumsResponse) ProtoMessage() {}
+
+func (x *ChecksumsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecksumsResponse.ProtoReflect.Descriptor instead.
+func (*ChecksumsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ChecksumsResponse) GetChecksums() map[string]string {
+	if x != nil {
+		return x.Checksums
+	}
+	return nil
+}
+
+// Content deduplication operations
+type StorageBlob struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StoragePath    string                 `protobuf:"bytes,1,opt,name=storage_path,json=storagePath,proto3" json:"storage_path,omitempty"`
+	Sha256Checksum string                 `protobuf:"bytes,2,opt,name=sha256_checksum,json=sha256Checksum,proto3" json:"sha256_checksum,omitempty"`
+	Size           int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	RefCount       int64                  `protobuf:"varint,4,opt,name=ref_count,json=refCount,proto3" json:"ref_count,omitempty"` // Сколько файлов и ревизий ссылаются на объект
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReleasedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty"` // Когда исчезла последняя ссылка
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StorageBlob) Reset() {
+	*x = StorageBlob{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageBlob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageBlob) ProtoMessage() {}
+
+func (x *StorageBlob) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageBlob.ProtoReflect.Descriptor instead.
+func (*StorageBlob) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *StorageBlob) GetStoragePath() string {
+	if x != nil {
+		return x.StoragePath
+	}
+	return ""
+}
+
+func (x *StorageBlob) GetSha256Checksum() string {
+	if x != nil {
+		return x.Sha256Checksum
+	}
+	return ""
+}
+
+func (x *StorageBlob) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *StorageBlob) GetRefCount() int64 {
+	if x != nil {
+		return x.RefCount
+	}
+	return 0
+}
+
+func (x *StorageBlob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StorageBlob) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+type FindBySHA256Request struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Sha256Checksum string                 `protobuf:"bytes,1,opt,name=sha256_checksum,json=sha256Checksum,proto3" json:"sha256_checksum,omitempty"`
+	OwnerId        string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // Пусто - поиск по всему хранилищу
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FindBySHA256Request) Reset() {
+	*x = FindBySHA256Request{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindBySHA256Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindBySHA256Request) ProtoMessage() {}
+
+func (x *FindBySHA256Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindBySHA256Request.ProtoReflect.Descriptor instead.
+func (*FindBySHA256Request) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *FindBySHA256Request) GetSha256Checksum() string {
+	if x != nil {
+		return x.Sha256Checksum
+	}
+	return ""
+}
+
+func (x *FindBySHA256Request) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type FindDuplicatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesRequest) Reset() {
+	*x = FindDuplicatesRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesRequest) ProtoMessage() {}
+
+func (x *FindDuplicatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesRequest.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *FindDuplicatesRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type DuplicateGroup struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Sha256Checksum string                 `protobuf:"bytes,1,opt,name=sha256_checksum,json=sha256Checksum,proto3" json:"sha256_checksum,omitempty"`
+	Size           int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Files          []*File                `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	WastedBytes    int64                  `protobuf:"varint,4,opt,name=wasted_bytes,json=wastedBytes,proto3" json:"wasted_bytes,omitempty"` // Место, которое освободится после дедупликации
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DuplicateGroup) Reset() {
+	*x = DuplicateGroup{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateGroup) ProtoMessage() {}
+
+func (x *DuplicateGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateGroup.ProtoReflect.Descriptor instead.
+func (*DuplicateGroup) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DuplicateGroup) GetSha256Checksum() string {
+	if x != nil {
+		return x.Sha256Checksum
+	}
+	return ""
+}
+
+func (x *DuplicateGroup) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DuplicateGroup) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *DuplicateGroup) GetWastedBytes() int64 {
+	if x != nil {
+		return x.WastedBytes
+	}
+	return 0
+}
+
+type FindDuplicatesResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Groups           []*DuplicateGroup      `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	TotalWastedBytes int64                  `protobuf:"varint,2,opt,name=total_wasted_bytes,json=totalWastedBytes,proto3" json:"total_wasted_bytes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesResponse) Reset() {
+	*x = FindDuplicatesResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesResponse) ProtoMessage() {}
+
+func (x *FindDuplicatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesResponse.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *FindDuplicatesResponse) GetGroups() []*DuplicateGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *FindDuplicatesResponse) GetTotalWastedBytes() int64 {
+	if x != nil {
+		return x.TotalWastedBytes
+	}
+	return 0
+}
+
+type StoragePathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StoragePath   string                 `protobuf:"bytes,1,opt,name=storage_path,json=storagePath,proto3" json:"storage_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoragePathRequest) Reset() {
+	*x = StoragePathRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoragePathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoragePathRequest) ProtoMessage() {}
+
+func (x *StoragePathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoragePathRequest.ProtoReflect.Descriptor instead.
+func (*StoragePathRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *StoragePathRequest) GetStoragePath() string {
+	if x != nil {
+		return x.StoragePath
+	}
+	return ""
+}
+
+type ListUnreferencedBlobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUnreferencedBlobsRequest) Reset() {
+	*x = ListUnreferencedBlobsRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUnreferencedBlobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUnreferencedBlobsRequest) ProtoMessage() {}
+
+func (x *ListUnreferencedBlobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUnreferencedBlobsRequest.ProtoReflect.Descriptor instead.
+func (*ListUnreferencedBlobsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListUnreferencedBlobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListStorageBlobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blobs         []*StorageBlob         `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStorageBlobsResponse) Reset() {
+	*x = ListStorageBlobsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStorageBlobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStorageBlobsResponse) ProtoMessage() {}
+
+func (x *ListStorageBlobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStorageBlobsResponse.ProtoReflect.Descriptor instead.
+func (*ListStorageBlobsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListStorageBlobsResponse) GetBlobs() []*StorageBlob {
+	if x != nil {
+		return x.Blobs
+	}
+	return nil
+}
+
+type ReleaseBlobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Released      bool                   `protobuf:"varint,1,opt,name=released,proto3" json:"released,omitempty"` // false - объект снова используется, стирать нельзя
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseBlobResponse) Reset() {
+	*x = ReleaseBlobResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseBlobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseBlobResponse) ProtoMessage() {}
+
+func (x *ReleaseBlobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseBlobResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseBlobResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ReleaseBlobResponse) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
+// Message definitions for Groups
+type Group struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // Создатель становится владельцем группы; пусто, если его аккаунт стёрт
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *Group) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Group) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Group) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Group) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GroupID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupID) Reset() {
+	*x = GroupID{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupID) ProtoMessage() {}
+
+func (x *GroupID) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupID.ProtoReflect.Descriptor instead.
+func (*GroupID) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GroupID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// acting_user_id - пользователь, который меняет группу. Изменять группу и её состав
+// могут OWNER и ADMIN; удалять группу, назначать и снимать OWNER/ADMIN - только OWNER.
+type UpdateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *Group                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	ActingUserId  string                 `protobuf:"bytes,2,opt,name=acting_user_id,json=actingUserId,proto3" json:"acting_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGroupRequest) Reset() {
+	*x = UpdateGroupRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGroupRequest) ProtoMessage() {}
+
+func (x *UpdateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGroupRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *UpdateGroupRequest) GetGroup() *Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *UpdateGroupRequest) GetActingUserId() string {
+	if x != nil {
+		return x.ActingUserId
+	}
+	return ""
+}
+
+type DeleteGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActingUserId  string                 `protobuf:"bytes,2,opt,name=acting_user_id,json=actingUserId,proto3" json:"acting_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGroupRequest) Reset() {
+	*x = DeleteGroupRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupRequest) ProtoMessage() {}
+
+func (x *DeleteGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *DeleteGroupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteGroupRequest) GetActingUserId() string {
+	if x != nil {
+		return x.ActingUserId
+	}
+	return ""
+}
+
+type ListGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Группы пользователя, включая вложенные
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsRequest) Reset() {
+	*x = ListGroupsRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsRequest) ProtoMessage() {}
+
+func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListGroupsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*Group               `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsResponse) Reset() {
+	*x = ListGroupsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsResponse) ProtoMessage() {}
+
+func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListGroupsResponse) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type GroupMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`       // user_id или id вложенной группы
+	MemberType    string                 `protobuf:"bytes,3,opt,name=member_type,json=memberType,proto3" json:"member_type,omitempty"` // USER, GROUP
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`                               // OWNER, ADMIN, MEMBER (для GROUP только MEMBER)
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupMember) Reset() {
+	*x = GroupMember{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMember) ProtoMessage() {}
+
+func (x *GroupMember) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMember.ProtoReflect.Descriptor instead.
+func (*GroupMember) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GroupMember) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GroupMember) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *GroupMember) GetMemberType() string {
+	if x != nil {
+		return x.MemberType
+	}
+	return ""
+}
+
+func (x *GroupMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GroupMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AddGroupMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *GroupMember           `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	ActingUserId  string                 `protobuf:"bytes,2,opt,name=acting_user_id,json=actingUserId,proto3" json:"acting_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddGroupMemberRequest) Reset() {
+	*x = AddGroupMemberRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddGroupMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupMemberRequest) ProtoMessage() {}
+
+func (x *AddGroupMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddGroupMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *AddGroupMemberRequest) GetMember() *GroupMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+func (x *AddGroupMemberRequest) GetActingUserId() string {
+	if x != nil {
+		return x.ActingUserId
+	}
+	return ""
+}
+
+type RemoveGroupMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	MemberType    string                 `protobuf:"bytes,3,opt,name=member_type,json=memberType,proto3" json:"member_type,omitempty"`
+	ActingUserId  string                 `protobuf:"bytes,4,opt,name=acting_user_id,json=actingUserId,proto3" json:"acting_user_id,omitempty"` // Участник может выйти из группы сам
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveGroupMemberRequest) Reset() {
+	*x = RemoveGroupMemberRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveGroupMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupMemberRequest) ProtoMessage() {}
+
+func (x *RemoveGroupMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveGroupMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RemoveGroupMemberRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *RemoveGroupMemberRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *RemoveGroupMemberRequest) GetMemberType() string {
+	if x != nil {
+		return x.MemberType
+	}
+	return ""
+}
+
+func (x *RemoveGroupMemberRequest) GetActingUserId() string {
+	if x != nil {
+		return x.ActingUserId
+	}
+	return ""
+}
+
+type ListGroupMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*GroupMember         `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupMembersResponse) Reset() {
+	*x = ListGroupMembersResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupMembersResponse) ProtoMessage() {}
+
+func (x *ListGroupMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupMembersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ListGroupMembersResponse) GetMembers() []*GroupMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Message definitions for Share Links
+type ShareLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Role          PermissionRole         `protobuf:"varint,3,opt,name=role,proto3,enum=dbservice.PermissionRole" json:"role,omitempty"` // READER, COMMENTER, WRITER
+	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`          // Не задано - бессрочно
+	PasswordHash  string                 `protobuf:"bytes,7,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"` // Только на запись: в ответах не возвращается
+	MaxUses       int32                  `protobuf:"varint,8,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`               // 0 - без ограничения
+	UseCount      int32                  `protobuf:"varint,9,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	Revoked       bool                   `protobuf:"varint,11,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	HasPassword   bool                   `protobuf:"varint,13,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ShareLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShareLink) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *ShareLink) GetRole() PermissionRole {
+	if x != nil {
+		return x.Role
+	}
+	return PermissionRole_PERMISSION_ROLE_UNSPECIFIED
+}
+
+func (x *ShareLink) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ShareLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+func (x *ShareLink) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *ShareLink) GetUseCount() int32 {
+	if x != nil {
+		return x.UseCount
+	}
+	return 0
+}
+
+func (x *ShareLink) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+func (x *ShareLink) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+type ShareLinkID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareLinkID) Reset() {
+	*x = ShareLinkID{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLinkID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLinkID) ProtoMessage() {}
+
+func (x *ShareLinkID) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLinkID.ProtoReflect.Descriptor instead.
+func (*ShareLinkID) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ShareLinkID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // Возвращается только при создании
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkResponse) Reset() {
+	*x = CreateShareLinkResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkResponse) ProtoMessage() {}
+
+func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *CreateShareLinkResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateShareLinkResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListShareLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*ShareLink           `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksResponse) ProtoMessage() {}
+
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ListShareLinksResponse) GetLinks() []*ShareLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type ResolveShareLinkRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Token            string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	PasswordVerified bool                   `protobuf:"varint,2,opt,name=password_verified,json=passwordVerified,proto3" json:"password_verified,omitempty"` // Пароль проверен вызывающим сервисом по password_hash
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ResolveShareLinkRequest) Reset() {
+	*x = ResolveShareLinkRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveShareLinkRequest) ProtoMessage() {}
+
+func (x *ResolveShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*ResolveShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ResolveShareLinkRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResolveShareLinkRequest) GetPasswordVerified() bool {
+	if x != nil {
+		return x.PasswordVerified
+	}
+	return false
+}
+
+type ResolveShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        ShareLinkStatus        `protobuf:"varint,1,opt,name=status,proto3,enum=dbservice.ShareLinkStatus" json:"status,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Role          PermissionRole         `protobuf:"varint,3,opt,name=role,proto3,enum=dbservice.PermissionRole" json:"role,omitempty"`
+	LinkId        string                 `protobuf:"bytes,4,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	PasswordHash  string                 `protobuf:"bytes,5,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"` // Только для SHARE_LINK_STATUS_PASSWORD_REQUIRED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveShareLinkResponse) Reset() {
+	*x = ResolveShareLinkResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveShareLinkResponse) ProtoMessage() {}
+
+func (x *ResolveShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*ResolveShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ResolveShareLinkResponse) GetStatus() ShareLinkStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ShareLinkStatus_SHARE_LINK_STATUS_UNSPECIFIED
+}
+
+func (x *ResolveShareLinkResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *ResolveShareLinkResponse) GetRole() PermissionRole {
+	if x != nil {
+		return x.Role
+	}
+	return PermissionRole_PERMISSION_ROLE_UNSPECIFIED
+}
+
+func (x *ResolveShareLinkResponse) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *ResolveShareLinkResponse) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+// Message definitions for Sessions
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FamilyId      string                 `protobuf:"bytes,2,opt,name=family_id,json=familyId,proto3" json:"family_id,omitempty"` // Все ротации одного входа
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceName    string                 `protobuf:"bytes,4,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,5,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,6,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	RevokedReason string                 `protobuf:"bytes,11,opt,name=revoked_reason,json=revokedReason,proto3" json:"revoked_reason,omitempty"` // ROTATED, REVOKED, REUSE_DETECTED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetFamilyId() string {
+	if x != nil {
+		return x.FamilyId
+	}
+	return ""
+}
+
+func (x *Session) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Session) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *Session) GetRevokedReason() string {
+	if x != nil {
+		return x.RevokedReason
+	}
+	return ""
+}
+
+type SessionID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionID) Reset() {
+	*x = SessionID{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionID) ProtoMessage() {}
+
+func (x *SessionID) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionID.ProtoReflect.Descriptor instead.
+func (*SessionID) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *SessionID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceName    string                 `protobuf:"bytes,2,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSessionRequest) Reset() {
+	*x = CreateSessionRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionRequest) ProtoMessage() {}
+
+func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *CreateSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type SessionTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *Session               `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Возвращается один раз, в базе хранится только хеш
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionTokenResponse) Reset() {
+	*x = SessionTokenResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionTokenResponse) ProtoMessage() {}
+
+func (x *SessionTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionTokenResponse.ProtoReflect.Descriptor instead.
+func (*SessionTokenResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *SessionTokenResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *SessionTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RotateSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"` // Пусто - оставить прежний
+	UserAgent     string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateSessionRequest) Reset() {
+	*x = RotateSessionRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateSessionRequest) ProtoMessage() {}
+
+func (x *RotateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateSessionRequest.ProtoReflect.Descriptor instead.
+func (*RotateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *RotateSessionRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RotateSessionRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *RotateSessionRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+type RotateSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        RotateSessionStatus    `protobuf:"varint,1,opt,name=status,proto3,enum=dbservice.RotateSessionStatus" json:"status,omitempty"`
+	Session       *Session               `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Только для ROTATE_SESSION_STATUS_ROTATED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateSessionResponse) Reset() {
+	*x = RotateSessionResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateSessionResponse) ProtoMessage() {}
+
+func (x *RotateSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateSessionResponse.ProtoReflect.Descriptor instead.
+func (*RotateSessionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *RotateSessionResponse) GetStatus() RotateSessionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RotateSessionStatus_ROTATE_SESSION_STATUS_UNSPECIFIED
+}
+
+func (x *RotateSessionResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *RotateSessionResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeAllSessionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExceptSessionId string                 `protobuf:"bytes,2,opt,name=except_session_id,json=exceptSessionId,proto3" json:"except_session_id,omitempty"` // Например, текущая сессия
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsRequest) Reset() {
+	*x = RevokeAllSessionsRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *RevokeAllSessionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeAllSessionsRequest) GetExceptSessionId() string {
+	if x != nil {
+		return x.ExceptSessionId
+	}
+	return ""
+}
+
+type RevokeAllSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revoked       int32                  `protobuf:"varint,1,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsResponse) Reset() {
+	*x = RevokeAllSessionsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeAllSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *RevokeAllSessionsResponse) GetRevoked() int32 {
+	if x != nil {
+		return x.Revoked
+	}
+	return 0
+}
+
+type EnrollTwoFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                           // base32, для ручного ввода в приложение
+	OtpauthUrl    string                 `protobuf:"bytes,2,opt,name=otpauth_url,json=otpauthUrl,proto3" json:"otpauth_url,omitempty"` // Для QR-кода
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTwoFactorResponse) Reset() {
+	*x = EnrollTwoFactorResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTwoFactorResponse) ProtoMessage() {}
+
+func (x *EnrollTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *EnrollTwoFactorResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTwoFactorResponse) GetOtpauthUrl() string {
+	if x != nil {
+		return x.OtpauthUrl
+	}
+	return ""
+}
+
+type TwoFactorCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // TOTP-код или резервный код
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TwoFactorCodeRequest) Reset() {
+	*x = TwoFactorCodeRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TwoFactorCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoFactorCodeRequest) ProtoMessage() {}
+
+func (x *TwoFactorCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoFactorCodeRequest.ProtoReflect.Descriptor instead.
+func (*TwoFactorCodeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *TwoFactorCodeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TwoFactorCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTwoFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Confirmed     bool                   `protobuf:"varint,1,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,2,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // Возвращаются один раз, в базе хранятся только хеши
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTwoFactorResponse) Reset() {
+	*x = ConfirmTwoFactorResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTwoFactorResponse) ProtoMessage() {}
+
+func (x *ConfirmTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ConfirmTwoFactorResponse) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+func (x *ConfirmTwoFactorResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type VerifyTwoFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTwoFactorResponse) Reset() {
+	*x = VerifyTwoFactorResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTwoFactorResponse) ProtoMessage() {}
+
+func (x *VerifyTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *VerifyTwoFactorResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type ConsumeRecoveryCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Remaining     int32                  `protobuf:"varint,2,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumeRecoveryCodeResponse) Reset() {
+	*x = ConsumeRecoveryCodeResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeRecoveryCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeRecoveryCodeResponse) ProtoMessage() {}
+
+func (x *ConsumeRecoveryCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeRecoveryCodeResponse.ProtoReflect.Descriptor instead.
+func (*ConsumeRecoveryCodeResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ConsumeRecoveryCodeResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ConsumeRecoveryCodeResponse) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+type RegenerateRecoveryCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`                                     // Принят ли TOTP-код
+	RecoveryCodes []string               `protobuf:"bytes,2,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // Заменяют все прежние коды
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesResponse) Reset() {
+	*x = RegenerateRecoveryCodesResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesResponse) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *RegenerateRecoveryCodesResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *RegenerateRecoveryCodesResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type WebAuthnCredential struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CredentialId  []byte                 `protobuf:"bytes,3,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // COSE-ключ
+	SignCount     uint32                 `protobuf:"varint,5,opt,name=sign_count,json=signCount,proto3" json:"sign_count,omitempty"`
+	Transports    []string               `protobuf:"bytes,6,rep,name=transports,proto3" json:"transports,omitempty"` // usb, nfc, ble, internal, hybrid
+	Name          string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebAuthnCredential) Reset() {
+	*x = WebAuthnCredential{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebAuthnCredential) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebAuthnCredential) ProtoMessage() {}
+
+func (x *WebAuthnCredential) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebAuthnCredential.ProtoReflect.Descriptor instead.
+func (*WebAuthnCredential) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *WebAuthnCredential) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebAuthnCredential) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WebAuthnCredential) GetCredentialId() []byte {
+	if x != nil {
+		return x.CredentialId
+	}
+	return nil
+}
+
+func (x *WebAuthnCredential) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *WebAuthnCredential) GetSignCount() uint32 {
+	if x != nil {
+		return x.SignCount
+	}
+	return 0
+}
+
+func (x *WebAuthnCredential) GetTransports() []string {
+	if x != nil {
+		return x.Transports
+	}
+	return nil
+}
+
+func (x *WebAuthnCredential) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WebAuthnCredential) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebAuthnCredential) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+type WebAuthnCredentialID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebAuthnCredentialID) Reset() {
+	*x = WebAuthnCredentialID{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebAuthnCredentialID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebAuthnCredentialID) ProtoMessage() {}
+
+func (x *WebAuthnCredentialID) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebAuthnCredentialID.ProtoReflect.Descriptor instead.
+func (*WebAuthnCredentialID) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *WebAuthnCredentialID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetWebAuthnCredentialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CredentialId  []byte                 `protobuf:"bytes,1,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebAuthnCredentialRequest) Reset() {
+	*x = GetWebAuthnCredentialRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebAuthnCredentialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebAuthnCredentialRequest) ProtoMessage() {}
+
+func (x *GetWebAuthnCredentialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebAuthnCredentialRequest.ProtoReflect.Descriptor instead.
+func (*GetWebAuthnCredentialRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *GetWebAuthnCredentialRequest) GetCredentialId() []byte {
+	if x != nil {
+		return x.CredentialId
+	}
+	return nil
+}
+
+type ListWebAuthnCredentialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Credentials   []*WebAuthnCredential  `protobuf:"bytes,1,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebAuthnCredentialsResponse) Reset() {
+	*x = ListWebAuthnCredentialsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebAuthnCredentialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebAuthnCredentialsResponse) ProtoMessage() {}
+
+func (x *ListWebAuthnCredentialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebAuthnCredentialsResponse.ProtoReflect.Descriptor instead.
+func (*ListWebAuthnCredentialsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *ListWebAuthnCredentialsResponse) GetCredentials() []*WebAuthnCredential {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type RenameWebAuthnCredentialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameWebAuthnCredentialRequest) Reset() {
+	*x = RenameWebAuthnCredentialRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameWebAuthnCredentialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameWebAuthnCredentialRequest) ProtoMessage() {}
+
+func (x *RenameWebAuthnCredentialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameWebAuthnCredentialRequest.ProtoReflect.Descriptor instead.
+func (*RenameWebAuthnCredentialRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *RenameWebAuthnCredentialRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RenameWebAuthnCredentialRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateWebAuthnSignCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CredentialId  []byte                 `protobuf:"bytes,1,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	SignCount     uint32                 `protobuf:"varint,2,opt,name=sign_count,json=signCount,proto3" json:"sign_count,omitempty"` // Значение из authenticator data
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebAuthnSignCountRequest) Reset() {
+	*x = UpdateWebAuthnSignCountRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebAuthnSignCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebAuthnSignCountRequest) ProtoMessage() {}
+
+func (x *UpdateWebAuthnSignCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebAuthnSignCountRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWebAuthnSignCountRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *UpdateWebAuthnSignCountRequest) GetCredentialId() []byte {
+	if x != nil {
+		return x.CredentialId
+	}
+	return nil
+}
+
+func (x *UpdateWebAuthnSignCountRequest) GetSignCount() uint32 {
+	if x != nil {
+		return x.SignCount
+	}
+	return 0
+}
+
+type UpdateWebAuthnSignCountResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Status        WebAuthnSignCountStatus `protobuf:"varint,1,opt,name=status,proto3,enum=dbservice.WebAuthnSignCountStatus" json:"status,omitempty"`
+	Credential    *WebAuthnCredential     `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebAuthnSignCountResponse) Reset() {
+	*x = UpdateWebAuthnSignCountResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebAuthnSignCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebAuthnSignCountResponse) ProtoMessage() {}
+
+func (x *UpdateWebAuthnSignCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebAuthnSignCountResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWebAuthnSignCountResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *UpdateWebAuthnSignCountResponse) GetStatus() WebAuthnSignCountStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateWebAuthnSignCountResponse) GetCredential() *WebAuthnCredential {
+	if x != nil {
+		return x.Credential
+	}
+	return nil
+}
+
+type UserIdentity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // google, github, ...
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`   // Claim sub провайдера
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	LinkedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=linked_at,json=linkedAt,proto3" json:"linked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserIdentity) Reset() {
+	*x = UserIdentity{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserIdentity) ProtoMessage() {}
+
+func (x *UserIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserIdentity.ProtoReflect.Descriptor instead.
+func (*UserIdentity) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *UserIdentity) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *UserIdentity) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *UserIdentity) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserIdentity) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserIdentity) GetLinkedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LinkedAt
+	}
+	return nil
+}
+
+type IdentityKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityKey) Reset() {
+	*x = IdentityKey{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityKey) ProtoMessage() {}
+
+func (x *IdentityKey) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityKey.ProtoReflect.Descriptor instead.
+func (*IdentityKey) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *IdentityKey) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *IdentityKey) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type UnlinkIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkIdentityRequest) Reset() {
+	*x = UnlinkIdentityRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkIdentityRequest) ProtoMessage() {}
+
+func (x *UnlinkIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *UnlinkIdentityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnlinkIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *UnlinkIdentityRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type ListIdentitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identities    []*UserIdentity        `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIdentitiesResponse) Reset() {
+	*x = ListIdentitiesResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentitiesResponse) ProtoMessage() {}
+
+func (x *ListIdentitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListIdentitiesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *ListIdentitiesResponse) GetIdentities() []*UserIdentity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+type AccountDeletion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeactivatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deactivated_at,json=deactivatedAt,proto3" json:"deactivated_at,omitempty"`
+	EraseAfter    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=erase_after,json=eraseAfter,proto3" json:"erase_after,omitempty"` // После этого момента доступен EraseUser
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountDeletion) Reset() {
+	*x = AccountDeletion{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDeletion) ProtoMessage() {}
+
+func (x *AccountDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDeletion.ProtoReflect.Descriptor instead.
+func (*AccountDeletion) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *AccountDeletion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AccountDeletion) GetDeactivatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeactivatedAt
+	}
+	return nil
+}
+
+func (x *AccountDeletion) GetEraseAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EraseAfter
+	}
+	return nil
+}
+
+type ListPendingErasuresRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // По умолчанию 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingErasuresRequest) Reset() {
+	*x = ListPendingErasuresRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingErasuresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingErasuresRequest) ProtoMessage() {}
+
+func (x *ListPendingErasuresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingErasuresRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingErasuresRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ListPendingErasuresRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListPendingErasuresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deletions     []*AccountDeletion     `protobuf:"bytes,1,rep,name=deletions,proto3" json:"deletions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingErasuresResponse) Reset() {
+	*x = ListPendingErasuresResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingErasuresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingErasuresResponse) ProtoMessage() {}
+
+func (x *ListPendingErasuresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingErasuresResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingErasuresResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *ListPendingErasuresResponse) GetDeletions() []*AccountDeletion {
+	if x != nil {
+		return x.Deletions
+	}
+	return nil
+}
+
+type ErasureResult struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UserId             string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FilesDeleted       int32                  `protobuf:"varint,2,opt,name=files_deleted,json=filesDeleted,proto3" json:"files_deleted,omitempty"`
+	RevisionsDeleted   int32                  `protobuf:"varint,3,opt,name=revisions_deleted,json=revisionsDeleted,proto3" json:"revisions_deleted,omitempty"`
+	PermissionsDeleted int32                  `protobuf:"varint,4,opt,name=permissions_deleted,json=permissionsDeleted,proto3" json:"permissions_deleted,omitempty"`
+	SessionsDeleted    int32                  `protobuf:"varint,5,opt,name=sessions_deleted,json=sessionsDeleted,proto3" json:"sessions_deleted,omitempty"`
+	StoragePaths       []string               `protobuf:"bytes,6,rep,name=storage_paths,json=storagePaths,proto3" json:"storage_paths,omitempty"`                 // Объекты без ссылок: стереть с диска и вызвать ReleaseBlob
+	GroupsTransferred  int32                  `protobuf:"varint,7,opt,name=groups_transferred,json=groupsTransferred,proto3" json:"groups_transferred,omitempty"` // Владение группой перешло ADMIN или самому давнему участнику
+	GroupsDeleted      int32                  `protobuf:"varint,8,opt,name=groups_deleted,json=groupsDeleted,proto3" json:"groups_deleted,omitempty"`             // Группы без других участников удалены
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ErasureResult) Reset() {
+	*x = ErasureResult{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErasureResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErasureResult) ProtoMessage() {}
+
+func (x *ErasureResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErasureResult.ProtoReflect.Descriptor instead.
+func (*ErasureResult) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *ErasureResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ErasureResult) GetFilesDeleted() int32 {
+	if x != nil {
+		return x.FilesDeleted
+	}
+	return 0
+}
+
+func (x *ErasureResult) GetRevisionsDeleted() int32 {
+	if x != nil {
+		return x.RevisionsDeleted
+	}
+	return 0
+}
+
+func (x *ErasureResult) GetPermissionsDeleted() int32 {
+	if x != nil {
+		return x.PermissionsDeleted
+	}
+	return 0
+}
+
+func (x *ErasureResult) GetSessionsDeleted() int32 {
+	if x != nil {
+		return x.SessionsDeleted
+	}
+	return 0
+}
+
+func (x *ErasureResult) GetStoragePaths() []string {
+	if x != nil {
+		return x.StoragePaths
+	}
+	return nil
+}
+
+func (x *ErasureResult) GetGroupsTransferred() int32 {
+	if x != nil {
+		return x.GroupsTransferred
+	}
+	return 0
+}
+
+func (x *ErasureResult) GetGroupsDeleted() int32 {
+	if x != nil {
+		return x.GroupsDeleted
+	}
+	return 0
+}
+
+type ListUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Фильтры, неуказанные не применяются
+	Role                string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	IsActive            *bool                  `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	IsEmailVerified     *bool                  `protobuf:"varint,3,opt,name=is_email_verified,json=isEmailVerified,proto3,oneof" json:"is_email_verified,omitempty"`
+	IsLocked            *bool                  `protobuf:"varint,4,opt,name=is_locked,json=isLocked,proto3,oneof" json:"is_locked,omitempty"` // Заблокирован прямо сейчас
+	IsQuotaExceeded     *bool                  `protobuf:"varint,5,opt,name=is_quota_exceeded,json=isQuotaExceeded,proto3,oneof" json:"is_quota_exceeded,omitempty"`
+	CreatedAfter        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	LastLoginAfter      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_login_after,json=lastLoginAfter,proto3" json:"last_login_after,omitempty"`
+	LastLoginBefore     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_login_before,json=lastLoginBefore,proto3" json:"last_login_before,omitempty"`
+	Search              string                 `protobuf:"bytes,10,opt,name=search,proto3" json:"search,omitempty"` // Подстрока email или username
+	SortBy              UserSortField          `protobuf:"varint,11,opt,name=sort_by,json=sortBy,proto3,enum=dbservice.UserSortField" json:"sort_by,omitempty"`
+	Descending          bool                   `protobuf:"varint,12,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize            int32                  `protobuf:"varint,13,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // По умолчанию 50, не больше 500
+	PageToken           string                 `protobuf:"bytes,14,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token предыдущей страницы, с той же сортировкой
+	IncludeExtendedInfo bool                   `protobuf:"varint,15,opt,name=include_extended_info,json=includeExtendedInfo,proto3" json:"include_extended_info,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetIsEmailVerified() bool {
+	if x != nil && x.IsEmailVerified != nil {
+		return *x.IsEmailVerified
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetIsLocked() bool {
+	if x != nil && x.IsLocked != nil {
+		return *x.IsLocked
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetIsQuotaExceeded() bool {
+	if x != nil && x.IsQuotaExceeded != nil {
+		return *x.IsQuotaExceeded
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetLastLoginAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginAfter
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetLastLoginBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginBefore
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetSortBy() UserSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return UserSortField_USER_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListUsersRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIncludeExtendedInfo() bool {
+	if x != nil {
+		return x.IncludeExtendedInfo
+	}
+	return false
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	ExtendedInfo  []*UserExtendedInfo    `protobuf:"bytes,2,rep,name=extended_info,json=extendedInfo,proto3" json:"extended_info,omitempty"`      // В том же порядке, если include_extended_info
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Пусто на последней странице
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                                       // Всего по фильтрам
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetExtendedInfo() []*UserExtendedInfo {
+	if x != nil {
+		return x.ExtendedInfo
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListUsersResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type PasswordHistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PasswordHash  string                 `protobuf:"bytes,1,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasswordHistoryEntry) Reset() {
+	*x = PasswordHistoryEntry{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordHistoryEntry) ProtoMessage() {}
+
+func (x *PasswordHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordHistoryEntry.ProtoReflect.Descriptor instead.
+func (*PasswordHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *PasswordHistoryEntry) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+func (x *PasswordHistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.HasPermission
+		return x.CreatedAt
 	}
-	return false
+	return nil
 }
 
-// File metadata operations
-type UpdateFileMetadataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	Metadata      string                 `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+type PasswordHistoryResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Entries       []*PasswordHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // Сначала текущий пароль
+	Depth         int32                   `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`    // Сколько последних паролей запрещено использовать повторно
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateFileMetadataRequest) Reset() {
-	*x = UpdateFileMetadataRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[33]
+func (x *PasswordHistoryResponse) Reset() {
+	*x = PasswordHistoryResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateFileMetadataRequest) String() string {
+func (x *PasswordHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateFileMetadataRequest) ProtoMessage() {}
+func (*PasswordHistoryResponse) ProtoMessage() {}
 
-func (x *UpdateFileMetadataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[33]
+func (x *PasswordHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2213,47 +7850,54 @@ func (x *UpdateFileMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateFileMetadataRequest.ProtoReflect.Descriptor instead.
-func (*UpdateFileMetadataRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use PasswordHistoryResponse.ProtoReflect.Descriptor instead.
+func (*PasswordHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{118}
 }
 
-func (x *UpdateFileMetadataRequest) GetFileId() string {
+func (x *PasswordHistoryResponse) GetEntries() []*PasswordHistoryEntry {
 	if x != nil {
-		return x.FileId
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateFileMetadataRequest) GetMetadata() string {
+func (x *PasswordHistoryResponse) GetDepth() int32 {
 	if x != nil {
-		return x.Metadata
+		return x.Depth
 	}
-	return ""
+	return 0
 }
 
-type FileMetadataResponse struct {
+// Настройки пользователя. Допустимые ключи:
+//
+//	theme: "light" | "dark" | "system"
+//	language: код языка, например "ru" или "en-US"
+//	default_sort: {field: "name" | "created_at" | "updated_at" | "size", direction: "asc" | "desc"}
+//	notifications: {email, push, shares, storage: bool}
+type Preferences struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      string                 `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences   *structpb.Struct       `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileMetadataResponse) Reset() {
-	*x = FileMetadataResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[34]
+func (x *Preferences) Reset() {
+	*x = Preferences{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileMetadataResponse) String() string {
+func (x *Preferences) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileMetadataResponse) ProtoMessage() {}
+func (*Preferences) ProtoMessage() {}
 
-func (x *FileMetadataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[34]
+func (x *Preferences) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2264,42 +7908,48 @@ func (x *FileMetadataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileMetadataResponse.ProtoReflect.Descriptor instead.
-func (*FileMetadataResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use Preferences.ProtoReflect.Descriptor instead.
+func (*Preferences) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{119}
 }
 
-func (x *FileMetadataResponse) GetMetadata() string {
+func (x *Preferences) GetUserId() string {
 	if x != nil {
-		return x.Metadata
+		return x.UserId
 	}
 	return ""
 }
 
-// File operations (star, move, copy, rename)
-type MoveFileRequest struct {
+func (x *Preferences) GetPreferences() *structpb.Struct {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type SetPreferencesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	NewParentId   string                 `protobuf:"bytes,2,opt,name=new_parent_id,json=newParentId,proto3" json:"new_parent_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences   *structpb.Struct       `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"` // Заменяет документ целиком
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MoveFileRequest) Reset() {
-	*x = MoveFileRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[35]
+func (x *SetPreferencesRequest) Reset() {
+	*x = SetPreferencesRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MoveFileRequest) String() string {
+func (x *SetPreferencesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveFileRequest) ProtoMessage() {}
+func (*SetPreferencesRequest) ProtoMessage() {}
 
-func (x *MoveFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[35]
+func (x *SetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2310,49 +7960,48 @@ func (x *MoveFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveFileRequest.ProtoReflect.Descriptor instead.
-func (*MoveFileRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use SetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*SetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *MoveFileRequest) GetFileId() string {
+func (x *SetPreferencesRequest) GetUserId() string {
 	if x != nil {
-		return x.FileId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *MoveFileRequest) GetNewParentId() string {
+func (x *SetPreferencesRequest) GetPreferences() *structpb.Struct {
 	if x != nil {
-		return x.NewParentId
+		return x.Preferences
 	}
-	return ""
+	return nil
 }
 
-type CopyFileRequest struct {
+type PatchPreferencesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	NewParentId   string                 `protobuf:"bytes,2,opt,name=new_parent_id,json=newParentId,proto3" json:"new_parent_id,omitempty"`
-	NewName       string                 `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Patch         *structpb.Struct       `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"` // JSON Merge Patch (RFC 7396): null удаляет ключ
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CopyFileRequest) Reset() {
-	*x = CopyFileRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[36]
+func (x *PatchPreferencesRequest) Reset() {
+	*x = PatchPreferencesRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CopyFileRequest) String() string {
+func (x *PatchPreferencesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CopyFileRequest) ProtoMessage() {}
+func (*PatchPreferencesRequest) ProtoMessage() {}
 
-func (x *CopyFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[36]
+func (x *PatchPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2363,55 +8012,54 @@ func (x *CopyFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CopyFileRequest.ProtoReflect.Descriptor instead.
-func (*CopyFileRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{36}
-}
-
-func (x *CopyFileRequest) GetFileId() string {
-	if x != nil {
-		return x.FileId
-	}
-	return ""
+// Deprecated: Use PatchPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*PatchPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{121}
 }
 
-func (x *CopyFileRequest) GetNewParentId() string {
+func (x *PatchPreferencesRequest) GetUserId() string {
 	if x != nil {
-		return x.NewParentId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *CopyFileRequest) GetNewName() string {
+func (x *PatchPreferencesRequest) GetPatch() *structpb.Struct {
 	if x != nil {
-		return x.NewName
+		return x.Patch
 	}
-	return ""
+	return nil
 }
 
-type RenameFileRequest struct {
+type SecurityEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	NewName       string                 `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EventType     SecurityEventType      `protobuf:"varint,3,opt,name=event_type,json=eventType,proto3,enum=dbservice.SecurityEventType" json:"event_type,omitempty"`
+	ActorId       *string                `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3,oneof" json:"actor_id,omitempty"` // Пусто, если действие выполнено без x-actor-id
+	IpAddress     *string                `protobuf:"bytes,5,opt,name=ip_address,json=ipAddress,proto3,oneof" json:"ip_address,omitempty"`
+	UserAgent     *string                `protobuf:"bytes,6,opt,name=user_agent,json=userAgent,proto3,oneof" json:"user_agent,omitempty"`
+	Details       *structpb.Struct       `protobuf:"bytes,7,opt,name=details,proto3" json:"details,omitempty"` // Например {from, to} для смены роли
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RenameFileRequest) Reset() {
-	*x = RenameFileRequest{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[37]
+func (x *SecurityEvent) Reset() {
+	*x = SecurityEvent{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RenameFileRequest) String() string {
+func (x *SecurityEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RenameFileRequest) ProtoMessage() {}
+func (*SecurityEvent) ProtoMessage() {}
 
-func (x *RenameFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[37]
+func (x *SecurityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2422,48 +8070,91 @@ func (x *RenameFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RenameFileRequest.ProtoReflect.Descriptor instead.
-func (*RenameFileRequest) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use SecurityEvent.ProtoReflect.Descriptor instead.
+func (*SecurityEvent) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{122}
 }
 
-func (x *RenameFileRequest) GetFileId() string {
+func (x *SecurityEvent) GetId() int64 {
 	if x != nil {
-		return x.FileId
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SecurityEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *RenameFileRequest) GetNewName() string {
+func (x *SecurityEvent) GetEventType() SecurityEventType {
 	if x != nil {
-		return x.NewName
+		return x.EventType
+	}
+	return SecurityEventType_SECURITY_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *SecurityEvent) GetActorId() string {
+	if x != nil && x.ActorId != nil {
+		return *x.ActorId
 	}
 	return ""
 }
 
-// File integrity operations
-type IntegrityResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	IsIntegrityVerified bool                   `protobuf:"varint,1,opt,name=is_integrity_verified,json=isIntegrityVerified,proto3" json:"is_integrity_verified,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *SecurityEvent) GetIpAddress() string {
+	if x != nil && x.IpAddress != nil {
+		return *x.IpAddress
+	}
+	return ""
 }
 
-func (x *IntegrityResponse) Reset() {
-	*x = IntegrityResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[38]
+func (x *SecurityEvent) GetUserAgent() string {
+	if x != nil && x.UserAgent != nil {
+		return *x.UserAgent
+	}
+	return ""
+}
+
+func (x *SecurityEvent) GetDetails() *structpb.Struct {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *SecurityEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListSecurityEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // По умолчанию 50, не больше 500
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token предыдущей страницы
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsRequest) Reset() {
+	*x = ListSecurityEventsRequest{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IntegrityResponse) String() string {
+func (x *ListSecurityEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IntegrityResponse) ProtoMessage() {}
+func (*ListSecurityEventsRequest) ProtoMessage() {}
 
-func (x *IntegrityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[38]
+func (x *ListSecurityEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2474,40 +8165,55 @@ func (x *IntegrityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IntegrityResponse.ProtoReflect.Descriptor instead.
-func (*IntegrityResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use ListSecurityEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{123}
 }
 
-func (x *IntegrityResponse) GetIsIntegrityVerified() bool {
+func (x *ListSecurityEventsRequest) GetUserId() string {
 	if x != nil {
-		return x.IsIntegrityVerified
+		return x.UserId
 	}
-	return false
+	return ""
 }
 
-type ChecksumsResponse struct {
+func (x *ListSecurityEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSecurityEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSecurityEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Checksums     map[string]string      `protobuf:"bytes,1,rep,name=checksums,proto3" json:"checksums,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Events        []*SecurityEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`                                      // От новых к старым
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Пусто на последней странице
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChecksumsResponse) Reset() {
-	*x = ChecksumsResponse{}
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[39]
+func (x *ListSecurityEventsResponse) Reset() {
+	*x = ListSecurityEventsResponse{}
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChecksumsResponse) String() string {
+func (x *ListSecurityEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChecksumsResponse) ProtoMessage() {}
+func (*ListSecurityEventsResponse) ProtoMessage() {}
 
-func (x *ChecksumsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[39]
+func (x *ListSecurityEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_transport_grpc_protos_db_manager_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2518,23 +8224,30 @@ func (x *ChecksumsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChecksumsResponse.ProtoReflect.Descriptor instead.
-func (*ChecksumsResponse) Descriptor() ([]byte, []int) {
-	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use ListSecurityEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *ChecksumsResponse) GetChecksums() map[string]string {
+func (x *ListSecurityEventsResponse) GetEvents() []*SecurityEvent {
 	if x != nil {
-		return x.Checksums
+		return x.Events
 	}
 	return nil
 }
 
+func (x *ListSecurityEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_internal_transport_grpc_protos_db_manager_proto protoreflect.FileDescriptor
 
 const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"\n" +
-	"/internal/transport/grpc/protos/db_manager.proto\x12\tdbservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xb2\x04\n" +
+	"/internal/transport/grpc/protos/db_manager.proto\x12\tdbservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xb2\x04\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
@@ -2581,7 +8294,11 @@ const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"\busername\x18\x01 \x01(\tR\busername\"L\n" +
 	"\x15UpdatePasswordRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
-	"\rpassword_hash\x18\x02 \x01(\tR\fpasswordHash\"C\n" +
+	"\rpassword_hash\x18\x02 \x01(\tR\fpasswordHash\"u\n" +
+	"\x11UpdateUserRequest\x12#\n" +
+	"\x04user\x18\x01 \x01(\v2\x0f.dbservice.UserR\x04user\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"C\n" +
 	"\x15UpdateUsernameRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\"Q\n" +
@@ -2594,7 +8311,31 @@ const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"\battempts\x18\x02 \x01(\x05R\battempts\"i\n" +
 	"\x18UpdateLockedUntilRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
-	"\flocked_until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vlockedUntil\"J\n" +
+	"\flocked_until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vlockedUntil\"\x97\x01\n" +
+	"\n" +
+	"LoginState\x122\n" +
+	"\x15failed_login_attempts\x18\x01 \x01(\x05R\x13failedLoginAttempts\x12\x16\n" +
+	"\x06locked\x18\x02 \x01(\bR\x06locked\x12=\n" +
+	"\flocked_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlockedUntil\"\xbb\x01\n" +
+	"\x11IssueTokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x125\n" +
+	"\apurpose\x18\x02 \x01(\x0e2\x1b.dbservice.UserTokenPurposeR\apurpose\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tnew_email\x18\x04 \x01(\tR\bnewEmail\"u\n" +
+	"\x12IssueTokenResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x8e\x01\n" +
+	"\x13ConsumeTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x125\n" +
+	"\apurpose\x18\x02 \x01(\x0e2\x1b.dbservice.UserTokenPurposeR\apurpose\x12*\n" +
+	"\x11new_password_hash\x18\x03 \x01(\tR\x0fnewPasswordHash\"\x83\x01\n" +
+	"\x14ConsumeTokenResponse\x125\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1d.dbservice.ConsumeTokenStatusR\x06status\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tnew_email\x18\x03 \x01(\tR\bnewEmail\"J\n" +
 	"\x19UpdateStorageUsageRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -2665,13 +8406,17 @@ const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\"&\n" +
 	"\x10FileSizeResponse\x12\x12\n" +
-	"\x04size\x18\x01 \x01(\x03R\x04size\";\n" +
+	"\x04size\x18\x01 \x01(\x03R\x04size\"u\n" +
+	"\x11UpdateFileRequest\x12#\n" +
+	"\x04file\x18\x01 \x01(\v2\x0f.dbservice.FileR\x04file\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\";\n" +
 	"\x15UpdateFileSizeRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04size\x18\x02 \x01(\x03R\x04size\"H\n" +
 	"\x12GetFileTreeRequest\x12\x19\n" +
 	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x17\n" +
-	"\aroot_id\x18\x02 \x01(\tR\x06rootId\"\xa3\x02\n" +
+	"\aroot_id\x18\x02 \x01(\tR\x06rootId\"\x84\x03\n" +
 	"\fFileRevision\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x1f\n" +
@@ -2683,7 +8428,11 @@ const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12!\n" +
 	"\fstorage_path\x18\a \x01(\tR\vstoragePath\x12\x1b\n" +
 	"\tmime_type\x18\b \x01(\tR\bmimeType\x12\x17\n" +
-	"\auser_id\x18\t \x01(\tR\x06userId\"\x1c\n" +
+	"\auser_id\x18\t \x01(\tR\x06userId\x12\x14\n" +
+	"\x05label\x18\n" +
+	" \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\x12'\n" +
+	"\x0fsha256_checksum\x18\f \x01(\tR\x0esha256Checksum\"\x1c\n" +
 	"\n" +
 	"RevisionID\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"N\n" +
@@ -2692,28 +8441,96 @@ const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"\x12GetRevisionRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1f\n" +
 	"\vrevision_id\x18\x02 \x01(\x03R\n" +
-	"revisionId\"\xeb\x01\n" +
+	"revisionId\"\x8b\x01\n" +
+	"\x17SetRevisionLabelRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1f\n" +
+	"\vrevision_id\x18\x02 \x01(\x03R\n" +
+	"revisionId\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"J\n" +
+	"\x19GetRevisionByLabelRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\"Q\n" +
+	"\x15PruneRevisionsRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1f\n" +
+	"\vkeep_latest\x18\x02 \x01(\x05R\n" +
+	"keepLatest\"\xeb\x03\n" +
 	"\x0eFilePermission\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x1d\n" +
 	"\n" +
-	"grantee_id\x18\x03 \x01(\tR\tgranteeId\x12!\n" +
-	"\fgrantee_type\x18\x04 \x01(\tR\vgranteeType\x12\x12\n" +
-	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1f\n" +
+	"grantee_id\x18\x03 \x01(\tR\tgranteeId\x12\x1f\n" +
 	"\vallow_share\x18\x06 \x01(\bR\n" +
 	"allowShare\x129\n" +
 	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x1e\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1c\n" +
+	"\tinherited\x18\b \x01(\bR\tinherited\x12%\n" +
+	"\x0einherited_from\x18\t \x01(\tR\rinheritedFrom\x12\x16\n" +
+	"\x06domain\x18\n" +
+	" \x01(\tR\x06domain\x129\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\fgrantee_kind\x18\f \x01(\x0e2\x16.dbservice.GranteeTypeR\vgranteeKind\x12B\n" +
+	"\x0fpermission_role\x18\r \x01(\x0e2\x19.dbservice.PermissionRoleR\x0epermissionRoleJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06R\fgrantee_typeR\x04role\"\x1e\n" +
 	"\fPermissionID\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"V\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"z\n" +
+	"\x17CreatePermissionRequest\x129\n" +
+	"\n" +
+	"permission\x18\x01 \x01(\v2\x19.dbservice.FilePermissionR\n" +
+	"permission\x12$\n" +
+	"\x0eacting_user_id\x18\x02 \x01(\tR\factingUserId\"z\n" +
+	"\x17UpdatePermissionRequest\x129\n" +
+	"\n" +
+	"permission\x18\x01 \x01(\v2\x19.dbservice.FilePermissionR\n" +
+	"permission\x12$\n" +
+	"\x0eacting_user_id\x18\x02 \x01(\tR\factingUserId\"O\n" +
+	"\x17DeletePermissionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\x0eacting_user_id\x18\x02 \x01(\tR\factingUserId\"V\n" +
 	"\x17ListPermissionsResponse\x12;\n" +
-	"\vpermissions\x18\x01 \x03(\v2\x19.dbservice.FilePermissionR\vpermissions\"o\n" +
+	"\vpermissions\x18\x01 \x03(\v2\x19.dbservice.FilePermissionR\vpermissions\"\x9d\x01\n" +
 	"\x16CheckPermissionRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12#\n" +
-	"\rrequired_role\x18\x03 \x01(\tR\frequiredRole\";\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12<\n" +
+	"\fminimum_role\x18\x04 \x01(\x0e2\x19.dbservice.PermissionRoleR\vminimumRoleJ\x04\b\x03\x10\x04R\rrequired_role\";\n" +
 	"\x12PermissionResponse\x12%\n" +
-	"\x0ehas_permission\x18\x01 \x01(\bR\rhasPermission\"P\n" +
+	"\x0ehas_permission\x18\x01 \x01(\bR\rhasPermission\"\x8b\x01\n" +
+	"\x17CheckPermissionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bfile_ids\x18\x02 \x03(\tR\afileIds\x12<\n" +
+	"\fminimum_role\x18\x03 \x01(\x0e2\x19.dbservice.PermissionRoleR\vminimumRole\"\xb2\x01\n" +
+	"\x18CheckPermissionsResponse\x12V\n" +
+	"\vpermissions\x18\x01 \x03(\v24.dbservice.CheckPermissionsResponse.PermissionsEntryR\vpermissions\x1a>\n" +
+	"\x10PermissionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"u\n" +
+	"\x1dGetEffectivePermissionRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\"\n" +
+	"\rshare_link_id\x18\x03 \x01(\tR\vshareLinkId\"\xfc\x02\n" +
+	"\x10PermissionSource\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12-\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x19.dbservice.PermissionRoleR\x04role\x12\x1f\n" +
+	"\vallow_share\x18\x03 \x01(\bR\n" +
+	"allowShare\x12#\n" +
+	"\rpermission_id\x18\x04 \x01(\tR\fpermissionId\x12\x17\n" +
+	"\afile_id\x18\x05 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfile_name\x18\x06 \x01(\tR\bfileName\x12\x1c\n" +
+	"\tinherited\x18\a \x01(\bR\tinherited\x12\x19\n" +
+	"\bgroup_id\x18\b \x01(\tR\agroupId\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\t \x01(\tR\tgroupName\x12\x16\n" +
+	"\x06domain\x18\n" +
+	" \x01(\tR\x06domain\x129\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xce\x01\n" +
+	"\x13EffectivePermission\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x19.dbservice.PermissionRoleR\x04role\x12\x1f\n" +
+	"\vallow_share\x18\x04 \x01(\bR\n" +
+	"allowShare\x125\n" +
+	"\asources\x18\x05 \x03(\v2\x1b.dbservice.PermissionSourceR\asources\"P\n" +
 	"\x19UpdateFileMetadataRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1a\n" +
 	"\bmetadata\x18\x02 \x01(\tR\bmetadata\"2\n" +
@@ -2728,37 +8545,445 @@ const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"\bnew_name\x18\x03 \x01(\tR\anewName\"G\n" +
 	"\x11RenameFileRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x19\n" +
-	"\bnew_name\x18\x02 \x01(\tR\anewName\"G\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\"\xa3\x01\n" +
+	"\x18TransferOwnershipRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12 \n" +
+	"\fnew_owner_id\x18\x02 \x01(\tR\n" +
+	"newOwnerId\x12L\n" +
+	"\x15keep_previous_as_role\x18\x03 \x01(\x0e2\x19.dbservice.PermissionRoleR\x12keepPreviousAsRole\"\xa1\x01\n" +
+	"\x19TransferOwnershipResponse\x12*\n" +
+	"\x11previous_owner_id\x18\x01 \x01(\tR\x0fpreviousOwnerId\x12+\n" +
+	"\x11files_transferred\x18\x02 \x01(\x05R\x10filesTransferred\x12+\n" +
+	"\x11bytes_transferred\x18\x03 \x01(\x03R\x10bytesTransferred\"G\n" +
 	"\x11IntegrityResponse\x122\n" +
 	"\x15is_integrity_verified\x18\x01 \x01(\bR\x13isIntegrityVerified\"\x9c\x01\n" +
 	"\x11ChecksumsResponse\x12I\n" +
 	"\tchecksums\x18\x01 \x03(\v2+.dbservice.ChecksumsResponse.ChecksumsEntryR\tchecksums\x1a<\n" +
 	"\x0eChecksumsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xf5\x1a\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x02\n" +
+	"\vStorageBlob\x12!\n" +
+	"\fstorage_path\x18\x01 \x01(\tR\vstoragePath\x12'\n" +
+	"\x0fsha256_checksum\x18\x02 \x01(\tR\x0esha256Checksum\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x1b\n" +
+	"\tref_count\x18\x04 \x01(\x03R\brefCount\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vreleased_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"releasedAt\"Y\n" +
+	"\x13FindBySHA256Request\x12'\n" +
+	"\x0fsha256_checksum\x18\x01 \x01(\tR\x0esha256Checksum\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\"2\n" +
+	"\x15FindDuplicatesRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\"\x97\x01\n" +
+	"\x0eDuplicateGroup\x12'\n" +
+	"\x0fsha256_checksum\x18\x01 \x01(\tR\x0esha256Checksum\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12%\n" +
+	"\x05files\x18\x03 \x03(\v2\x0f.dbservice.FileR\x05files\x12!\n" +
+	"\fwasted_bytes\x18\x04 \x01(\x03R\vwastedBytes\"y\n" +
+	"\x16FindDuplicatesResponse\x121\n" +
+	"\x06groups\x18\x01 \x03(\v2\x19.dbservice.DuplicateGroupR\x06groups\x12,\n" +
+	"\x12total_wasted_bytes\x18\x02 \x01(\x03R\x10totalWastedBytes\"7\n" +
+	"\x12StoragePathRequest\x12!\n" +
+	"\fstorage_path\x18\x01 \x01(\tR\vstoragePath\"4\n" +
+	"\x1cListUnreferencedBlobsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"H\n" +
+	"\x18ListStorageBlobsResponse\x12,\n" +
+	"\x05blobs\x18\x01 \x03(\v2\x16.dbservice.StorageBlobR\x05blobs\"1\n" +
+	"\x13ReleaseBlobResponse\x12\x1a\n" +
+	"\breleased\x18\x01 \x01(\bR\breleased\"\xe2\x01\n" +
+	"\x05Group\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x19\n" +
+	"\aGroupID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"b\n" +
+	"\x12UpdateGroupRequest\x12&\n" +
+	"\x05group\x18\x01 \x01(\v2\x10.dbservice.GroupR\x05group\x12$\n" +
+	"\x0eacting_user_id\x18\x02 \x01(\tR\factingUserId\"J\n" +
+	"\x12DeleteGroupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\x0eacting_user_id\x18\x02 \x01(\tR\factingUserId\",\n" +
+	"\x11ListGroupsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\">\n" +
+	"\x12ListGroupsResponse\x12(\n" +
+	"\x06groups\x18\x01 \x03(\v2\x10.dbservice.GroupR\x06groups\"\xb5\x01\n" +
+	"\vGroupMember\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\x12\x1f\n" +
+	"\vmember_type\x18\x03 \x01(\tR\n" +
+	"memberType\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"m\n" +
+	"\x15AddGroupMemberRequest\x12.\n" +
+	"\x06member\x18\x01 \x01(\v2\x16.dbservice.GroupMemberR\x06member\x12$\n" +
+	"\x0eacting_user_id\x18\x02 \x01(\tR\factingUserId\"\x99\x01\n" +
+	"\x18RemoveGroupMemberRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\x12\x1f\n" +
+	"\vmember_type\x18\x03 \x01(\tR\n" +
+	"memberType\x12$\n" +
+	"\x0eacting_user_id\x18\x04 \x01(\tR\factingUserId\"L\n" +
+	"\x18ListGroupMembersResponse\x120\n" +
+	"\amembers\x18\x01 \x03(\v2\x16.dbservice.GroupMemberR\amembers\"\x8b\x04\n" +
+	"\tShareLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12-\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x19.dbservice.PermissionRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12#\n" +
+	"\rpassword_hash\x18\a \x01(\tR\fpasswordHash\x12\x19\n" +
+	"\bmax_uses\x18\b \x01(\x05R\amaxUses\x12\x1b\n" +
+	"\tuse_count\x18\t \x01(\x05R\buseCount\x12<\n" +
+	"\flast_used_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x12\x18\n" +
+	"\arevoked\x18\v \x01(\bR\arevoked\x129\n" +
+	"\n" +
+	"revoked_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12!\n" +
+	"\fhas_password\x18\r \x01(\bR\vhasPassword\"\x1d\n" +
+	"\vShareLinkID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x17CreateShareLinkResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"D\n" +
+	"\x16ListShareLinksResponse\x12*\n" +
+	"\x05links\x18\x01 \x03(\v2\x14.dbservice.ShareLinkR\x05links\"\\\n" +
+	"\x17ResolveShareLinkRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12+\n" +
+	"\x11password_verified\x18\x02 \x01(\bR\x10passwordVerified\"\xd4\x01\n" +
+	"\x18ResolveShareLinkResponse\x122\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1a.dbservice.ShareLinkStatusR\x06status\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12-\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x19.dbservice.PermissionRoleR\x04role\x12\x17\n" +
+	"\alink_id\x18\x04 \x01(\tR\x06linkId\x12#\n" +
+	"\rpassword_hash\x18\x05 \x01(\tR\fpasswordHash\"\xc4\x03\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tfamily_id\x18\x02 \x01(\tR\bfamilyId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vdevice_name\x18\x04 \x01(\tR\n" +
+	"deviceName\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x05 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x06 \x01(\tR\tuserAgent\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12%\n" +
+	"\x0erevoked_reason\x18\v \x01(\tR\rrevokedReason\"\x1b\n" +
+	"\tSessionID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc9\x01\n" +
+	"\x14CreateSessionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vdevice_name\x18\x02 \x01(\tR\n" +
+	"deviceName\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x04 \x01(\tR\tuserAgent\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"i\n" +
+	"\x14SessionTokenResponse\x12,\n" +
+	"\asession\x18\x01 \x01(\v2\x12.dbservice.SessionR\asession\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"y\n" +
+	"\x14RotateSessionRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x02 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\"\xa2\x01\n" +
+	"\x15RotateSessionResponse\x126\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1e.dbservice.RotateSessionStatusR\x06status\x12,\n" +
+	"\asession\x18\x02 \x01(\v2\x12.dbservice.SessionR\asession\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"F\n" +
+	"\x14ListSessionsResponse\x12.\n" +
+	"\bsessions\x18\x01 \x03(\v2\x12.dbservice.SessionR\bsessions\"_\n" +
+	"\x18RevokeAllSessionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12*\n" +
+	"\x11except_session_id\x18\x02 \x01(\tR\x0fexceptSessionId\"5\n" +
+	"\x19RevokeAllSessionsResponse\x12\x18\n" +
+	"\arevoked\x18\x01 \x01(\x05R\arevoked\"R\n" +
+	"\x17EnrollTwoFactorResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_url\x18\x02 \x01(\tR\n" +
+	"otpauthUrl\"C\n" +
+	"\x14TwoFactorCodeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"_\n" +
+	"\x18ConfirmTwoFactorResponse\x12\x1c\n" +
+	"\tconfirmed\x18\x01 \x01(\bR\tconfirmed\x12%\n" +
+	"\x0erecovery_codes\x18\x02 \x03(\tR\rrecoveryCodes\"/\n" +
+	"\x17VerifyTwoFactorResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\"Q\n" +
+	"\x1bConsumeRecoveryCodeResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x1c\n" +
+	"\tremaining\x18\x02 \x01(\x05R\tremaining\"^\n" +
+	"\x1fRegenerateRecoveryCodesResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12%\n" +
+	"\x0erecovery_codes\x18\x02 \x03(\tR\rrecoveryCodes\"\xcd\x02\n" +
+	"\x12WebAuthnCredential\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12#\n" +
+	"\rcredential_id\x18\x03 \x01(\fR\fcredentialId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x04 \x01(\fR\tpublicKey\x12\x1d\n" +
+	"\n" +
+	"sign_count\x18\x05 \x01(\rR\tsignCount\x12\x1e\n" +
+	"\n" +
+	"transports\x18\x06 \x03(\tR\n" +
+	"transports\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\"&\n" +
+	"\x14WebAuthnCredentialID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"C\n" +
+	"\x1cGetWebAuthnCredentialRequest\x12#\n" +
+	"\rcredential_id\x18\x01 \x01(\fR\fcredentialId\"b\n" +
+	"\x1fListWebAuthnCredentialsResponse\x12?\n" +
+	"\vcredentials\x18\x01 \x03(\v2\x1d.dbservice.WebAuthnCredentialR\vcredentials\"E\n" +
+	"\x1fRenameWebAuthnCredentialRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"d\n" +
+	"\x1eUpdateWebAuthnSignCountRequest\x12#\n" +
+	"\rcredential_id\x18\x01 \x01(\fR\fcredentialId\x12\x1d\n" +
+	"\n" +
+	"sign_count\x18\x02 \x01(\rR\tsignCount\"\x9c\x01\n" +
+	"\x1fUpdateWebAuthnSignCountResponse\x12:\n" +
+	"\x06status\x18\x01 \x01(\x0e2\".dbservice.WebAuthnSignCountStatusR\x06status\x12=\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\v2\x1d.dbservice.WebAuthnCredentialR\n" +
+	"credential\"\xac\x01\n" +
+	"\fUserIdentity\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x127\n" +
+	"\tlinked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blinkedAt\"C\n" +
+	"\vIdentityKey\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\"f\n" +
+	"\x15UnlinkIdentityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\"Q\n" +
+	"\x16ListIdentitiesResponse\x127\n" +
+	"\n" +
+	"identities\x18\x01 \x03(\v2\x17.dbservice.UserIdentityR\n" +
+	"identities\"\xaa\x01\n" +
+	"\x0fAccountDeletion\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12A\n" +
+	"\x0edeactivated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rdeactivatedAt\x12;\n" +
+	"\verase_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"eraseAfter\"2\n" +
+	"\x1aListPendingErasuresRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"W\n" +
+	"\x1bListPendingErasuresResponse\x128\n" +
+	"\tdeletions\x18\x01 \x03(\v2\x1a.dbservice.AccountDeletionR\tdeletions\"\xd1\x02\n" +
+	"\rErasureResult\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rfiles_deleted\x18\x02 \x01(\x05R\ffilesDeleted\x12+\n" +
+	"\x11revisions_deleted\x18\x03 \x01(\x05R\x10revisionsDeleted\x12/\n" +
+	"\x13permissions_deleted\x18\x04 \x01(\x05R\x12permissionsDeleted\x12)\n" +
+	"\x10sessions_deleted\x18\x05 \x01(\x05R\x0fsessionsDeleted\x12#\n" +
+	"\rstorage_paths\x18\x06 \x03(\tR\fstoragePaths\x12-\n" +
+	"\x12groups_transferred\x18\a \x01(\x05R\x11groupsTransferred\x12%\n" +
+	"\x0egroups_deleted\x18\b \x01(\x05R\rgroupsDeleted\"\x81\x06\n" +
+	"\x10ListUsersRequest\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12 \n" +
+	"\tis_active\x18\x02 \x01(\bH\x00R\bisActive\x88\x01\x01\x12/\n" +
+	"\x11is_email_verified\x18\x03 \x01(\bH\x01R\x0fisEmailVerified\x88\x01\x01\x12 \n" +
+	"\tis_locked\x18\x04 \x01(\bH\x02R\bisLocked\x88\x01\x01\x12/\n" +
+	"\x11is_quota_exceeded\x18\x05 \x01(\bH\x03R\x0fisQuotaExceeded\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12D\n" +
+	"\x10last_login_after\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0elastLoginAfter\x12F\n" +
+	"\x11last_login_before\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0flastLoginBefore\x12\x16\n" +
+	"\x06search\x18\n" +
+	" \x01(\tR\x06search\x121\n" +
+	"\asort_by\x18\v \x01(\x0e2\x18.dbservice.UserSortFieldR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\f \x01(\bR\n" +
+	"descending\x12\x1b\n" +
+	"\tpage_size\x18\r \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x0e \x01(\tR\tpageToken\x122\n" +
+	"\x15include_extended_info\x18\x0f \x01(\bR\x13includeExtendedInfoB\f\n" +
+	"\n" +
+	"_is_activeB\x14\n" +
+	"\x12_is_email_verifiedB\f\n" +
+	"\n" +
+	"_is_lockedB\x14\n" +
+	"\x12_is_quota_exceeded\"\xba\x01\n" +
+	"\x11ListUsersResponse\x12%\n" +
+	"\x05users\x18\x01 \x03(\v2\x0f.dbservice.UserR\x05users\x12@\n" +
+	"\rextended_info\x18\x02 \x03(\v2\x1b.dbservice.UserExtendedInfoR\fextendedInfo\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"v\n" +
+	"\x14PasswordHistoryEntry\x12#\n" +
+	"\rpassword_hash\x18\x01 \x01(\tR\fpasswordHash\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"j\n" +
+	"\x17PasswordHistoryResponse\x129\n" +
+	"\aentries\x18\x01 \x03(\v2\x1f.dbservice.PasswordHistoryEntryR\aentries\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"a\n" +
+	"\vPreferences\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x17.google.protobuf.StructR\vpreferences\"k\n" +
+	"\x15SetPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x17.google.protobuf.StructR\vpreferences\"a\n" +
+	"\x17PatchPreferencesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12-\n" +
+	"\x05patch\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05patch\"\xf6\x02\n" +
+	"\rSecurityEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12;\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\x0e2\x1c.dbservice.SecurityEventTypeR\teventType\x12\x1e\n" +
+	"\bactor_id\x18\x04 \x01(\tH\x00R\aactorId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"ip_address\x18\x05 \x01(\tH\x01R\tipAddress\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"user_agent\x18\x06 \x01(\tH\x02R\tuserAgent\x88\x01\x01\x121\n" +
+	"\adetails\x18\a \x01(\v2\x17.google.protobuf.StructR\adetails\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\v\n" +
+	"\t_actor_idB\r\n" +
+	"\v_ip_addressB\r\n" +
+	"\v_user_agent\"p\n" +
+	"\x19ListSecurityEventsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"v\n" +
+	"\x1aListSecurityEventsResponse\x120\n" +
+	"\x06events\x18\x01 \x03(\v2\x18.dbservice.SecurityEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xad\x01\n" +
+	"\x10UserTokenPurpose\x12\"\n" +
+	"\x1eUSER_TOKEN_PURPOSE_UNSPECIFIED\x10\x00\x12)\n" +
+	"%USER_TOKEN_PURPOSE_EMAIL_VERIFICATION\x10\x01\x12%\n" +
+	"!USER_TOKEN_PURPOSE_PASSWORD_RESET\x10\x02\x12#\n" +
+	"\x1fUSER_TOKEN_PURPOSE_EMAIL_CHANGE\x10\x03*\xca\x01\n" +
+	"\x12ConsumeTokenStatus\x12$\n" +
+	" CONSUME_TOKEN_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dCONSUME_TOKEN_STATUS_CONSUMED\x10\x01\x12\"\n" +
+	"\x1eCONSUME_TOKEN_STATUS_NOT_FOUND\x10\x02\x12 \n" +
+	"\x1cCONSUME_TOKEN_STATUS_EXPIRED\x10\x03\x12%\n" +
+	"!CONSUME_TOKEN_STATUS_ALREADY_USED\x10\x04*\xe2\x01\n" +
+	"\x0ePermissionRole\x12\x1f\n" +
+	"\x1bPERMISSION_ROLE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16PERMISSION_ROLE_READER\x10\x01\x12\x1d\n" +
+	"\x19PERMISSION_ROLE_COMMENTER\x10\x02\x12\x1a\n" +
+	"\x16PERMISSION_ROLE_WRITER\x10\x03\x12\x1e\n" +
+	"\x1aPERMISSION_ROLE_FILE_OWNER\x10\x04\x12\x1d\n" +
+	"\x19PERMISSION_ROLE_ORGANIZER\x10\x05\x12\x19\n" +
+	"\x15PERMISSION_ROLE_OWNER\x10\x06*\x8c\x01\n" +
+	"\vGranteeType\x12\x1c\n" +
+	"\x18GRANTEE_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11GRANTEE_TYPE_USER\x10\x01\x12\x16\n" +
+	"\x12GRANTEE_TYPE_GROUP\x10\x02\x12\x17\n" +
+	"\x13GRANTEE_TYPE_DOMAIN\x10\x03\x12\x17\n" +
+	"\x13GRANTEE_TYPE_ANYONE\x10\x04*\xaa\x02\n" +
+	"\x0fShareLinkStatus\x12!\n" +
+	"\x1dSHARE_LINK_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SHARE_LINK_STATUS_VALID\x10\x01\x12\x1f\n" +
+	"\x1bSHARE_LINK_STATUS_NOT_FOUND\x10\x02\x12\x1d\n" +
+	"\x19SHARE_LINK_STATUS_REVOKED\x10\x03\x12\x1d\n" +
+	"\x19SHARE_LINK_STATUS_EXPIRED\x10\x04\x12'\n" +
+	"#SHARE_LINK_STATUS_USE_LIMIT_REACHED\x10\x05\x12'\n" +
+	"#SHARE_LINK_STATUS_PASSWORD_REQUIRED\x10\x06\x12&\n" +
+	"\"SHARE_LINK_STATUS_FILE_UNAVAILABLE\x10\a*\xf4\x01\n" +
+	"\x13RotateSessionStatus\x12%\n" +
+	"!ROTATE_SESSION_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dROTATE_SESSION_STATUS_ROTATED\x10\x01\x12#\n" +
+	"\x1fROTATE_SESSION_STATUS_NOT_FOUND\x10\x02\x12!\n" +
+	"\x1dROTATE_SESSION_STATUS_EXPIRED\x10\x03\x12!\n" +
+	"\x1dROTATE_SESSION_STATUS_REVOKED\x10\x04\x12(\n" +
+	"$ROTATE_SESSION_STATUS_REUSE_DETECTED\x10\x05*\xca\x01\n" +
+	"\x17WebAuthnSignCountStatus\x12+\n" +
+	"'WEB_AUTHN_SIGN_COUNT_STATUS_UNSPECIFIED\x10\x00\x12'\n" +
+	"#WEB_AUTHN_SIGN_COUNT_STATUS_UPDATED\x10\x01\x12)\n" +
+	"%WEB_AUTHN_SIGN_COUNT_STATUS_NOT_FOUND\x10\x02\x12.\n" +
+	"*WEB_AUTHN_SIGN_COUNT_STATUS_CLONE_DETECTED\x10\x03*\xcc\x01\n" +
+	"\rUserSortField\x12\x1f\n" +
+	"\x1bUSER_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aUSER_SORT_FIELD_CREATED_AT\x10\x01\x12!\n" +
+	"\x1dUSER_SORT_FIELD_LAST_LOGIN_AT\x10\x02\x12\x19\n" +
+	"\x15USER_SORT_FIELD_EMAIL\x10\x03\x12\x1c\n" +
+	"\x18USER_SORT_FIELD_USERNAME\x10\x04\x12\x1e\n" +
+	"\x1aUSER_SORT_FIELD_USED_SPACE\x10\x05*\xa2\x04\n" +
+	"\x11SecurityEventType\x12#\n" +
+	"\x1fSECURITY_EVENT_TYPE_UNSPECIFIED\x10\x00\x12(\n" +
+	"$SECURITY_EVENT_TYPE_PASSWORD_CHANGED\x10\x01\x12&\n" +
+	"\"SECURITY_EVENT_TYPE_EMAIL_VERIFIED\x10\x02\x12(\n" +
+	"$SECURITY_EVENT_TYPE_EMAIL_UNVERIFIED\x10\x03\x12&\n" +
+	"\"SECURITY_EVENT_TYPE_ACCOUNT_LOCKED\x10\x04\x12(\n" +
+	"$SECURITY_EVENT_TYPE_ACCOUNT_UNLOCKED\x10\x05\x12$\n" +
+	" SECURITY_EVENT_TYPE_ROLE_CHANGED\x10\x06\x12)\n" +
+	"%SECURITY_EVENT_TYPE_ACCOUNT_ACTIVATED\x10\a\x12+\n" +
+	"'SECURITY_EVENT_TYPE_ACCOUNT_DEACTIVATED\x10\b\x12'\n" +
+	"#SECURITY_EVENT_TYPE_LOGIN_SUCCEEDED\x10\t\x12$\n" +
+	" SECURITY_EVENT_TYPE_LOGIN_FAILED\x10\n" +
+	"\x12%\n" +
+	"!SECURITY_EVENT_TYPE_LOGIN_BLOCKED\x10\v\x12&\n" +
+	"\"SECURITY_EVENT_TYPE_ACCOUNT_ERASED\x10\f2\xbd@\n" +
 	"\tDBService\x122\n" +
 	"\n" +
 	"CreateUser\x12\x0f.dbservice.User\x1a\x11.dbservice.UserID\"\x00\x123\n" +
 	"\vGetUserByID\x12\x11.dbservice.UserID\x1a\x0f.dbservice.User\"\x00\x12<\n" +
-	"\x0eGetUserByEmail\x12\x17.dbservice.EmailRequest\x1a\x0f.dbservice.User\"\x00\x12G\n" +
-	"\x13GetUserExtendedInfo\x12\x11.dbservice.UserID\x1a\x1b.dbservice.UserExtendedInfo\"\x00\x127\n" +
+	"\x0eGetUserByEmail\x12\x17.dbservice.EmailRequest\x1a\x0f.dbservice.User\"\x00\x12H\n" +
+	"\tListUsers\x12\x1b.dbservice.ListUsersRequest\x1a\x1c.dbservice.ListUsersResponse\"\x00\x12G\n" +
+	"\x13GetUserExtendedInfo\x12\x11.dbservice.UserID\x1a\x1b.dbservice.UserExtendedInfo\"\x00\x12D\n" +
 	"\n" +
-	"UpdateUser\x12\x0f.dbservice.User\x1a\x16.google.protobuf.Empty\"\x00\x12L\n" +
-	"\x0eUpdatePassword\x12 .dbservice.UpdatePasswordRequest\x1a\x16.google.protobuf.Empty\"\x00\x12L\n" +
+	"UpdateUser\x12\x1c.dbservice.UpdateUserRequest\x1a\x16.google.protobuf.Empty\"\x00\x12L\n" +
+	"\x0eUpdatePassword\x12 .dbservice.UpdatePasswordRequest\x1a\x16.google.protobuf.Empty\"\x00\x12M\n" +
+	"\x12GetPasswordHistory\x12\x11.dbservice.UserID\x1a\".dbservice.PasswordHistoryResponse\"\x00\x12=\n" +
+	"\x0eGetPreferences\x12\x11.dbservice.UserID\x1a\x16.dbservice.Preferences\"\x00\x12L\n" +
+	"\x0eSetPreferences\x12 .dbservice.SetPreferencesRequest\x1a\x16.dbservice.Preferences\"\x00\x12P\n" +
+	"\x10PatchPreferences\x12\".dbservice.PatchPreferencesRequest\x1a\x16.dbservice.Preferences\"\x00\x12L\n" +
 	"\x0eUpdateUsername\x12 .dbservice.UpdateUsernameRequest\x1a\x16.google.protobuf.Empty\"\x00\x12^\n" +
 	"\x17UpdateEmailVerification\x12).dbservice.UpdateEmailVerificationRequest\x1a\x16.google.protobuf.Empty\"\x00\x12>\n" +
 	"\x0fUpdateLastLogin\x12\x11.dbservice.UserID\x1a\x16.google.protobuf.Empty\"\x00\x12b\n" +
 	"\x19UpdateFailedLoginAttempts\x12+.dbservice.UpdateFailedLoginAttemptsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12R\n" +
-	"\x11UpdateLockedUntil\x12#.dbservice.UpdateLockedUntilRequest\x1a\x16.google.protobuf.Empty\"\x00\x12T\n" +
+	"\x11UpdateLockedUntil\x12#.dbservice.UpdateLockedUntilRequest\x1a\x16.google.protobuf.Empty\"\x00\x12?\n" +
+	"\x11RecordFailedLogin\x12\x11.dbservice.UserID\x1a\x15.dbservice.LoginState\"\x00\x12C\n" +
+	"\x15RecordSuccessfulLogin\x12\x11.dbservice.UserID\x1a\x15.dbservice.LoginState\"\x00\x12K\n" +
+	"\n" +
+	"IssueToken\x12\x1c.dbservice.IssueTokenRequest\x1a\x1d.dbservice.IssueTokenResponse\"\x00\x12Q\n" +
+	"\fConsumeToken\x12\x1e.dbservice.ConsumeTokenRequest\x1a\x1f.dbservice.ConsumeTokenResponse\"\x00\x12A\n" +
+	"\x0eDeactivateUser\x12\x11.dbservice.UserID\x1a\x1a.dbservice.AccountDeletion\"\x00\x12A\n" +
+	"\x12CancelUserDeletion\x12\x11.dbservice.UserID\x1a\x16.google.protobuf.Empty\"\x00\x12f\n" +
+	"\x13ListPendingErasures\x12%.dbservice.ListPendingErasuresRequest\x1a&.dbservice.ListPendingErasuresResponse\"\x00\x12:\n" +
+	"\tEraseUser\x12\x11.dbservice.UserID\x1a\x18.dbservice.ErasureResult\"\x00\x12T\n" +
 	"\x12UpdateStorageUsage\x12$.dbservice.UpdateStorageUsageRequest\x1a\x16.google.protobuf.Empty\"\x00\x12H\n" +
 	"\x10CheckEmailExists\x12\x17.dbservice.EmailRequest\x1a\x19.dbservice.ExistsResponse\"\x00\x12N\n" +
 	"\x13CheckUsernameExists\x12\x1a.dbservice.UsernameRequest\x1a\x19.dbservice.ExistsResponse\"\x00\x122\n" +
 	"\n" +
 	"CreateFile\x12\x0f.dbservice.File\x1a\x11.dbservice.FileID\"\x00\x123\n" +
 	"\vGetFileByID\x12\x11.dbservice.FileID\x1a\x0f.dbservice.File\"\x00\x12C\n" +
-	"\rGetFileByPath\x12\x1f.dbservice.GetFileByPathRequest\x1a\x0f.dbservice.File\"\x00\x127\n" +
+	"\rGetFileByPath\x12\x1f.dbservice.GetFileByPathRequest\x1a\x0f.dbservice.File\"\x00\x12D\n" +
 	"\n" +
-	"UpdateFile\x12\x0f.dbservice.File\x1a\x16.google.protobuf.Empty\"\x00\x129\n" +
+	"UpdateFile\x12\x1c.dbservice.UpdateFileRequest\x1a\x16.google.protobuf.Empty\"\x00\x129\n" +
 	"\n" +
 	"DeleteFile\x12\x11.dbservice.FileID\x1a\x16.google.protobuf.Empty\"\x00\x12=\n" +
 	"\x0eSoftDeleteFile\x12\x11.dbservice.FileID\x1a\x16.google.protobuf.Empty\"\x00\x12:\n" +
@@ -2775,12 +9000,18 @@ const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"\x0eCreateRevision\x12\x17.dbservice.FileRevision\x1a\x15.dbservice.RevisionID\"\x00\x12E\n" +
 	"\fGetRevisions\x12\x11.dbservice.FileID\x1a .dbservice.ListRevisionsResponse\"\x00\x12G\n" +
 	"\vGetRevision\x12\x1d.dbservice.GetRevisionRequest\x1a\x17.dbservice.FileRevision\"\x00\x12A\n" +
-	"\x0eDeleteRevision\x12\x15.dbservice.RevisionID\x1a\x16.google.protobuf.Empty\"\x00\x12H\n" +
-	"\x10CreatePermission\x12\x19.dbservice.FilePermission\x1a\x17.dbservice.PermissionID\"\x00\x12I\n" +
-	"\x0eGetPermissions\x12\x11.dbservice.FileID\x1a\".dbservice.ListPermissionsResponse\"\x00\x12G\n" +
-	"\x10UpdatePermission\x12\x19.dbservice.FilePermission\x1a\x16.google.protobuf.Empty\"\x00\x12E\n" +
-	"\x10DeletePermission\x12\x17.dbservice.PermissionID\x1a\x16.google.protobuf.Empty\"\x00\x12U\n" +
-	"\x0fCheckPermission\x12!.dbservice.CheckPermissionRequest\x1a\x1d.dbservice.PermissionResponse\"\x00\x12T\n" +
+	"\x0eDeleteRevision\x12\x15.dbservice.RevisionID\x1a\x16.google.protobuf.Empty\"\x00\x12Q\n" +
+	"\x10SetRevisionLabel\x12\".dbservice.SetRevisionLabelRequest\x1a\x17.dbservice.FileRevision\"\x00\x12M\n" +
+	"\x12ClearRevisionLabel\x12\x1d.dbservice.GetRevisionRequest\x1a\x16.google.protobuf.Empty\"\x00\x12U\n" +
+	"\x12GetRevisionByLabel\x12$.dbservice.GetRevisionByLabelRequest\x1a\x17.dbservice.FileRevision\"\x00\x12V\n" +
+	"\x0ePruneRevisions\x12 .dbservice.PruneRevisionsRequest\x1a .dbservice.ListRevisionsResponse\"\x00\x12Q\n" +
+	"\x10CreatePermission\x12\".dbservice.CreatePermissionRequest\x1a\x17.dbservice.PermissionID\"\x00\x12I\n" +
+	"\x0eGetPermissions\x12\x11.dbservice.FileID\x1a\".dbservice.ListPermissionsResponse\"\x00\x12P\n" +
+	"\x10UpdatePermission\x12\".dbservice.UpdatePermissionRequest\x1a\x16.google.protobuf.Empty\"\x00\x12P\n" +
+	"\x10DeletePermission\x12\".dbservice.DeletePermissionRequest\x1a\x16.google.protobuf.Empty\"\x00\x12U\n" +
+	"\x0fCheckPermission\x12!.dbservice.CheckPermissionRequest\x1a\x1d.dbservice.PermissionResponse\"\x00\x12]\n" +
+	"\x10CheckPermissions\x12\".dbservice.CheckPermissionsRequest\x1a#.dbservice.CheckPermissionsResponse\"\x00\x12d\n" +
+	"\x16GetEffectivePermission\x12(.dbservice.GetEffectivePermissionRequest\x1a\x1e.dbservice.EffectivePermission\"\x00\x12T\n" +
 	"\x12UpdateFileMetadata\x12$.dbservice.UpdateFileMetadataRequest\x1a\x16.google.protobuf.Empty\"\x00\x12G\n" +
 	"\x0fGetFileMetadata\x12\x11.dbservice.FileID\x1a\x1f.dbservice.FileMetadataResponse\"\x00\x127\n" +
 	"\bStarFile\x12\x11.dbservice.FileID\x1a\x16.google.protobuf.Empty\"\x00\x129\n" +
@@ -2789,9 +9020,49 @@ const file_internal_transport_grpc_protos_db_manager_proto_rawDesc = "" +
 	"\bMoveFile\x12\x1a.dbservice.MoveFileRequest\x1a\x16.google.protobuf.Empty\"\x00\x129\n" +
 	"\bCopyFile\x12\x1a.dbservice.CopyFileRequest\x1a\x0f.dbservice.File\"\x00\x12D\n" +
 	"\n" +
-	"RenameFile\x12\x1c.dbservice.RenameFileRequest\x1a\x16.google.protobuf.Empty\"\x00\x12H\n" +
+	"RenameFile\x12\x1c.dbservice.RenameFileRequest\x1a\x16.google.protobuf.Empty\"\x00\x12`\n" +
+	"\x11TransferOwnership\x12#.dbservice.TransferOwnershipRequest\x1a$.dbservice.TransferOwnershipResponse\"\x00\x12H\n" +
 	"\x13VerifyFileIntegrity\x12\x11.dbservice.FileID\x1a\x1c.dbservice.IntegrityResponse\"\x00\x12K\n" +
-	"\x16CalculateFileChecksums\x12\x11.dbservice.FileID\x1a\x1c.dbservice.ChecksumsResponse\"\x00B=Z;homecloud--dbmanager-service/internal/transport/grpc/protosb\x06proto3"
+	"\x16CalculateFileChecksums\x12\x11.dbservice.FileID\x1a\x1c.dbservice.ChecksumsResponse\"\x00\x12H\n" +
+	"\fFindBySHA256\x12\x1e.dbservice.FindBySHA256Request\x1a\x16.dbservice.StorageBlob\"\x00\x12W\n" +
+	"\x0eFindDuplicates\x12 .dbservice.FindDuplicatesRequest\x1a!.dbservice.FindDuplicatesResponse\"\x00\x12I\n" +
+	"\x0eGetStorageBlob\x12\x1d.dbservice.StoragePathRequest\x1a\x16.dbservice.StorageBlob\"\x00\x12g\n" +
+	"\x15ListUnreferencedBlobs\x12'.dbservice.ListUnreferencedBlobsRequest\x1a#.dbservice.ListStorageBlobsResponse\"\x00\x12N\n" +
+	"\vReleaseBlob\x12\x1d.dbservice.StoragePathRequest\x1a\x1e.dbservice.ReleaseBlobResponse\"\x00\x125\n" +
+	"\vCreateGroup\x12\x10.dbservice.Group\x1a\x12.dbservice.GroupID\"\x00\x122\n" +
+	"\bGetGroup\x12\x12.dbservice.GroupID\x1a\x10.dbservice.Group\"\x00\x12F\n" +
+	"\vUpdateGroup\x12\x1d.dbservice.UpdateGroupRequest\x1a\x16.google.protobuf.Empty\"\x00\x12F\n" +
+	"\vDeleteGroup\x12\x1d.dbservice.DeleteGroupRequest\x1a\x16.google.protobuf.Empty\"\x00\x12K\n" +
+	"\n" +
+	"ListGroups\x12\x1c.dbservice.ListGroupsRequest\x1a\x1d.dbservice.ListGroupsResponse\"\x00\x12L\n" +
+	"\x0eAddGroupMember\x12 .dbservice.AddGroupMemberRequest\x1a\x16.google.protobuf.Empty\"\x00\x12R\n" +
+	"\x11RemoveGroupMember\x12#.dbservice.RemoveGroupMemberRequest\x1a\x16.google.protobuf.Empty\"\x00\x12M\n" +
+	"\x10ListGroupMembers\x12\x12.dbservice.GroupID\x1a#.dbservice.ListGroupMembersResponse\"\x00\x12M\n" +
+	"\x0fCreateShareLink\x12\x14.dbservice.ShareLink\x1a\".dbservice.CreateShareLinkResponse\"\x00\x12H\n" +
+	"\x0eListShareLinks\x12\x11.dbservice.FileID\x1a!.dbservice.ListShareLinksResponse\"\x00\x12C\n" +
+	"\x0fRevokeShareLink\x12\x16.dbservice.ShareLinkID\x1a\x16.google.protobuf.Empty\"\x00\x12]\n" +
+	"\x10ResolveShareLink\x12\".dbservice.ResolveShareLinkRequest\x1a#.dbservice.ResolveShareLinkResponse\"\x00\x12S\n" +
+	"\rCreateSession\x12\x1f.dbservice.CreateSessionRequest\x1a\x1f.dbservice.SessionTokenResponse\"\x00\x12T\n" +
+	"\rRotateSession\x12\x1f.dbservice.RotateSessionRequest\x1a .dbservice.RotateSessionResponse\"\x00\x12D\n" +
+	"\fListSessions\x12\x11.dbservice.UserID\x1a\x1f.dbservice.ListSessionsResponse\"\x00\x12?\n" +
+	"\rRevokeSession\x12\x14.dbservice.SessionID\x1a\x16.google.protobuf.Empty\"\x00\x12`\n" +
+	"\x11RevokeAllSessions\x12#.dbservice.RevokeAllSessionsRequest\x1a$.dbservice.RevokeAllSessionsResponse\"\x00\x12J\n" +
+	"\x0fEnrollTwoFactor\x12\x11.dbservice.UserID\x1a\".dbservice.EnrollTwoFactorResponse\"\x00\x12Z\n" +
+	"\x10ConfirmTwoFactor\x12\x1f.dbservice.TwoFactorCodeRequest\x1a#.dbservice.ConfirmTwoFactorResponse\"\x00\x12X\n" +
+	"\x0fVerifyTwoFactor\x12\x1f.dbservice.TwoFactorCodeRequest\x1a\".dbservice.VerifyTwoFactorResponse\"\x00\x12`\n" +
+	"\x13ConsumeRecoveryCode\x12\x1f.dbservice.TwoFactorCodeRequest\x1a&.dbservice.ConsumeRecoveryCodeResponse\"\x00\x12h\n" +
+	"\x17RegenerateRecoveryCodes\x12\x1f.dbservice.TwoFactorCodeRequest\x1a*.dbservice.RegenerateRecoveryCodesResponse\"\x00\x12\\\n" +
+	"\x1aRegisterWebAuthnCredential\x12\x1d.dbservice.WebAuthnCredential\x1a\x1d.dbservice.WebAuthnCredential\"\x00\x12a\n" +
+	"\x15GetWebAuthnCredential\x12'.dbservice.GetWebAuthnCredentialRequest\x1a\x1d.dbservice.WebAuthnCredential\"\x00\x12Z\n" +
+	"\x17ListWebAuthnCredentials\x12\x11.dbservice.UserID\x1a*.dbservice.ListWebAuthnCredentialsResponse\"\x00\x12g\n" +
+	"\x18RenameWebAuthnCredential\x12*.dbservice.RenameWebAuthnCredentialRequest\x1a\x1d.dbservice.WebAuthnCredential\"\x00\x12U\n" +
+	"\x18DeleteWebAuthnCredential\x12\x1f.dbservice.WebAuthnCredentialID\x1a\x16.google.protobuf.Empty\"\x00\x12r\n" +
+	"\x17UpdateWebAuthnSignCount\x12).dbservice.UpdateWebAuthnSignCountRequest\x1a*.dbservice.UpdateWebAuthnSignCountResponse\"\x00\x12B\n" +
+	"\fLinkIdentity\x12\x17.dbservice.UserIdentity\x1a\x17.dbservice.UserIdentity\"\x00\x12L\n" +
+	"\x0eUnlinkIdentity\x12 .dbservice.UnlinkIdentityRequest\x1a\x16.google.protobuf.Empty\"\x00\x12H\n" +
+	"\x0eListIdentities\x12\x11.dbservice.UserID\x1a!.dbservice.ListIdentitiesResponse\"\x00\x12>\n" +
+	"\x11GetUserByIdentity\x12\x16.dbservice.IdentityKey\x1a\x0f.dbservice.User\"\x00\x12c\n" +
+	"\x12ListSecurityEvents\x12$.dbservice.ListSecurityEventsRequest\x1a%.dbservice.ListSecurityEventsResponse\"\x00B=Z;homecloud--dbmanager-service/internal/transport/grpc/protosb\x06proto3"
 
 var (
 	file_internal_transport_grpc_protos_db_manager_proto_rawDescOnce sync.Once
@@ -2805,172 +9076,464 @@ func file_internal_transport_grpc_protos_db_manager_proto_rawDescGZIP() []byte {
 	return file_internal_transport_grpc_protos_db_manager_proto_rawDescData
 }
 
-var file_internal_transport_grpc_protos_db_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_internal_transport_grpc_protos_db_manager_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_internal_transport_grpc_protos_db_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 128)
 var file_internal_transport_grpc_protos_db_manager_proto_goTypes = []any{
-	(*User)(nil),                             // 0: dbservice.User
-	(*UserExtendedInfo)(nil),                 // 1: dbservice.UserExtendedInfo
-	(*UserID)(nil),                           // 2: dbservice.UserID
-	(*EmailRequest)(nil),                     // 3: dbservice.EmailRequest
-	(*UsernameRequest)(nil),                  // 4: dbservice.UsernameRequest
-	(*UpdatePasswordRequest)(nil),            // 5: dbservice.UpdatePasswordRequest
-	(*UpdateUsernameRequest)(nil),            // 6: dbservice.UpdateUsernameRequest
-	(*UpdateEmailVerificationRequest)(nil),   // 7: dbservice.UpdateEmailVerificationRequest
-	(*UpdateFailedLoginAttemptsRequest)(nil), // 8: dbservice.UpdateFailedLoginAttemptsRequest
-	(*UpdateLockedUntilRequest)(nil),         // 9: dbservice.UpdateLockedUntilRequest
-	(*UpdateStorageUsageRequest)(nil),        // 10: dbservice.UpdateStorageUsageRequest
-	(*ExistsResponse)(nil),                   // 11: dbservice.ExistsResponse
-	(*File)(nil),                             // 12: dbservice.File
-	(*FileID)(nil),                           // 13: dbservice.FileID
-	(*GetFileByPathRequest)(nil),             // 14: dbservice.GetFileByPathRequest
-	(*ListFilesRequest)(nil),                 // 15: dbservice.ListFilesRequest
-	(*ListFilesResponse)(nil),                // 16: dbservice.ListFilesResponse
-	(*ListFilesByParentRequest)(nil),         // 17: dbservice.ListFilesByParentRequest
-	(*ListStarredFilesRequest)(nil),          // 18: dbservice.ListStarredFilesRequest
-	(*ListTrashedFilesRequest)(nil),          // 19: dbservice.ListTrashedFilesRequest
-	(*SearchFilesRequest)(nil),               // 20: dbservice.SearchFilesRequest
-	(*FileSizeResponse)(nil),                 // 21: dbservice.FileSizeResponse
-	(*UpdateFileSizeRequest)(nil),            // 22: dbservice.UpdateFileSizeRequest
-	(*GetFileTreeRequest)(nil),               // 23: dbservice.GetFileTreeRequest
-	(*FileRevision)(nil),                     // 24: dbservice.FileRevision
-	(*RevisionID)(nil),                       // 25: dbservice.RevisionID
-	(*ListRevisionsResponse)(nil),            // 26: dbservice.ListRevisionsResponse
-	(*GetRevisionRequest)(nil),               // 27: dbservice.GetRevisionRequest
-	(*FilePermission)(nil),                   // 28: dbservice.FilePermission
-	(*PermissionID)(nil),                     // 29: dbservice.PermissionID
-	(*ListPermissionsResponse)(nil),          // 30: dbservice.ListPermissionsResponse
-	(*CheckPermissionRequest)(nil),           // 31: dbservice.CheckPermissionRequest
-	(*PermissionResponse)(nil),               // 32: dbservice.PermissionResponse
-	(*UpdateFileMetadataRequest)(nil),        // 33: dbservice.UpdateFileMetadataRequest
-	(*FileMetadataResponse)(nil),             // 34: dbservice.FileMetadataResponse
-	(*MoveFileRequest)(nil),                  // 35: dbservice.MoveFileRequest
-	(*CopyFileRequest)(nil),                  // 36: dbservice.CopyFileRequest
-	(*RenameFileRequest)(nil),                // 37: dbservice.RenameFileRequest
-	(*IntegrityResponse)(nil),                // 38: dbservice.IntegrityResponse
-	(*ChecksumsResponse)(nil),                // 39: dbservice.ChecksumsResponse
-	nil,                                      // 40: dbservice.UserExtendedInfo.MetadataEntry
-	nil,                                      // 41: dbservice.ChecksumsResponse.ChecksumsEntry
-	(*timestamppb.Timestamp)(nil),            // 42: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                    // 43: google.protobuf.Empty
+	(UserTokenPurpose)(0),                    // 0: dbservice.UserTokenPurpose
+	(ConsumeTokenStatus)(0),                  // 1: dbservice.ConsumeTokenStatus
+	(PermissionRole)(0),                      // 2: dbservice.PermissionRole
+	(GranteeType)(0),                         // 3: dbservice.GranteeType
+	(ShareLinkStatus)(0),                     // 4: dbservice.ShareLinkStatus
+	(RotateSessionStatus)(0),                 // 5: dbservice.RotateSessionStatus
+	(WebAuthnSignCountStatus)(0),             // 6: dbservice.WebAuthnSignCountStatus
+	(UserSortField)(0),                       // 7: dbservice.UserSortField
+	(SecurityEventType)(0),                   // 8: dbservice.SecurityEventType
+	(*User)(nil),                             // 9: dbservice.User
+	(*UserExtendedInfo)(nil),                 // 10: dbservice.UserExtendedInfo
+	(*UserID)(nil),                           // 11: dbservice.UserID
+	(*EmailRequest)(nil),                     // 12: dbservice.EmailRequest
+	(*UsernameRequest)(nil),                  // 13: dbservice.UsernameRequest
+	(*UpdatePasswordRequest)(nil),            // 14: dbservice.UpdatePasswordRequest
+	(*UpdateUserRequest)(nil),                // 15: dbservice.UpdateUserRequest
+	(*UpdateUsernameRequest)(nil),            // 16: dbservice.UpdateUsernameRequest
+	(*UpdateEmailVerificationRequest)(nil),   // 17: dbservice.UpdateEmailVerificationRequest
+	(*UpdateFailedLoginAttemptsRequest)(nil), // 18: dbservice.UpdateFailedLoginAttemptsRequest
+	(*UpdateLockedUntilRequest)(nil),         // 19: dbservice.UpdateLockedUntilRequest
+	(*LoginState)(nil),                       // 20: dbservice.LoginState
+	(*IssueTokenRequest)(nil),                // 21: dbservice.IssueTokenRequest
+	(*IssueTokenResponse)(nil),               // 22: dbservice.IssueTokenResponse
+	(*ConsumeTokenRequest)(nil),              // 23: dbservice.ConsumeTokenRequest
+	(*ConsumeTokenResponse)(nil),             // 24: dbservice.ConsumeTokenResponse
+	(*UpdateStorageUsageRequest)(nil),        // 25: dbservice.UpdateStorageUsageRequest
+	(*ExistsResponse)(nil),                   // 26: dbservice.ExistsResponse
+	(*File)(nil),                             // 27: dbservice.File
+	(*FileID)(nil),                           // 28: dbservice.FileID
+	(*GetFileByPathRequest)(nil),             // 29: dbservice.GetFileByPathRequest
+	(*ListFilesRequest)(nil),                 // 30: dbservice.ListFilesRequest
+	(*ListFilesResponse)(nil),                // 31: dbservice.ListFilesResponse
+	(*ListFilesByParentRequest)(nil),         // 32: dbservice.ListFilesByParentRequest
+	(*ListStarredFilesRequest)(nil),          // 33: dbservice.ListStarredFilesRequest
+	(*ListTrashedFilesRequest)(nil),          // 34: dbservice.ListTrashedFilesRequest
+	(*SearchFilesRequest)(nil),               // 35: dbservice.SearchFilesRequest
+	(*FileSizeResponse)(nil),                 // 36: dbservice.FileSizeResponse
+	(*UpdateFileRequest)(nil),                // 37: dbservice.UpdateFileRequest
+	(*UpdateFileSizeRequest)(nil),            // 38: dbservice.UpdateFileSizeRequest
+	(*GetFileTreeRequest)(nil),               // 39: dbservice.GetFileTreeRequest
+	(*FileRevision)(nil),                     // 40: dbservice.FileRevision
+	(*RevisionID)(nil),                       // 41: dbservice.RevisionID
+	(*ListRevisionsResponse)(nil),            // 42: dbservice.ListRevisionsResponse
+	(*GetRevisionRequest)(nil),               // 43: dbservice.GetRevisionRequest
+	(*SetRevisionLabelRequest)(nil),          // 44: dbservice.SetRevisionLabelRequest
+	(*GetRevisionByLabelRequest)(nil),        // 45: dbservice.GetRevisionByLabelRequest
+	(*PruneRevisionsRequest)(nil),            // 46: dbservice.PruneRevisionsRequest
+	(*FilePermission)(nil),                   // 47: dbservice.FilePermission
+	(*PermissionID)(nil),                     // 48: dbservice.PermissionID
+	(*CreatePermissionRequest)(nil),          // 49: dbservice.CreatePermissionRequest
+	(*UpdatePermissionRequest)(nil),          // 50: dbservice.UpdatePermissionRequest
+	(*DeletePermissionRequest)(nil),          // 51: dbservice.DeletePermissionRequest
+	(*ListPermissionsResponse)(nil),          // 52: dbservice.ListPermissionsResponse
+	(*CheckPermissionRequest)(nil),           // 53: dbservice.CheckPermissionRequest
+	(*PermissionResponse)(nil),               // 54: dbservice.PermissionResponse
+	(*CheckPermissionsRequest)(nil),          // 55: dbservice.CheckPermissionsRequest
+	(*CheckPermissionsResponse)(nil),         // 56: dbservice.CheckPermissionsResponse
+	(*GetEffectivePermissionRequest)(nil),    // 57: dbservice.GetEffectivePermissionRequest
+	(*PermissionSource)(nil),                 // 58: dbservice.PermissionSource
+	(*EffectivePermission)(nil),              // 59: dbservice.EffectivePermission
+	(*UpdateFileMetadataRequest)(nil),        // 60: dbservice.UpdateFileMetadataRequest
+	(*FileMetadataResponse)(nil),             // 61: dbservice.FileMetadataResponse
+	(*MoveFileRequest)(nil),                  // 62: dbservice.MoveFileRequest
+	(*CopyFileRequest)(nil),                  // 63: dbservice.CopyFileRequest
+	(*RenameFileRequest)(nil),                // 64: dbservice.RenameFileRequest
+	(*TransferOwnershipRequest)(nil),         // 65: dbservice.TransferOwnershipRequest
+	(*TransferOwnershipResponse)(nil),        // 66: dbservice.TransferOwnershipResponse
+	(*IntegrityResponse)(nil),                // 67: dbservice.IntegrityResponse
+	(*ChecksumsResponse)(nil),                // 68: dbservice.ChecksumsResponse
+	(*StorageBlob)(nil),                      // 69: dbservice.StorageBlob
+	(*FindBySHA256Request)(nil),              // 70: dbservice.FindBySHA256Request
+	(*FindDuplicatesRequest)(nil),            // 71: dbservice.FindDuplicatesRequest
+	(*DuplicateGroup)(nil),                   // 72: dbservice.DuplicateGroup
+	(*FindDuplicatesResponse)(nil),           // 73: dbservice.FindDuplicatesResponse
+	(*StoragePathRequest)(nil),               // 74: dbservice.StoragePathRequest
+	(*ListUnreferencedBlobsRequest)(nil),     // 75: dbservice.ListUnreferencedBlobsRequest
+	(*ListStorageBlobsResponse)(nil),         // 76: dbservice.ListStorageBlobsResponse
+	(*ReleaseBlobResponse)(nil),              // 77: dbservice.ReleaseBlobResponse
+	(*Group)(nil),                            // 78: dbservice.Group
+	(*GroupID)(nil),                          // 79: dbservice.GroupID
+	(*UpdateGroupRequest)(nil),               // 80: dbservice.UpdateGroupRequest
+	(*DeleteGroupRequest)(nil),               // 81: dbservice.DeleteGroupRequest
+	(*ListGroupsRequest)(nil),                // 82: dbservice.ListGroupsRequest
+	(*ListGroupsResponse)(nil),               // 83: dbservice.ListGroupsResponse
+	(*GroupMember)(nil),                      // 84: dbservice.GroupMember
+	(*AddGroupMemberRequest)(nil),            // 85: dbservice.AddGroupMemberRequest
+	(*RemoveGroupMemberRequest)(nil),         // 86: dbservice.RemoveGroupMemberRequest
+	(*ListGroupMembersResponse)(nil),         // 87: dbservice.ListGroupMembersResponse
+	(*ShareLink)(nil),                        // 88: dbservice.ShareLink
+	(*ShareLinkID)(nil),                      // 89: dbservice.ShareLinkID
+	(*CreateShareLinkResponse)(nil),          // 90: dbservice.CreateShareLinkResponse
+	(*ListShareLinksResponse)(nil),           // 91: dbservice.ListShareLinksResponse
+	(*ResolveShareLinkRequest)(nil),          // 92: dbservice.ResolveShareLinkRequest
+	(*ResolveShareLinkResponse)(nil),         // 93: dbservice.ResolveShareLinkResponse
+	(*Session)(nil),                          // 94: dbservice.Session
+	(*SessionID)(nil),                        // 95: dbservice.SessionID
+	(*CreateSessionRequest)(nil),             // 96: dbservice.CreateSessionRequest
+	(*SessionTokenResponse)(nil),             // 97: dbservice.SessionTokenResponse
+	(*RotateSessionRequest)(nil),             // 98: dbservice.RotateSessionRequest
+	(*RotateSessionResponse)(nil),            // 99: dbservice.RotateSessionResponse
+	(*ListSessionsResponse)(nil),             // 100: dbservice.ListSessionsResponse
+	(*RevokeAllSessionsRequest)(nil),         // 101: dbservice.RevokeAllSessionsRequest
+	(*RevokeAllSessionsResponse)(nil),        // 102: dbservice.RevokeAllSessionsResponse
+	(*EnrollTwoFactorResponse)(nil),          // 103: dbservice.EnrollTwoFactorResponse
+	(*TwoFactorCodeRequest)(nil),             // 104: dbservice.TwoFactorCodeRequest
+	(*ConfirmTwoFactorResponse)(nil),         // 105: dbservice.ConfirmTwoFactorResponse
+	(*VerifyTwoFactorResponse)(nil),          // 106: dbservice.VerifyTwoFactorResponse
+	(*ConsumeRecoveryCodeResponse)(nil),      // 107: dbservice.ConsumeRecoveryCodeResponse
+	(*RegenerateRecoveryCodesResponse)(nil),  // 108: dbservice.RegenerateRecoveryCodesResponse
+	(*WebAuthnCredential)(nil),               // 109: dbservice.WebAuthnCredential
+	(*WebAuthnCredentialID)(nil),             // 110: dbservice.WebAuthnCredentialID
+	(*GetWebAuthnCredentialRequest)(nil),     // 111: dbservice.GetWebAuthnCredentialRequest
+	(*ListWebAuthnCredentialsResponse)(nil),  // 112: dbservice.ListWebAuthnCredentialsResponse
+	(*RenameWebAuthnCredentialRequest)(nil),  // 113: dbservice.RenameWebAuthnCredentialRequest
+	(*UpdateWebAuthnSignCountRequest)(nil),   // 114: dbservice.UpdateWebAuthnSignCountRequest
+	(*UpdateWebAuthnSignCountResponse)(nil),  // 115: dbservice.UpdateWebAuthnSignCountResponse
+	(*UserIdentity)(nil),                     // 116: dbservice.UserIdentity
+	(*IdentityKey)(nil),                      // 117: dbservice.IdentityKey
+	(*UnlinkIdentityRequest)(nil),            // 118: dbservice.UnlinkIdentityRequest
+	(*ListIdentitiesResponse)(nil),           // 119: dbservice.ListIdentitiesResponse
+	(*AccountDeletion)(nil),                  // 120: dbservice.AccountDeletion
+	(*ListPendingErasuresRequest)(nil),       // 121: dbservice.ListPendingErasuresRequest
+	(*ListPendingErasuresResponse)(nil),      // 122: dbservice.ListPendingErasuresResponse
+	(*ErasureResult)(nil),                    // 123: dbservice.ErasureResult
+	(*ListUsersRequest)(nil),                 // 124: dbservice.ListUsersRequest
+	(*ListUsersResponse)(nil),                // 125: dbservice.ListUsersResponse
+	(*PasswordHistoryEntry)(nil),             // 126: dbservice.PasswordHistoryEntry
+	(*PasswordHistoryResponse)(nil),          // 127: dbservice.PasswordHistoryResponse
+	(*Preferences)(nil),                      // 128: dbservice.Preferences
+	(*SetPreferencesRequest)(nil),            // 129: dbservice.SetPreferencesRequest
+	(*PatchPreferencesRequest)(nil),          // 130: dbservice.PatchPreferencesRequest
+	(*SecurityEvent)(nil),                    // 131: dbservice.SecurityEvent
+	(*ListSecurityEventsRequest)(nil),        // 132: dbservice.ListSecurityEventsRequest
+	(*ListSecurityEventsResponse)(nil),       // 133: dbservice.ListSecurityEventsResponse
+	nil,                                      // 134: dbservice.UserExtendedInfo.MetadataEntry
+	nil,                                      // 135: dbservice.CheckPermissionsResponse.PermissionsEntry
+	nil,                                      // 136: dbservice.ChecksumsResponse.ChecksumsEntry
+	(*timestamppb.Timestamp)(nil),            // 137: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),            // 138: google.protobuf.FieldMask
+	(*structpb.Struct)(nil),                  // 139: google.protobuf.Struct
+	(*emptypb.Empty)(nil),                    // 140: google.protobuf.Empty
 }
 var file_internal_transport_grpc_protos_db_manager_proto_depIdxs = []int32{
-	42, // 0: dbservice.User.created_at:type_name -> google.protobuf.Timestamp
-	42, // 1: dbservice.User.updated_at:type_name -> google.protobuf.Timestamp
-	42, // 2: dbservice.User.locked_until:type_name -> google.protobuf.Timestamp
-	42, // 3: dbservice.User.last_login:type_name -> google.protobuf.Timestamp
-	0,  // 4: dbservice.UserExtendedInfo.user:type_name -> dbservice.User
-	40, // 5: dbservice.UserExtendedInfo.metadata:type_name -> dbservice.UserExtendedInfo.MetadataEntry
-	42, // 6: dbservice.UpdateLockedUntilRequest.locked_until:type_name -> google.protobuf.Timestamp
-	42, // 7: dbservice.File.trashed_at:type_name -> google.protobuf.Timestamp
-	42, // 8: dbservice.File.created_at:type_name -> google.protobuf.Timestamp
-	42, // 9: dbservice.File.updated_at:type_name -> google.protobuf.Timestamp
-	42, // 10: dbservice.File.last_viewed_at:type_name -> google.protobuf.Timestamp
-	12, // 11: dbservice.ListFilesResponse.files:type_name -> dbservice.File
-	42, // 12: dbservice.FileRevision.created_at:type_name -> google.protobuf.Timestamp
-	24, // 13: dbservice.ListRevisionsResponse.revisions:type_name -> dbservice.FileRevision
-	42, // 14: dbservice.FilePermission.created_at:type_name -> google.protobuf.Timestamp
-	28, // 15: dbservice.ListPermissionsResponse.permissions:type_name -> dbservice.FilePermission
-	41, // 16: dbservice.ChecksumsResponse.checksums:type_name -> dbservice.ChecksumsResponse.ChecksumsEntry
-	0,  // 17: dbservice.DBService.CreateUser:input_type -> dbservice.User
-	2,  // 18: dbservice.DBService.GetUserByID:input_type -> dbservice.UserID
-	3,  // 19: dbservice.DBService.GetUserByEmail:input_type -> dbservice.EmailRequest
-	2,  // 20: dbservice.DBService.GetUserExtendedInfo:input_type -> dbservice.UserID
-	0,  // 21: dbservice.DBService.UpdateUser:input_type -> dbservice.User
-	5,  // 22: dbservice.DBService.UpdatePassword:input_type -> dbservice.UpdatePasswordRequest
-	6,  // 23: dbservice.DBService.UpdateUsername:input_type -> dbservice.UpdateUsernameRequest
-	7,  // 24: dbservice.DBService.UpdateEmailVerification:input_type -> dbservice.UpdateEmailVerificationRequest
-	2,  // 25: dbservice.DBService.UpdateLastLogin:input_type -> dbservice.UserID
-	8,  // 26: dbservice.DBService.UpdateFailedLoginAttempts:input_type -> dbservice.UpdateFailedLoginAttemptsRequest
-	9,  // 27: dbservice.DBService.UpdateLockedUntil:input_type -> dbservice.UpdateLockedUntilRequest
-	10, // 28: dbservice.DBService.UpdateStorageUsage:input_type -> dbservice.UpdateStorageUsageRequest
-	3,  // 29: dbservice.DBService.CheckEmailExists:input_type -> dbservice.EmailRequest
-	4,  // 30: dbservice.DBService.CheckUsernameExists:input_type -> dbservice.UsernameRequest
-	12, // 31: dbservice.DBService.CreateFile:input_type -> dbservice.File
-	13, // 32: dbservice.DBService.GetFileByID:input_type -> dbservice.FileID
-	14, // 33: dbservice.DBService.GetFileByPath:input_type -> dbservice.GetFileByPathRequest
-	12, // 34: dbservice.DBService.UpdateFile:input_type -> dbservice.File
-	13, // 35: dbservice.DBService.DeleteFile:input_type -> dbservice.FileID
-	13, // 36: dbservice.DBService.SoftDeleteFile:input_type -> dbservice.FileID
-	13, // 37: dbservice.DBService.RestoreFile:input_type -> dbservice.FileID
-	15, // 38: dbservice.DBService.ListFiles:input_type -> dbservice.ListFilesRequest
-	17, // 39: dbservice.DBService.ListFilesByParent:input_type -> dbservice.ListFilesByParentRequest
-	18, // 40: dbservice.DBService.ListStarredFiles:input_type -> dbservice.ListStarredFilesRequest
-	19, // 41: dbservice.DBService.ListTrashedFiles:input_type -> dbservice.ListTrashedFilesRequest
-	20, // 42: dbservice.DBService.SearchFiles:input_type -> dbservice.SearchFilesRequest
-	13, // 43: dbservice.DBService.GetFileSize:input_type -> dbservice.FileID
-	22, // 44: dbservice.DBService.UpdateFileSize:input_type -> dbservice.UpdateFileSizeRequest
-	13, // 45: dbservice.DBService.UpdateLastViewed:input_type -> dbservice.FileID
-	23, // 46: dbservice.DBService.GetFileTree:input_type -> dbservice.GetFileTreeRequest
-	24, // 47: dbservice.DBService.CreateRevision:input_type -> dbservice.FileRevision
-	13, // 48: dbservice.DBService.GetRevisions:input_type -> dbservice.FileID
-	27, // 49: dbservice.DBService.GetRevision:input_type -> dbservice.GetRevisionRequest
-	25, // 50: dbservice.DBService.DeleteRevision:input_type -> dbservice.RevisionID
-	28, // 51: dbservice.DBService.CreatePermission:input_type -> dbservice.FilePermission
-	13, // 52: dbservice.DBService.GetPermissions:input_type -> dbservice.FileID
-	28, // 53: dbservice.DBService.UpdatePermission:input_type -> dbservice.FilePermission
-	29, // 54: dbservice.DBService.DeletePermission:input_type -> dbservice.PermissionID
-	31, // 55: dbservice.DBService.CheckPermission:input_type -> dbservice.CheckPermissionRequest
-	33, // 56: dbservice.DBService.UpdateFileMetadata:input_type -> dbservice.UpdateFileMetadataRequest
-	13, // 57: dbservice.DBService.GetFileMetadata:input_type -> dbservice.FileID
-	13, // 58: dbservice.DBService.StarFile:input_type -> dbservice.FileID
-	13, // 59: dbservice.DBService.UnstarFile:input_type -> dbservice.FileID
-	35, // 60: dbservice.DBService.MoveFile:input_type -> dbservice.MoveFileRequest
-	36, // 61: dbservice.DBService.CopyFile:input_type -> dbservice.CopyFileRequest
-	37, // 62: dbservice.DBService.RenameFile:input_type -> dbservice.RenameFileRequest
-	13, // 63: dbservice.DBService.VerifyFileIntegrity:input_type -> dbservice.FileID
-	13, // 64: dbservice.DBService.CalculateFileChecksums:input_type -> dbservice.FileID
-	2,  // 65: dbservice.DBService.CreateUser:output_type -> dbservice.UserID
-	0,  // 66: dbservice.DBService.GetUserByID:output_type -> dbservice.User
-	0,  // 67: dbservice.DBService.GetUserByEmail:output_type -> dbservice.User
-	1,  // 68: dbservice.DBService.GetUserExtendedInfo:output_type -> dbservice.UserExtendedInfo
-	43, // 69: dbservice.DBService.UpdateUser:output_type -> google.protobuf.Empty
-	43, // 70: dbservice.DBService.UpdatePassword:output_type -> google.protobuf.Empty
-	43, // 71: dbservice.DBService.UpdateUsername:output_type -> google.protobuf.Empty
-	43, // 72: dbservice.DBService.UpdateEmailVerification:output_type -> google.protobuf.Empty
-	43, // 73: dbservice.DBService.UpdateLastLogin:output_type -> google.protobuf.Empty
-	43, // 74: dbservice.DBService.UpdateFailedLoginAttempts:output_type -> google.protobuf.Empty
-	43, // 75: dbservice.DBService.UpdateLockedUntil:output_type -> google.protobuf.Empty
-	43, // 76: dbservice.DBService.UpdateStorageUsage:output_type -> google.protobuf.Empty
-	11, // 77: dbservice.DBService.CheckEmailExists:output_type -> dbservice.ExistsResponse
-	11, // 78: dbservice.DBService.CheckUsernameExists:output_type -> dbservice.ExistsResponse
-	13, // 79: dbservice.DBService.CreateFile:output_type -> dbservice.FileID
-	12, // 80: dbservice.DBService.GetFileByID:output_type -> dbservice.File
-	12, // 81: dbservice.DBService.GetFileByPath:output_type -> dbservice.File
-	43, // 82: dbservice.DBService.UpdateFile:output_type -> google.protobuf.Empty
-	43, // 83: dbservice.DBService.DeleteFile:output_type -> google.protobuf.Empty
-	43, // 84: dbservice.DBService.SoftDeleteFile:output_type -> google.protobuf.Empty
-	43, // 85: dbservice.DBService.RestoreFile:output_type -> google.protobuf.Empty
-	16, // 86: dbservice.DBService.ListFiles:output_type -> dbservice.ListFilesResponse
-	16, // 87: dbservice.DBService.ListFilesByParent:output_type -> dbservice.ListFilesResponse
-	16, // 88: dbservice.DBService.ListStarredFiles:output_type -> dbservice.ListFilesResponse
-	16, // 89: dbservice.DBService.ListTrashedFiles:output_type -> dbservice.ListFilesResponse
-	16, // 90: dbservice.DBService.SearchFiles:output_type -> dbservice.ListFilesResponse
-	21, // 91: dbservice.DBService.GetFileSize:output_type -> dbservice.FileSizeResponse
-	43, // 92: dbservice.DBService.UpdateFileSize:output_type -> google.protobuf.Empty
-	43, // 93: dbservice.DBService.UpdateLastViewed:output_type -> google.protobuf.Empty
-	16, // 94: dbservice.DBService.GetFileTree:output_type -> dbservice.ListFilesResponse
-	25, // 95: dbservice.DBService.CreateRevision:output_type -> dbservice.RevisionID
-	26, // 96: dbservice.DBService.GetRevisions:output_type -> dbservice.ListRevisionsResponse
-	24, // 97: dbservice.DBService.GetRevision:output_type -> dbservice.FileRevision
-	43, // 98: dbservice.DBService.DeleteRevision:output_type -> google.protobuf.Empty
-	29, // 99: dbservice.DBService.CreatePermission:output_type -> dbservice.PermissionID
-	30, // 100: dbservice.DBService.GetPermissions:output_type -> dbservice.ListPermissionsResponse
-	43, // 101: dbservice.DBService.UpdatePermission:output_type -> google.protobuf.Empty
-	43, // 102: dbservice.DBService.DeletePermission:output_type -> google.protobuf.Empty
-	32, // 103: dbservice.DBService.CheckPermission:output_type -> dbservice.PermissionResponse
-	43, // 104: dbservice.DBService.UpdateFileMetadata:output_type -> google.protobuf.Empty
-	34, // 105: dbservice.DBService.GetFileMetadata:output_type -> dbservice.FileMetadataResponse
-	43, // 106: dbservice.DBService.StarFile:output_type -> google.protobuf.Empty
-	43, // 107: dbservice.DBService.UnstarFile:output_type -> google.protobuf.Empty
-	43, // 108: dbservice.DBService.MoveFile:output_type -> google.protobuf.Empty
-	12, // 109: dbservice.DBService.CopyFile:output_type -> dbservice.File
-	43, // 110: dbservice.DBService.RenameFile:output_type -> google.protobuf.Empty
-	38, // 111: dbservice.DBService.VerifyFileIntegrity:output_type -> dbservice.IntegrityResponse
-	39, // 112: dbservice.DBService.CalculateFileChecksums:output_type -> dbservice.ChecksumsResponse
-	65, // [65:113] is the sub-list for method output_type
-	17, // [17:65] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	137, // 0: dbservice.User.created_at:type_name -> google.protobuf.Timestamp
+	137, // 1: dbservice.User.updated_at:type_name -> google.protobuf.Timestamp
+	137, // 2: dbservice.User.locked_until:type_name -> google.protobuf.Timestamp
+	137, // 3: dbservice.User.last_login:type_name -> google.protobuf.Timestamp
+	9,   // 4: dbservice.UserExtendedInfo.user:type_name -> dbservice.User
+	134, // 5: dbservice.UserExtendedInfo.metadata:type_name -> dbservice.UserExtendedInfo.MetadataEntry
+	9,   // 6: dbservice.UpdateUserRequest.user:type_name -> dbservice.User
+	138, // 7: dbservice.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	137, // 8: dbservice.UpdateLockedUntilRequest.locked_until:type_name -> google.protobuf.Timestamp
+	137, // 9: dbservice.LoginState.locked_until:type_name -> google.protobuf.Timestamp
+	0,   // 10: dbservice.IssueTokenRequest.purpose:type_name -> dbservice.UserTokenPurpose
+	137, // 11: dbservice.IssueTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	137, // 12: dbservice.IssueTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0,   // 13: dbservice.ConsumeTokenRequest.purpose:type_name -> dbservice.UserTokenPurpose
+	1,   // 14: dbservice.ConsumeTokenResponse.status:type_name -> dbservice.ConsumeTokenStatus
+	137, // 15: dbservice.File.trashed_at:type_name -> google.protobuf.Timestamp
+	137, // 16: dbservice.File.created_at:type_name -> google.protobuf.Timestamp
+	137, // 17: dbservice.File.updated_at:type_name -> google.protobuf.Timestamp
+	137, // 18: dbservice.File.last_viewed_at:type_name -> google.protobuf.Timestamp
+	27,  // 19: dbservice.ListFilesResponse.files:type_name -> dbservice.File
+	27,  // 20: dbservice.UpdateFileRequest.file:type_name -> dbservice.File
+	138, // 21: dbservice.UpdateFileRequest.update_mask:type_name -> google.protobuf.FieldMask
+	137, // 22: dbservice.FileRevision.created_at:type_name -> google.protobuf.Timestamp
+	40,  // 23: dbservice.ListRevisionsResponse.revisions:type_name -> dbservice.FileRevision
+	137, // 24: dbservice.FilePermission.created_at:type_name -> google.protobuf.Timestamp
+	137, // 25: dbservice.FilePermission.expires_at:type_name -> google.protobuf.Timestamp
+	3,   // 26: dbservice.FilePermission.grantee_kind:type_name -> dbservice.GranteeType
+	2,   // 27: dbservice.FilePermission.permission_role:type_name -> dbservice.PermissionRole
+	47,  // 28: dbservice.CreatePermissionRequest.permission:type_name -> dbservice.FilePermission
+	47,  // 29: dbservice.UpdatePermissionRequest.permission:type_name -> dbservice.FilePermission
+	47,  // 30: dbservice.ListPermissionsResponse.permissions:type_name -> dbservice.FilePermission
+	2,   // 31: dbservice.CheckPermissionRequest.minimum_role:type_name -> dbservice.PermissionRole
+	2,   // 32: dbservice.CheckPermissionsRequest.minimum_role:type_name -> dbservice.PermissionRole
+	135, // 33: dbservice.CheckPermissionsResponse.permissions:type_name -> dbservice.CheckPermissionsResponse.PermissionsEntry
+	2,   // 34: dbservice.PermissionSource.role:type_name -> dbservice.PermissionRole
+	137, // 35: dbservice.PermissionSource.expires_at:type_name -> google.protobuf.Timestamp
+	2,   // 36: dbservice.EffectivePermission.role:type_name -> dbservice.PermissionRole
+	58,  // 37: dbservice.EffectivePermission.sources:type_name -> dbservice.PermissionSource
+	2,   // 38: dbservice.TransferOwnershipRequest.keep_previous_as_role:type_name -> dbservice.PermissionRole
+	136, // 39: dbservice.ChecksumsResponse.checksums:type_name -> dbservice.ChecksumsResponse.ChecksumsEntry
+	137, // 40: dbservice.StorageBlob.created_at:type_name -> google.protobuf.Timestamp
+	137, // 41: dbservice.StorageBlob.released_at:type_name -> google.protobuf.Timestamp
+	27,  // 42: dbservice.DuplicateGroup.files:type_name -> dbservice.File
+	72,  // 43: dbservice.FindDuplicatesResponse.groups:type_name -> dbservice.DuplicateGroup
+	69,  // 44: dbservice.ListStorageBlobsResponse.blobs:type_name -> dbservice.StorageBlob
+	137, // 45: dbservice.Group.created_at:type_name -> google.protobuf.Timestamp
+	137, // 46: dbservice.Group.updated_at:type_name -> google.protobuf.Timestamp
+	78,  // 47: dbservice.UpdateGroupRequest.group:type_name -> dbservice.Group
+	78,  // 48: dbservice.ListGroupsResponse.groups:type_name -> dbservice.Group
+	137, // 49: dbservice.GroupMember.created_at:type_name -> google.protobuf.Timestamp
+	84,  // 50: dbservice.AddGroupMemberRequest.member:type_name -> dbservice.GroupMember
+	84,  // 51: dbservice.ListGroupMembersResponse.members:type_name -> dbservice.GroupMember
+	2,   // 52: dbservice.ShareLink.role:type_name -> dbservice.PermissionRole
+	137, // 53: dbservice.ShareLink.created_at:type_name -> google.protobuf.Timestamp
+	137, // 54: dbservice.ShareLink.expires_at:type_name -> google.protobuf.Timestamp
+	137, // 55: dbservice.ShareLink.last_used_at:type_name -> google.protobuf.Timestamp
+	137, // 56: dbservice.ShareLink.revoked_at:type_name -> google.protobuf.Timestamp
+	88,  // 57: dbservice.ListShareLinksResponse.links:type_name -> dbservice.ShareLink
+	4,   // 58: dbservice.ResolveShareLinkResponse.status:type_name -> dbservice.ShareLinkStatus
+	2,   // 59: dbservice.ResolveShareLinkResponse.role:type_name -> dbservice.PermissionRole
+	137, // 60: dbservice.Session.created_at:type_name -> google.protobuf.Timestamp
+	137, // 61: dbservice.Session.last_used_at:type_name -> google.protobuf.Timestamp
+	137, // 62: dbservice.Session.expires_at:type_name -> google.protobuf.Timestamp
+	137, // 63: dbservice.Session.revoked_at:type_name -> google.protobuf.Timestamp
+	137, // 64: dbservice.CreateSessionRequest.expires_at:type_name -> google.protobuf.Timestamp
+	94,  // 65: dbservice.SessionTokenResponse.session:type_name -> dbservice.Session
+	5,   // 66: dbservice.RotateSessionResponse.status:type_name -> dbservice.RotateSessionStatus
+	94,  // 67: dbservice.RotateSessionResponse.session:type_name -> dbservice.Session
+	94,  // 68: dbservice.ListSessionsResponse.sessions:type_name -> dbservice.Session
+	137, // 69: dbservice.WebAuthnCredential.created_at:type_name -> google.protobuf.Timestamp
+	137, // 70: dbservice.WebAuthnCredential.last_used_at:type_name -> google.protobuf.Timestamp
+	109, // 71: dbservice.ListWebAuthnCredentialsResponse.credentials:type_name -> dbservice.WebAuthnCredential
+	6,   // 72: dbservice.UpdateWebAuthnSignCountResponse.status:type_name -> dbservice.WebAuthnSignCountStatus
+	109, // 73: dbservice.UpdateWebAuthnSignCountResponse.credential:type_name -> dbservice.WebAuthnCredential
+	137, // 74: dbservice.UserIdentity.linked_at:type_name -> google.protobuf.Timestamp
+	116, // 75: dbservice.ListIdentitiesResponse.identities:type_name -> dbservice.UserIdentity
+	137, // 76: dbservice.AccountDeletion.deactivated_at:type_name -> google.protobuf.Timestamp
+	137, // 77: dbservice.AccountDeletion.erase_after:type_name -> google.protobuf.Timestamp
+	120, // 78: dbservice.ListPendingErasuresResponse.deletions:type_name -> dbservice.AccountDeletion
+	137, // 79: dbservice.ListUsersRequest.created_after:type_name -> google.protobuf.Timestamp
+	137, // 80: dbservice.ListUsersRequest.created_before:type_name -> google.protobuf.Timestamp
+	137, // 81: dbservice.ListUsersRequest.last_login_after:type_name -> google.protobuf.Timestamp
+	137, // 82: dbservice.ListUsersRequest.last_login_before:type_name -> google.protobuf.Timestamp
+	7,   // 83: dbservice.ListUsersRequest.sort_by:type_name -> dbservice.UserSortField
+	9,   // 84: dbservice.ListUsersResponse.users:type_name -> dbservice.User
+	10,  // 85: dbservice.ListUsersResponse.extended_info:type_name -> dbservice.UserExtendedInfo
+	137, // 86: dbservice.PasswordHistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	126, // 87: dbservice.PasswordHistoryResponse.entries:type_name -> dbservice.PasswordHistoryEntry
+	139, // 88: dbservice.Preferences.preferences:type_name -> google.protobuf.Struct
+	139, // 89: dbservice.SetPreferencesRequest.preferences:type_name -> google.protobuf.Struct
+	139, // 90: dbservice.PatchPreferencesRequest.patch:type_name -> google.protobuf.Struct
+	8,   // 91: dbservice.SecurityEvent.event_type:type_name -> dbservice.SecurityEventType
+	139, // 92: dbservice.SecurityEvent.details:type_name -> google.protobuf.Struct
+	137, // 93: dbservice.SecurityEvent.created_at:type_name -> google.protobuf.Timestamp
+	131, // 94: dbservice.ListSecurityEventsResponse.events:type_name -> dbservice.SecurityEvent
+	9,   // 95: dbservice.DBService.CreateUser:input_type -> dbservice.User
+	11,  // 96: dbservice.DBService.GetUserByID:input_type -> dbservice.UserID
+	12,  // 97: dbservice.DBService.GetUserByEmail:input_type -> dbservice.EmailRequest
+	124, // 98: dbservice.DBService.ListUsers:input_type -> dbservice.ListUsersRequest
+	11,  // 99: dbservice.DBService.GetUserExtendedInfo:input_type -> dbservice.UserID
+	15,  // 100: dbservice.DBService.UpdateUser:input_type -> dbservice.UpdateUserRequest
+	14,  // 101: dbservice.DBService.UpdatePassword:input_type -> dbservice.UpdatePasswordRequest
+	11,  // 102: dbservice.DBService.GetPasswordHistory:input_type -> dbservice.UserID
+	11,  // 103: dbservice.DBService.GetPreferences:input_type -> dbservice.UserID
+	129, // 104: dbservice.DBService.SetPreferences:input_type -> dbservice.SetPreferencesRequest
+	130, // 105: dbservice.DBService.PatchPreferences:input_type -> dbservice.PatchPreferencesRequest
+	16,  // 106: dbservice.DBService.UpdateUsername:input_type -> dbservice.UpdateUsernameRequest
+	17,  // 107: dbservice.DBService.UpdateEmailVerification:input_type -> dbservice.UpdateEmailVerificationRequest
+	11,  // 108: dbservice.DBService.UpdateLastLogin:input_type -> dbservice.UserID
+	18,  // 109: dbservice.DBService.UpdateFailedLoginAttempts:input_type -> dbservice.UpdateFailedLoginAttemptsRequest
+	19,  // 110: dbservice.DBService.UpdateLockedUntil:input_type -> dbservice.UpdateLockedUntilRequest
+	11,  // 111: dbservice.DBService.RecordFailedLogin:input_type -> dbservice.UserID
+	11,  // 112: dbservice.DBService.RecordSuccessfulLogin:input_type -> dbservice.UserID
+	21,  // 113: dbservice.DBService.IssueToken:input_type -> dbservice.IssueTokenRequest
+	23,  // 114: dbservice.DBService.ConsumeToken:input_type -> dbservice.ConsumeTokenRequest
+	11,  // 115: dbservice.DBService.DeactivateUser:input_type -> dbservice.UserID
+	11,  // 116: dbservice.DBService.CancelUserDeletion:input_type -> dbservice.UserID
+	121, // 117: dbservice.DBService.ListPendingErasures:input_type -> dbservice.ListPendingErasuresRequest
+	11,  // 118: dbservice.DBService.EraseUser:input_type -> dbservice.UserID
+	25,  // 119: dbservice.DBService.UpdateStorageUsage:input_type -> dbservice.UpdateStorageUsageRequest
+	12,  // 120: dbservice.DBService.CheckEmailExists:input_type -> dbservice.EmailRequest
+	13,  // 121: dbservice.DBService.CheckUsernameExists:input_type -> dbservice.UsernameRequest
+	27,  // 122: dbservice.DBService.CreateFile:input_type -> dbservice.File
+	28,  // 123: dbservice.DBService.GetFileByID:input_type -> dbservice.FileID
+	29,  // 124: dbservice.DBService.GetFileByPath:input_type -> dbservice.GetFileByPathRequest
+	37,  // 125: dbservice.DBService.UpdateFile:input_type -> dbservice.UpdateFileRequest
+	28,  // 126: dbservice.DBService.DeleteFile:input_type -> dbservice.FileID
+	28,  // 127: dbservice.DBService.SoftDeleteFile:input_type -> dbservice.FileID
+	28,  // 128: dbservice.DBService.RestoreFile:input_type -> dbservice.FileID
+	30,  // 129: dbservice.DBService.ListFiles:input_type -> dbservice.ListFilesRequest
+	32,  // 130: dbservice.DBService.ListFilesByParent:input_type -> dbservice.ListFilesByParentRequest
+	33,  // 131: dbservice.DBService.ListStarredFiles:input_type -> dbservice.ListStarredFilesRequest
+	34,  // 132: dbservice.DBService.ListTrashedFiles:input_type -> dbservice.ListTrashedFilesRequest
+	35,  // 133: dbservice.DBService.SearchFiles:input_type -> dbservice.SearchFilesRequest
+	28,  // 134: dbservice.DBService.GetFileSize:input_type -> dbservice.FileID
+	38,  // 135: dbservice.DBService.UpdateFileSize:input_type -> dbservice.UpdateFileSizeRequest
+	28,  // 136: dbservice.DBService.UpdateLastViewed:input_type -> dbservice.FileID
+	39,  // 137: dbservice.DBService.GetFileTree:input_type -> dbservice.GetFileTreeRequest
+	40,  // 138: dbservice.DBService.CreateRevision:input_type -> dbservice.FileRevision
+	28,  // 139: dbservice.DBService.GetRevisions:input_type -> dbservice.FileID
+	43,  // 140: dbservice.DBService.GetRevision:input_type -> dbservice.GetRevisionRequest
+	41,  // 141: dbservice.DBService.DeleteRevision:input_type -> dbservice.RevisionID
+	44,  // 142: dbservice.DBService.SetRevisionLabel:input_type -> dbservice.SetRevisionLabelRequest
+	43,  // 143: dbservice.DBService.ClearRevisionLabel:input_type -> dbservice.GetRevisionRequest
+	45,  // 144: dbservice.DBService.GetRevisionByLabel:input_type -> dbservice.GetRevisionByLabelRequest
+	46,  // 145: dbservice.DBService.PruneRevisions:input_type -> dbservice.PruneRevisionsRequest
+	49,  // 146: dbservice.DBService.CreatePermission:input_type -> dbservice.CreatePermissionRequest
+	28,  // 147: dbservice.DBService.GetPermissions:input_type -> dbservice.FileID
+	50,  // 148: dbservice.DBService.UpdatePermission:input_type -> dbservice.UpdatePermissionRequest
+	51,  // 149: dbservice.DBService.DeletePermission:input_type -> dbservice.DeletePermissionRequest
+	53,  // 150: dbservice.DBService.CheckPermission:input_type -> dbservice.CheckPermissionRequest
+	55,  // 151: dbservice.DBService.CheckPermissions:input_type -> dbservice.CheckPermissionsRequest
+	57,  // 152: dbservice.DBService.GetEffectivePermission:input_type -> dbservice.GetEffectivePermissionRequest
+	60,  // 153: dbservice.DBService.UpdateFileMetadata:input_type -> dbservice.UpdateFileMetadataRequest
+	28,  // 154: dbservice.DBService.GetFileMetadata:input_type -> dbservice.FileID
+	28,  // 155: dbservice.DBService.StarFile:input_type -> dbservice.FileID
+	28,  // 156: dbservice.DBService.UnstarFile:input_type -> dbservice.FileID
+	62,  // 157: dbservice.DBService.MoveFile:input_type -> dbservice.MoveFileRequest
+	63,  // 158: dbservice.DBService.CopyFile:input_type -> dbservice.CopyFileRequest
+	64,  // 159: dbservice.DBService.RenameFile:input_type -> dbservice.RenameFileRequest
+	65,  // 160: dbservice.DBService.TransferOwnership:input_type -> dbservice.TransferOwnershipRequest
+	28,  // 161: dbservice.DBService.VerifyFileIntegrity:input_type -> dbservice.FileID
+	28,  // 162: dbservice.DBService.CalculateFileChecksums:input_type -> dbservice.FileID
+	70,  // 163: dbservice.DBService.FindBySHA256:input_type -> dbservice.FindBySHA256Request
+	71,  // 164: dbservice.DBService.FindDuplicates:input_type -> dbservice.FindDuplicatesRequest
+	74,  // 165: dbservice.DBService.GetStorageBlob:input_type -> dbservice.StoragePathRequest
+	75,  // 166: dbservice.DBService.ListUnreferencedBlobs:input_type -> dbservice.ListUnreferencedBlobsRequest
+	74,  // 167: dbservice.DBService.ReleaseBlob:input_type -> dbservice.StoragePathRequest
+	78,  // 168: dbservice.DBService.CreateGroup:input_type -> dbservice.Group
+	79,  // 169: dbservice.DBService.GetGroup:input_type -> dbservice.GroupID
+	80,  // 170: dbservice.DBService.UpdateGroup:input_type -> dbservice.UpdateGroupRequest
+	81,  // 171: dbservice.DBService.DeleteGroup:input_type -> dbservice.DeleteGroupRequest
+	82,  // 172: dbservice.DBService.ListGroups:input_type -> dbservice.ListGroupsRequest
+	85,  // 173: dbservice.DBService.AddGroupMember:input_type -> dbservice.AddGroupMemberRequest
+	86,  // 174: dbservice.DBService.RemoveGroupMember:input_type -> dbservice.RemoveGroupMemberRequest
+	79,  // 175: dbservice.DBService.ListGroupMembers:input_type -> dbservice.GroupID
+	88,  // 176: dbservice.DBService.CreateShareLink:input_type -> dbservice.ShareLink
+	28,  // 177: dbservice.DBService.ListShareLinks:input_type -> dbservice.FileID
+	89,  // 178: dbservice.DBService.RevokeShareLink:input_type -> dbservice.ShareLinkID
+	92,  // 179: dbservice.DBService.ResolveShareLink:input_type -> dbservice.ResolveShareLinkRequest
+	96,  // 180: dbservice.DBService.CreateSession:input_type -> dbservice.CreateSessionRequest
+	98,  // 181: dbservice.DBService.RotateSession:input_type -> dbservice.RotateSessionRequest
+	11,  // 182: dbservice.DBService.ListSessions:input_type -> dbservice.UserID
+	95,  // 183: dbservice.DBService.RevokeSession:input_type -> dbservice.SessionID
+	101, // 184: dbservice.DBService.RevokeAllSessions:input_type -> dbservice.RevokeAllSessionsRequest
+	11,  // 185: dbservice.DBService.EnrollTwoFactor:input_type -> dbservice.UserID
+	104, // 186: dbservice.DBService.ConfirmTwoFactor:input_type -> dbservice.TwoFactorCodeRequest
+	104, // 187: dbservice.DBService.VerifyTwoFactor:input_type -> dbservice.TwoFactorCodeRequest
+	104, // 188: dbservice.DBService.ConsumeRecoveryCode:input_type -> dbservice.TwoFactorCodeRequest
+	104, // 189: dbservice.DBService.RegenerateRecoveryCodes:input_type -> dbservice.TwoFactorCodeRequest
+	109, // 190: dbservice.DBService.RegisterWebAuthnCredential:input_type -> dbservice.WebAuthnCredential
+	111, // 191: dbservice.DBService.GetWebAuthnCredential:input_type -> dbservice.GetWebAuthnCredentialRequest
+	11,  // 192: dbservice.DBService.ListWebAuthnCredentials:input_type -> dbservice.UserID
+	113, // 193: dbservice.DBService.RenameWebAuthnCredential:input_type -> dbservice.RenameWebAuthnCredentialRequest
+	110, // 194: dbservice.DBService.DeleteWebAuthnCredential:input_type -> dbservice.WebAuthnCredentialID
+	114, // 195: dbservice.DBService.UpdateWebAuthnSignCount:input_type -> dbservice.UpdateWebAuthnSignCountRequest
+	116, // 196: dbservice.DBService.LinkIdentity:input_type -> dbservice.UserIdentity
+	118, // 197: dbservice.DBService.UnlinkIdentity:input_type -> dbservice.UnlinkIdentityRequest
+	11,  // 198: dbservice.DBService.ListIdentities:input_type -> dbservice.UserID
+	117, // 199: dbservice.DBService.GetUserByIdentity:input_type -> dbservice.IdentityKey
+	132, // 200: dbservice.DBService.ListSecurityEvents:input_type -> dbservice.ListSecurityEventsRequest
+	11,  // 201: dbservice.DBService.CreateUser:output_type -> dbservice.UserID
+	9,   // 202: dbservice.DBService.GetUserByID:output_type -> dbservice.User
+	9,   // 203: dbservice.DBService.GetUserByEmail:output_type -> dbservice.User
+	125, // 204: dbservice.DBService.ListUsers:output_type -> dbservice.ListUsersResponse
+	10,  // 205: dbservice.DBService.GetUserExtendedInfo:output_type -> dbservice.UserExtendedInfo
+	140, // 206: dbservice.DBService.UpdateUser:output_type -> google.protobuf.Empty
+	140, // 207: dbservice.DBService.UpdatePassword:output_type -> google.protobuf.Empty
+	127, // 208: dbservice.DBService.GetPasswordHistory:output_type -> dbservice.PasswordHistoryResponse
+	128, // 209: dbservice.DBService.GetPreferences:output_type -> dbservice.Preferences
+	128, // 210: dbservice.DBService.SetPreferences:output_type -> dbservice.Preferences
+	128, // 211: dbservice.DBService.PatchPreferences:output_type -> dbservice.Preferences
+	140, // 212: dbservice.DBService.UpdateUsername:output_type -> google.protobuf.Empty
+	140, // 213: dbservice.DBService.UpdateEmailVerification:output_type -> google.protobuf.Empty
+	140, // 214: dbservice.DBService.UpdateLastLogin:output_type -> google.protobuf.Empty
+	140, // 215: dbservice.DBService.UpdateFailedLoginAttempts:output_type -> google.protobuf.Empty
+	140, // 216: dbservice.DBService.UpdateLockedUntil:output_type -> google.protobuf.Empty
+	20,  // 217: dbservice.DBService.RecordFailedLogin:output_type -> dbservice.LoginState
+	20,  // 218: dbservice.DBService.RecordSuccessfulLogin:output_type -> dbservice.LoginState
+	22,  // 219: dbservice.DBService.IssueToken:output_type -> dbservice.IssueTokenResponse
+	24,  // 220: dbservice.DBService.ConsumeToken:output_type -> dbservice.ConsumeTokenResponse
+	120, // 221: dbservice.DBService.DeactivateUser:output_type -> dbservice.AccountDeletion
+	140, // 222: dbservice.DBService.CancelUserDeletion:output_type -> google.protobuf.Empty
+	122, // 223: dbservice.DBService.ListPendingErasures:output_type -> dbservice.ListPendingErasuresResponse
+	123, // 224: dbservice.DBService.EraseUser:output_type -> dbservice.ErasureResult
+	140, // 225: dbservice.DBService.UpdateStorageUsage:output_type -> google.protobuf.Empty
+	26,  // 226: dbservice.DBService.CheckEmailExists:output_type -> dbservice.ExistsResponse
+	26,  // 227: dbservice.DBService.CheckUsernameExists:output_type -> dbservice.ExistsResponse
+	28,  // 228: dbservice.DBService.CreateFile:output_type -> dbservice.FileID
+	27,  // 229: dbservice.DBService.GetFileByID:output_type -> dbservice.File
+	27,  // 230: dbservice.DBService.GetFileByPath:output_type -> dbservice.File
+	140, // 231: dbservice.DBService.UpdateFile:output_type -> google.protobuf.Empty
+	140, // 232: dbservice.DBService.DeleteFile:output_type -> google.protobuf.Empty
+	140, // 233: dbservice.DBService.SoftDeleteFile:output_type -> google.protobuf.Empty
+	140, // 234: dbservice.DBService.RestoreFile:output_type -> google.protobuf.Empty
+	31,  // 235: dbservice.DBService.ListFiles:output_type -> dbservice.ListFilesResponse
+	31,  // 236: dbservice.DBService.ListFilesByParent:output_type -> dbservice.ListFilesResponse
+	31,  // 237: dbservice.DBService.ListStarredFiles:output_type -> dbservice.ListFilesResponse
+	31,  // 238: dbservice.DBService.ListTrashedFiles:output_type -> dbservice.ListFilesResponse
+	31,  // 239: dbservice.DBService.SearchFiles:output_type -> dbservice.ListFilesResponse
+	36,  // 240: dbservice.DBService.GetFileSize:output_type -> dbservice.FileSizeResponse
+	140, // 241: dbservice.DBService.UpdateFileSize:output_type -> google.protobuf.Empty
+	140, // 242: dbservice.DBService.UpdateLastViewed:output_type -> google.protobuf.Empty
+	31,  // 243: dbservice.DBService.GetFileTree:output_type -> dbservice.ListFilesResponse
+	41,  // 244: dbservice.DBService.CreateRevision:output_type -> dbservice.RevisionID
+	42,  // 245: dbservice.DBService.GetRevisions:output_type -> dbservice.ListRevisionsResponse
+	40,  // 246: dbservice.DBService.GetRevision:output_type -> dbservice.FileRevision
+	140, // 247: dbservice.DBService.DeleteRevision:output_type -> google.protobuf.Empty
+	40,  // 248: dbservice.DBService.SetRevisionLabel:output_type -> dbservice.FileRevision
+	140, // 249: dbservice.DBService.ClearRevisionLabel:output_type -> google.protobuf.Empty
+	40,  // 250: dbservice.DBService.GetRevisionByLabel:output_type -> dbservice.FileRevision
+	42,  // 251: dbservice.DBService.PruneRevisions:output_type -> dbservice.ListRevisionsResponse
+	48,  // 252: dbservice.DBService.CreatePermission:output_type -> dbservice.PermissionID
+	52,  // 253: dbservice.DBService.GetPermissions:output_type -> dbservice.ListPermissionsResponse
+	140, // 254: dbservice.DBService.UpdatePermission:output_type -> google.protobuf.Empty
+	140, // 255: dbservice.DBService.DeletePermission:output_type -> google.protobuf.Empty
+	54,  // 256: dbservice.DBService.CheckPermission:output_type -> dbservice.PermissionResponse
+	56,  // 257: dbservice.DBService.CheckPermissions:output_type -> dbservice.CheckPermissionsResponse
+	59,  // 258: dbservice.DBService.GetEffectivePermission:output_type -> dbservice.EffectivePermission
+	140, // 259: dbservice.DBService.UpdateFileMetadata:output_type -> google.protobuf.Empty
+	61,  // 260: dbservice.DBService.GetFileMetadata:output_type -> dbservice.FileMetadataResponse
+	140, // 261: dbservice.DBService.StarFile:output_type -> google.protobuf.Empty
+	140, // 262: dbservice.DBService.UnstarFile:output_type -> google.protobuf.Empty
+	140, // 263: dbservice.DBService.MoveFile:output_type -> google.protobuf.Empty
+	27,  // 264: dbservice.DBService.CopyFile:output_type -> dbservice.File
+	140, // 265: dbservice.DBService.RenameFile:output_type -> google.protobuf.Empty
+	66,  // 266: dbservice.DBService.TransferOwnership:output_type -> dbservice.TransferOwnershipResponse
+	67,  // 267: dbservice.DBService.VerifyFileIntegrity:output_type -> dbservice.IntegrityResponse
+	68,  // 268: dbservice.DBService.CalculateFileChecksums:output_type -> dbservice.ChecksumsResponse
+	69,  // 269: dbservice.DBService.FindBySHA256:output_type -> dbservice.StorageBlob
+	73,  // 270: dbservice.DBService.FindDuplicates:output_type -> dbservice.FindDuplicatesResponse
+	69,  // 271: dbservice.DBService.GetStorageBlob:output_type -> dbservice.StorageBlob
+	76,  // 272: dbservice.DBService.ListUnreferencedBlobs:output_type -> dbservice.ListStorageBlobsResponse
+	77,  // 273: dbservice.DBService.ReleaseBlob:output_type -> dbservice.ReleaseBlobResponse
+	79,  // 274: dbservice.DBService.CreateGroup:output_type -> dbservice.GroupID
+	78,  // 275: dbservice.DBService.GetGroup:output_type -> dbservice.Group
+	140, // 276: dbservice.DBService.UpdateGroup:output_type -> google.protobuf.Empty
+	140, // 277: dbservice.DBService.DeleteGroup:output_type -> google.protobuf.Empty
+	83,  // 278: dbservice.DBService.ListGroups:output_type -> dbservice.ListGroupsResponse
+	140, // 279: dbservice.DBService.AddGroupMember:output_type -> google.protobuf.Empty
+	140, // 280: dbservice.DBService.RemoveGroupMember:output_type -> google.protobuf.Empty
+	87,  // 281: dbservice.DBService.ListGroupMembers:output_type -> dbservice.ListGroupMembersResponse
+	90,  // 282: dbservice.DBService.CreateShareLink:output_type -> dbservice.CreateShareLinkResponse
+	91,  // 283: dbservice.DBService.ListShareLinks:output_type -> dbservice.ListShareLinksResponse
+	140, // 284: dbservice.DBService.RevokeShareLink:output_type -> google.protobuf.Empty
+	93,  // 285: dbservice.DBService.ResolveShareLink:output_type -> dbservice.ResolveShareLinkResponse
+	97,  // 286: dbservice.DBService.CreateSession:output_type -> dbservice.SessionTokenResponse
+	99,  // 287: dbservice.DBService.RotateSession:output_type -> dbservice.RotateSessionResponse
+	100, // 288: dbservice.DBService.ListSessions:output_type -> dbservice.ListSessionsResponse
+	140, // 289: dbservice.DBService.RevokeSession:output_type -> google.protobuf.Empty
+	102, // 290: dbservice.DBService.RevokeAllSessions:output_type -> dbservice.RevokeAllSessionsResponse
+	103, // 291: dbservice.DBService.EnrollTwoFactor:output_type -> dbservice.EnrollTwoFactorResponse
+	105, // 292: dbservice.DBService.ConfirmTwoFactor:output_type -> dbservice.ConfirmTwoFactorResponse
+	106, // 293: dbservice.DBService.VerifyTwoFactor:output_type -> dbservice.VerifyTwoFactorResponse
+	107, // 294: dbservice.DBService.ConsumeRecoveryCode:output_type -> dbservice.ConsumeRecoveryCodeResponse
+	108, // 295: dbservice.DBService.RegenerateRecoveryCodes:output_type -> dbservice.RegenerateRecoveryCodesResponse
+	109, // 296: dbservice.DBService.RegisterWebAuthnCredential:output_type -> dbservice.WebAuthnCredential
+	109, // 297: dbservice.DBService.GetWebAuthnCredential:output_type -> dbservice.WebAuthnCredential
+	112, // 298: dbservice.DBService.ListWebAuthnCredentials:output_type -> dbservice.ListWebAuthnCredentialsResponse
+	109, // 299: dbservice.DBService.RenameWebAuthnCredential:output_type -> dbservice.WebAuthnCredential
+	140, // 300: dbservice.DBService.DeleteWebAuthnCredential:output_type -> google.protobuf.Empty
+	115, // 301: dbservice.DBService.UpdateWebAuthnSignCount:output_type -> dbservice.UpdateWebAuthnSignCountResponse
+	116, // 302: dbservice.DBService.LinkIdentity:output_type -> dbservice.UserIdentity
+	140, // 303: dbservice.DBService.UnlinkIdentity:output_type -> google.protobuf.Empty
+	119, // 304: dbservice.DBService.ListIdentities:output_type -> dbservice.ListIdentitiesResponse
+	9,   // 305: dbservice.DBService.GetUserByIdentity:output_type -> dbservice.User
+	133, // 306: dbservice.DBService.ListSecurityEvents:output_type -> dbservice.ListSecurityEventsResponse
+	201, // [201:307] is the sub-list for method output_type
+	95,  // [95:201] is the sub-list for method input_type
+	95,  // [95:95] is the sub-list for extension type_name
+	95,  // [95:95] is the sub-list for extension extendee
+	0,   // [0:95] is the sub-list for field type_name
 }
 
 func init() { file_internal_transport_grpc_protos_db_manager_proto_init() }
@@ -2978,18 +9541,21 @@ func file_internal_transport_grpc_protos_db_manager_proto_init() {
 	if File_internal_transport_grpc_protos_db_manager_proto != nil {
 		return
 	}
+	file_internal_transport_grpc_protos_db_manager_proto_msgTypes[115].OneofWrappers = []any{}
+	file_internal_transport_grpc_protos_db_manager_proto_msgTypes[122].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_transport_grpc_protos_db_manager_proto_rawDesc), len(file_internal_transport_grpc_protos_db_manager_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   42,
+			NumEnums:      9,
+			NumMessages:   128,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_internal_transport_grpc_protos_db_manager_proto_goTypes,
 		DependencyIndexes: file_internal_transport_grpc_protos_db_manager_proto_depIdxs,
+		EnumInfos:         file_internal_transport_grpc_protos_db_manager_proto_enumTypes,
 		MessageInfos:      file_internal_transport_grpc_protos_db_manager_proto_msgTypes,
 	}.Build()
 	File_internal_transport_grpc_protos_db_manager_proto = out.File
@@ -20,54 +20,112 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DBService_CreateUser_FullMethodName                = "/dbservice.DBService/CreateUser"
-	DBService_GetUserByID_FullMethodName               = "/dbservice.DBService/GetUserByID"
-	DBService_GetUserByEmail_FullMethodName            = "/dbservice.DBService/GetUserByEmail"
-	DBService_GetUserExtendedInfo_FullMethodName       = "/dbservice.DBService/GetUserExtendedInfo"
-	DBService_UpdateUser_FullMethodName                = "/dbservice.DBService/UpdateUser"
-	DBService_UpdatePassword_FullMethodName            = "/dbservice.DBService/UpdatePassword"
-	DBService_UpdateUsername_FullMethodName            = "/dbservice.DBService/UpdateUsername"
-	DBService_UpdateEmailVerification_FullMethodName   = "/dbservice.DBService/UpdateEmailVerification"
-	DBService_UpdateLastLogin_FullMethodName           = "/dbservice.DBService/UpdateLastLogin"
-	DBService_UpdateFailedLoginAttempts_FullMethodName = "/dbservice.DBService/UpdateFailedLoginAttempts"
-	DBService_UpdateLockedUntil_FullMethodName         = "/dbservice.DBService/UpdateLockedUntil"
-	DBService_UpdateStorageUsage_FullMethodName        = "/dbservice.DBService/UpdateStorageUsage"
-	DBService_CheckEmailExists_FullMethodName          = "/dbservice.DBService/CheckEmailExists"
-	DBService_CheckUsernameExists_FullMethodName       = "/dbservice.DBService/CheckUsernameExists"
-	DBService_CreateFile_FullMethodName                = "/dbservice.DBService/CreateFile"
-	DBService_GetFileByID_FullMethodName               = "/dbservice.DBService/GetFileByID"
-	DBService_GetFileByPath_FullMethodName             = "/dbservice.DBService/GetFileByPath"
-	DBService_UpdateFile_FullMethodName                = "/dbservice.DBService/UpdateFile"
-	DBService_DeleteFile_FullMethodName                = "/dbservice.DBService/DeleteFile"
-	DBService_SoftDeleteFile_FullMethodName            = "/dbservice.DBService/SoftDeleteFile"
-	DBService_RestoreFile_FullMethodName               = "/dbservice.DBService/RestoreFile"
-	DBService_ListFiles_FullMethodName                 = "/dbservice.DBService/ListFiles"
-	DBService_ListFilesByParent_FullMethodName         = "/dbservice.DBService/ListFilesByParent"
-	DBService_ListStarredFiles_FullMethodName          = "/dbservice.DBService/ListStarredFiles"
-	DBService_ListTrashedFiles_FullMethodName          = "/dbservice.DBService/ListTrashedFiles"
-	DBService_SearchFiles_FullMethodName               = "/dbservice.DBService/SearchFiles"
-	DBService_GetFileSize_FullMethodName               = "/dbservice.DBService/GetFileSize"
-	DBService_UpdateFileSize_FullMethodName            = "/dbservice.DBService/UpdateFileSize"
-	DBService_UpdateLastViewed_FullMethodName          = "/dbservice.DBService/UpdateLastViewed"
-	DBService_GetFileTree_FullMethodName               = "/dbservice.DBService/GetFileTree"
-	DBService_CreateRevision_FullMethodName            = "/dbservice.DBService/CreateRevision"
-	DBService_GetRevisions_FullMethodName              = "/dbservice.DBService/GetRevisions"
-	DBService_GetRevision_FullMethodName               = "/dbservice.DBService/GetRevision"
-	DBService_DeleteRevision_FullMethodName            = "/dbservice.DBService/DeleteRevision"
-	DBService_CreatePermission_FullMethodName          = "/dbservice.DBService/CreatePermission"
-	DBService_GetPermissions_FullMethodName            = "/dbservice.DBService/GetPermissions"
-	DBService_UpdatePermission_FullMethodName          = "/dbservice.DBService/UpdatePermission"
-	DBService_DeletePermission_FullMethodName          = "/dbservice.DBService/DeletePermission"
-	DBService_CheckPermission_FullMethodName           = "/dbservice.DBService/CheckPermission"
-	DBService_UpdateFileMetadata_FullMethodName        = "/dbservice.DBService/UpdateFileMetadata"
-	DBService_GetFileMetadata_FullMethodName           = "/dbservice.DBService/GetFileMetadata"
-	DBService_StarFile_FullMethodName                  = "/dbservice.DBService/StarFile"
-	DBService_UnstarFile_FullMethodName                = "/dbservice.DBService/UnstarFile"
-	DBService_MoveFile_FullMethodName                  = "/dbservice.DBService/MoveFile"
-	DBService_CopyFile_FullMethodName                  = "/dbservice.DBService/CopyFile"
-	DBService_RenameFile_FullMethodName                = "/dbservice.DBService/RenameFile"
-	DBService_VerifyFileIntegrity_FullMethodName       = "/dbservice.DBService/VerifyFileIntegrity"
-	DBService_CalculateFileChecksums_FullMethodName    = "/dbservice.DBService/CalculateFileChecksums"
+	DBService_CreateUser_FullMethodName                 = "/dbservice.DBService/CreateUser"
+	DBService_GetUserByID_FullMethodName                = "/dbservice.DBService/GetUserByID"
+	DBService_GetUserByEmail_FullMethodName             = "/dbservice.DBService/GetUserByEmail"
+	DBService_ListUsers_FullMethodName                  = "/dbservice.DBService/ListUsers"
+	DBService_GetUserExtendedInfo_FullMethodName        = "/dbservice.DBService/GetUserExtendedInfo"
+	DBService_UpdateUser_FullMethodName                 = "/dbservice.DBService/UpdateUser"
+	DBService_UpdatePassword_FullMethodName             = "/dbservice.DBService/UpdatePassword"
+	DBService_GetPasswordHistory_FullMethodName         = "/dbservice.DBService/GetPasswordHistory"
+	DBService_GetPreferences_FullMethodName             = "/dbservice.DBService/GetPreferences"
+	DBService_SetPreferences_FullMethodName             = "/dbservice.DBService/SetPreferences"
+	DBService_PatchPreferences_FullMethodName           = "/dbservice.DBService/PatchPreferences"
+	DBService_UpdateUsername_FullMethodName             = "/dbservice.DBService/UpdateUsername"
+	DBService_UpdateEmailVerification_FullMethodName    = "/dbservice.DBService/UpdateEmailVerification"
+	DBService_UpdateLastLogin_FullMethodName            = "/dbservice.DBService/UpdateLastLogin"
+	DBService_UpdateFailedLoginAttempts_FullMethodName  = "/dbservice.DBService/UpdateFailedLoginAttempts"
+	DBService_UpdateLockedUntil_FullMethodName          = "/dbservice.DBService/UpdateLockedUntil"
+	DBService_RecordFailedLogin_FullMethodName          = "/dbservice.DBService/RecordFailedLogin"
+	DBService_RecordSuccessfulLogin_FullMethodName      = "/dbservice.DBService/RecordSuccessfulLogin"
+	DBService_IssueToken_FullMethodName                 = "/dbservice.DBService/IssueToken"
+	DBService_ConsumeToken_FullMethodName               = "/dbservice.DBService/ConsumeToken"
+	DBService_DeactivateUser_FullMethodName             = "/dbservice.DBService/DeactivateUser"
+	DBService_CancelUserDeletion_FullMethodName         = "/dbservice.DBService/CancelUserDeletion"
+	DBService_ListPendingErasures_FullMethodName        = "/dbservice.DBService/ListPendingErasures"
+	DBService_EraseUser_FullMethodName                  = "/dbservice.DBService/EraseUser"
+	DBService_UpdateStorageUsage_FullMethodName         = "/dbservice.DBService/UpdateStorageUsage"
+	DBService_CheckEmailExists_FullMethodName           = "/dbservice.DBService/CheckEmailExists"
+	DBService_CheckUsernameExists_FullMethodName        = "/dbservice.DBService/CheckUsernameExists"
+	DBService_CreateFile_FullMethodName                 = "/dbservice.DBService/CreateFile"
+	DBService_GetFileByID_FullMethodName                = "/dbservice.DBService/GetFileByID"
+	DBService_GetFileByPath_FullMethodName              = "/dbservice.DBService/GetFileByPath"
+	DBService_UpdateFile_FullMethodName                 = "/dbservice.DBService/UpdateFile"
+	DBService_DeleteFile_FullMethodName                 = "/dbservice.DBService/DeleteFile"
+	DBService_SoftDeleteFile_FullMethodName             = "/dbservice.DBService/SoftDeleteFile"
+	DBService_RestoreFile_FullMethodName                = "/dbservice.DBService/RestoreFile"
+	DBService_ListFiles_FullMethodName                  = "/dbservice.DBService/ListFiles"
+	DBService_ListFilesByParent_FullMethodName          = "/dbservice.DBService/ListFilesByParent"
+	DBService_ListStarredFiles_FullMethodName           = "/dbservice.DBService/ListStarredFiles"
+	DBService_ListTrashedFiles_FullMethodName           = "/dbservice.DBService/ListTrashedFiles"
+	DBService_SearchFiles_FullMethodName                = "/dbservice.DBService/SearchFiles"
+	DBService_GetFileSize_FullMethodName                = "/dbservice.DBService/GetFileSize"
+	DBService_UpdateFileSize_FullMethodName             = "/dbservice.DBService/UpdateFileSize"
+	DBService_UpdateLastViewed_FullMethodName           = "/dbservice.DBService/UpdateLastViewed"
+	DBService_GetFileTree_FullMethodName                = "/dbservice.DBService/GetFileTree"
+	DBService_CreateRevision_FullMethodName             = "/dbservice.DBService/CreateRevision"
+	DBService_GetRevisions_FullMethodName               = "/dbservice.DBService/GetRevisions"
+	DBService_GetRevision_FullMethodName                = "/dbservice.DBService/GetRevision"
+	DBService_DeleteRevision_FullMethodName             = "/dbservice.DBService/DeleteRevision"
+	DBService_SetRevisionLabel_FullMethodName           = "/dbservice.DBService/SetRevisionLabel"
+	DBService_ClearRevisionLabel_FullMethodName         = "/dbservice.DBService/ClearRevisionLabel"
+	DBService_GetRevisionByLabel_FullMethodName         = "/dbservice.DBService/GetRevisionByLabel"
+	DBService_PruneRevisions_FullMethodName             = "/dbservice.DBService/PruneRevisions"
+	DBService_CreatePermission_FullMethodName           = "/dbservice.DBService/CreatePermission"
+	DBService_GetPermissions_FullMethodName             = "/dbservice.DBService/GetPermissions"
+	DBService_UpdatePermission_FullMethodName           = "/dbservice.DBService/UpdatePermission"
+	DBService_DeletePermission_FullMethodName           = "/dbservice.DBService/DeletePermission"
+	DBService_CheckPermission_FullMethodName            = "/dbservice.DBService/CheckPermission"
+	DBService_CheckPermissions_FullMethodName           = "/dbservice.DBService/CheckPermissions"
+	DBService_GetEffectivePermission_FullMethodName     = "/dbservice.DBService/GetEffectivePermission"
+	DBService_UpdateFileMetadata_FullMethodName         = "/dbservice.DBService/UpdateFileMetadata"
+	DBService_GetFileMetadata_FullMethodName            = "/dbservice.DBService/GetFileMetadata"
+	DBService_StarFile_FullMethodName                   = "/dbservice.DBService/StarFile"
+	DBService_UnstarFile_FullMethodName                 = "/dbservice.DBService/UnstarFile"
+	DBService_MoveFile_FullMethodName                   = "/dbservice.DBService/MoveFile"
+	DBService_CopyFile_FullMethodName                   = "/dbservice.DBService/CopyFile"
+	DBService_RenameFile_FullMethodName                 = "/dbservice.DBService/RenameFile"
+	DBService_TransferOwnership_FullMethodName          = "/dbservice.DBService/TransferOwnership"
+	DBService_VerifyFileIntegrity_FullMethodName        = "/dbservice.DBService/VerifyFileIntegrity"
+	DBService_CalculateFileChecksums_FullMethodName     = "/dbservice.DBService/CalculateFileChecksums"
+	DBService_FindBySHA256_FullMethodName               = "/dbservice.DBService/FindBySHA256"
+	DBService_FindDuplicates_FullMethodName             = "/dbservice.DBService/FindDuplicates"
+	DBService_GetStorageBlob_FullMethodName             = "/dbservice.DBService/GetStorageBlob"
+	DBService_ListUnreferencedBlobs_FullMethodName      = "/dbservice.DBService/ListUnreferencedBlobs"
+	DBService_ReleaseBlob_FullMethodName                = "/dbservice.DBService/ReleaseBlob"
+	DBService_CreateGroup_FullMethodName                = "/dbservice.DBService/CreateGroup"
+	DBService_GetGroup_FullMethodName                   = "/dbservice.DBService/GetGroup"
+	DBService_UpdateGroup_FullMethodName                = "/dbservice.DBService/UpdateGroup"
+	DBService_DeleteGroup_FullMethodName                = "/dbservice.DBService/DeleteGroup"
+	DBService_ListGroups_FullMethodName                 = "/dbservice.DBService/ListGroups"
+	DBService_AddGroupMember_FullMethodName             = "/dbservice.DBService/AddGroupMember"
+	DBService_RemoveGroupMember_FullMethodName          = "/dbservice.DBService/RemoveGroupMember"
+	DBService_ListGroupMembers_FullMethodName           = "/dbservice.DBService/ListGroupMembers"
+	DBService_CreateShareLink_FullMethodName            = "/dbservice.DBService/CreateShareLink"
+	DBService_ListShareLinks_FullMethodName             = "/dbservice.DBService/ListShareLinks"
+	DBService_RevokeShareLink_FullMethodName            = "/dbservice.DBService/RevokeShareLink"
+	DBService_ResolveShareLink_FullMethodName           = "/dbservice.DBService/ResolveShareLink"
+	DBService_CreateSession_FullMethodName              = "/dbservice.DBService/CreateSession"
+	DBService_RotateSession_FullMethodName              = "/dbservice.DBService/RotateSession"
+	DBService_ListSessions_FullMethodName               = "/dbservice.DBService/ListSessions"
+	DBService_RevokeSession_FullMethodName              = "/dbservice.DBService/RevokeSession"
+	DBService_RevokeAllSessions_FullMethodName          = "/dbservice.DBService/RevokeAllSessions"
+	DBService_EnrollTwoFactor_FullMethodName            = "/dbservice.DBService/EnrollTwoFactor"
+	DBService_ConfirmTwoFactor_FullMethodName           = "/dbservice.DBService/ConfirmTwoFactor"
+	DBService_VerifyTwoFactor_FullMethodName            = "/dbservice.DBService/VerifyTwoFactor"
+	DBService_ConsumeRecoveryCode_FullMethodName        = "/dbservice.DBService/ConsumeRecoveryCode"
+	DBService_RegenerateRecoveryCodes_FullMethodName    = "/dbservice.DBService/RegenerateRecoveryCodes"
+	DBService_RegisterWebAuthnCredential_FullMethodName = "/dbservice.DBService/RegisterWebAuthnCredential"
+	DBService_GetWebAuthnCredential_FullMethodName      = "/dbservice.DBService/GetWebAuthnCredential"
+	DBService_ListWebAuthnCredentials_FullMethodName    = "/dbservice.DBService/ListWebAuthnCredentials"
+	DBService_RenameWebAuthnCredential_FullMethodName   = "/dbservice.DBService/RenameWebAuthnCredential"
+	DBService_DeleteWebAuthnCredential_FullMethodName   = "/dbservice.DBService/DeleteWebAuthnCredential"
+	DBService_UpdateWebAuthnSignCount_FullMethodName    = "/dbservice.DBService/UpdateWebAuthnSignCount"
+	DBService_LinkIdentity_FullMethodName               = "/dbservice.DBService/LinkIdentity"
+	DBService_UnlinkIdentity_FullMethodName             = "/dbservice.DBService/UnlinkIdentity"
+	DBService_ListIdentities_FullMethodName             = "/dbservice.DBService/ListIdentities"
+	DBService_GetUserByIdentity_FullMethodName          = "/dbservice.DBService/GetUserByIdentity"
+	DBService_ListSecurityEvents_FullMethodName         = "/dbservice.DBService/ListSecurityEvents"
 )
 
 // DBServiceClient is the client API for DBService service.
@@ -80,14 +138,28 @@ type DBServiceClient interface {
 	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*UserID, error)
 	GetUserByID(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*User, error)
 	GetUserByEmail(ctx context.Context, in *EmailRequest, opts ...grpc.CallOption) (*User, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 	GetUserExtendedInfo(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UserExtendedInfo, error)
-	UpdateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdatePassword(ctx context.Context, in *UpdatePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetPasswordHistory(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*PasswordHistoryResponse, error)
+	GetPreferences(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Preferences, error)
+	SetPreferences(ctx context.Context, in *SetPreferencesRequest, opts ...grpc.CallOption) (*Preferences, error)
+	PatchPreferences(ctx context.Context, in *PatchPreferencesRequest, opts ...grpc.CallOption) (*Preferences, error)
 	UpdateUsername(ctx context.Context, in *UpdateUsernameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateEmailVerification(ctx context.Context, in *UpdateEmailVerificationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateLastLogin(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateFailedLoginAttempts(ctx context.Context, in *UpdateFailedLoginAttemptsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateLockedUntil(ctx context.Context, in *UpdateLockedUntilRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RecordFailedLogin(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*LoginState, error)
+	RecordSuccessfulLogin(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*LoginState, error)
+	IssueToken(ctx context.Context, in *IssueTokenRequest, opts ...grpc.CallOption) (*IssueTokenResponse, error)
+	ConsumeToken(ctx context.Context, in *ConsumeTokenRequest, opts ...grpc.CallOption) (*ConsumeTokenResponse, error)
+	// Account deletion
+	DeactivateUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*AccountDeletion, error)
+	CancelUserDeletion(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListPendingErasures(ctx context.Context, in *ListPendingErasuresRequest, opts ...grpc.CallOption) (*ListPendingErasuresResponse, error)
+	EraseUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ErasureResult, error)
 	UpdateStorageUsage(ctx context.Context, in *UpdateStorageUsageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CheckEmailExists(ctx context.Context, in *EmailRequest, opts ...grpc.CallOption) (*ExistsResponse, error)
 	CheckUsernameExists(ctx context.Context, in *UsernameRequest, opts ...grpc.CallOption) (*ExistsResponse, error)
@@ -95,7 +167,7 @@ type DBServiceClient interface {
 	CreateFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*FileID, error)
 	GetFileByID(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*File, error)
 	GetFileByPath(ctx context.Context, in *GetFileByPathRequest, opts ...grpc.CallOption) (*File, error)
-	UpdateFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateFile(ctx context.Context, in *UpdateFileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteFile(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SoftDeleteFile(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RestoreFile(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -113,12 +185,18 @@ type DBServiceClient interface {
 	GetRevisions(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
 	GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*FileRevision, error)
 	DeleteRevision(ctx context.Context, in *RevisionID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetRevisionLabel(ctx context.Context, in *SetRevisionLabelRequest, opts ...grpc.CallOption) (*FileRevision, error)
+	ClearRevisionLabel(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetRevisionByLabel(ctx context.Context, in *GetRevisionByLabelRequest, opts ...grpc.CallOption) (*FileRevision, error)
+	PruneRevisions(ctx context.Context, in *PruneRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
 	// File permission operations
-	CreatePermission(ctx context.Context, in *FilePermission, opts ...grpc.CallOption) (*PermissionID, error)
+	CreatePermission(ctx context.Context, in *CreatePermissionRequest, opts ...grpc.CallOption) (*PermissionID, error)
 	GetPermissions(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*ListPermissionsResponse, error)
-	UpdatePermission(ctx context.Context, in *FilePermission, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	DeletePermission(ctx context.Context, in *PermissionID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdatePermission(ctx context.Context, in *UpdatePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeletePermission(ctx context.Context, in *DeletePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CheckPermission(ctx context.Context, in *CheckPermissionRequest, opts ...grpc.CallOption) (*PermissionResponse, error)
+	CheckPermissions(ctx context.Context, in *CheckPermissionsRequest, opts ...grpc.CallOption) (*CheckPermissionsResponse, error)
+	GetEffectivePermission(ctx context.Context, in *GetEffectivePermissionRequest, opts ...grpc.CallOption) (*EffectivePermission, error)
 	// File metadata operations
 	UpdateFileMetadata(ctx context.Context, in *UpdateFileMetadataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetFileMetadata(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*FileMetadataResponse, error)
@@ -128,9 +206,56 @@ type DBServiceClient interface {
 	MoveFile(ctx context.Context, in *MoveFileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*File, error)
 	RenameFile(ctx context.Context, in *RenameFileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*TransferOwnershipResponse, error)
 	// File integrity operations
 	VerifyFileIntegrity(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*IntegrityResponse, error)
 	CalculateFileChecksums(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*ChecksumsResponse, error)
+	// Content deduplication operations
+	FindBySHA256(ctx context.Context, in *FindBySHA256Request, opts ...grpc.CallOption) (*StorageBlob, error)
+	FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error)
+	GetStorageBlob(ctx context.Context, in *StoragePathRequest, opts ...grpc.CallOption) (*StorageBlob, error)
+	ListUnreferencedBlobs(ctx context.Context, in *ListUnreferencedBlobsRequest, opts ...grpc.CallOption) (*ListStorageBlobsResponse, error)
+	ReleaseBlob(ctx context.Context, in *StoragePathRequest, opts ...grpc.CallOption) (*ReleaseBlobResponse, error)
+	// Group operations
+	CreateGroup(ctx context.Context, in *Group, opts ...grpc.CallOption) (*GroupID, error)
+	GetGroup(ctx context.Context, in *GroupID, opts ...grpc.CallOption) (*Group, error)
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	AddGroupMember(ctx context.Context, in *AddGroupMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveGroupMember(ctx context.Context, in *RemoveGroupMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListGroupMembers(ctx context.Context, in *GroupID, opts ...grpc.CallOption) (*ListGroupMembersResponse, error)
+	// Share link operations
+	CreateShareLink(ctx context.Context, in *ShareLink, opts ...grpc.CallOption) (*CreateShareLinkResponse, error)
+	ListShareLinks(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*ListShareLinksResponse, error)
+	RevokeShareLink(ctx context.Context, in *ShareLinkID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ResolveShareLink(ctx context.Context, in *ResolveShareLinkRequest, opts ...grpc.CallOption) (*ResolveShareLinkResponse, error)
+	// Session operations
+	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*SessionTokenResponse, error)
+	RotateSession(ctx context.Context, in *RotateSessionRequest, opts ...grpc.CallOption) (*RotateSessionResponse, error)
+	ListSessions(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *SessionID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*RevokeAllSessionsResponse, error)
+	// Two-factor authentication (TOTP)
+	EnrollTwoFactor(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*EnrollTwoFactorResponse, error)
+	ConfirmTwoFactor(ctx context.Context, in *TwoFactorCodeRequest, opts ...grpc.CallOption) (*ConfirmTwoFactorResponse, error)
+	VerifyTwoFactor(ctx context.Context, in *TwoFactorCodeRequest, opts ...grpc.CallOption) (*VerifyTwoFactorResponse, error)
+	ConsumeRecoveryCode(ctx context.Context, in *TwoFactorCodeRequest, opts ...grpc.CallOption) (*ConsumeRecoveryCodeResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, in *TwoFactorCodeRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error)
+	// WebAuthn credentials (passkeys)
+	RegisterWebAuthnCredential(ctx context.Context, in *WebAuthnCredential, opts ...grpc.CallOption) (*WebAuthnCredential, error)
+	GetWebAuthnCredential(ctx context.Context, in *GetWebAuthnCredentialRequest, opts ...grpc.CallOption) (*WebAuthnCredential, error)
+	ListWebAuthnCredentials(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ListWebAuthnCredentialsResponse, error)
+	RenameWebAuthnCredential(ctx context.Context, in *RenameWebAuthnCredentialRequest, opts ...grpc.CallOption) (*WebAuthnCredential, error)
+	DeleteWebAuthnCredential(ctx context.Context, in *WebAuthnCredentialID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateWebAuthnSignCount(ctx context.Context, in *UpdateWebAuthnSignCountRequest, opts ...grpc.CallOption) (*UpdateWebAuthnSignCountResponse, error)
+	// External identities (OAuth/OIDC)
+	LinkIdentity(ctx context.Context, in *UserIdentity, opts ...grpc.CallOption) (*UserIdentity, error)
+	UnlinkIdentity(ctx context.Context, in *UnlinkIdentityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListIdentities(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ListIdentitiesResponse, error)
+	GetUserByIdentity(ctx context.Context, in *IdentityKey, opts ...grpc.CallOption) (*User, error)
+	// Security event audit log
+	ListSecurityEvents(ctx context.Context, in *ListSecurityEventsRequest, opts ...grpc.CallOption) (*ListSecurityEventsResponse, error)
 }
 
 type dBServiceClient struct {
@@ -171,6 +296,16 @@ func (c *dBServiceClient) GetUserByEmail(ctx context.Context, in *EmailRequest,
 	return out, nil
 }
 
+func (c *dBServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, DBService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBServiceClient) GetUserExtendedInfo(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UserExtendedInfo, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UserExtendedInfo)
@@ -181,7 +316,7 @@ func (c *dBServiceClient) GetUserExtendedInfo(ctx context.Context, in *UserID, o
 	return out, nil
 }
 
-func (c *dBServiceClient) UpdateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *dBServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, DBService_UpdateUser_FullMethodName, in, out, cOpts...)
@@ -201,6 +336,46 @@ func (c *dBServiceClient) UpdatePassword(ctx context.Context, in *UpdatePassword
 	return out, nil
 }
 
+func (c *dBServiceClient) GetPasswordHistory(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*PasswordHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PasswordHistoryResponse)
+	err := c.cc.Invoke(ctx, DBService_GetPasswordHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) GetPreferences(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, DBService_GetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) SetPreferences(ctx context.Context, in *SetPreferencesRequest, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, DBService_SetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) PatchPreferences(ctx context.Context, in *PatchPreferencesRequest, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, DBService_PatchPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBServiceClient) UpdateUsername(ctx context.Context, in *UpdateUsernameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -251,6 +426,86 @@ func (c *dBServiceClient) UpdateLockedUntil(ctx context.Context, in *UpdateLocke
 	return out, nil
 }
 
+func (c *dBServiceClient) RecordFailedLogin(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*LoginState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginState)
+	err := c.cc.Invoke(ctx, DBService_RecordFailedLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) RecordSuccessfulLogin(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*LoginState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginState)
+	err := c.cc.Invoke(ctx, DBService_RecordSuccessfulLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) IssueToken(ctx context.Context, in *IssueTokenRequest, opts ...grpc.CallOption) (*IssueTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueTokenResponse)
+	err := c.cc.Invoke(ctx, DBService_IssueToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) ConsumeToken(ctx context.Context, in *ConsumeTokenRequest, opts ...grpc.CallOption) (*ConsumeTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsumeTokenResponse)
+	err := c.cc.Invoke(ctx, DBService_ConsumeToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) DeactivateUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*AccountDeletion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountDeletion)
+	err := c.cc.Invoke(ctx, DBService_DeactivateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) CancelUserDeletion(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_CancelUserDeletion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) ListPendingErasures(ctx context.Context, in *ListPendingErasuresRequest, opts ...grpc.CallOption) (*ListPendingErasuresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingErasuresResponse)
+	err := c.cc.Invoke(ctx, DBService_ListPendingErasures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) EraseUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ErasureResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ErasureResult)
+	err := c.cc.Invoke(ctx, DBService_EraseUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBServiceClient) UpdateStorageUsage(ctx context.Context, in *UpdateStorageUsageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -311,7 +566,7 @@ func (c *dBServiceClient) GetFileByPath(ctx context.Context, in *GetFileByPathRe
 	return out, nil
 }
 
-func (c *dBServiceClient) UpdateFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *dBServiceClient) UpdateFile(ctx context.Context, in *UpdateFileRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, DBService_UpdateFile_FullMethodName, in, out, cOpts...)
@@ -481,7 +736,47 @@ func (c *dBServiceClient) DeleteRevision(ctx context.Context, in *RevisionID, op
 	return out, nil
 }
 
-func (c *dBServiceClient) CreatePermission(ctx context.Context, in *FilePermission, opts ...grpc.CallOption) (*PermissionID, error) {
+func (c *dBServiceClient) SetRevisionLabel(ctx context.Context, in *SetRevisionLabelRequest, opts ...grpc.CallOption) (*FileRevision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileRevision)
+	err := c.cc.Invoke(ctx, DBService_SetRevisionLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) ClearRevisionLabel(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_ClearRevisionLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) GetRevisionByLabel(ctx context.Context, in *GetRevisionByLabelRequest, opts ...grpc.CallOption) (*FileRevision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileRevision)
+	err := c.cc.Invoke(ctx, DBService_GetRevisionByLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) PruneRevisions(ctx context.Context, in *PruneRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, DBService_PruneRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) CreatePermission(ctx context.Context, in *CreatePermissionRequest, opts ...grpc.CallOption) (*PermissionID, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PermissionID)
 	err := c.cc.Invoke(ctx, DBService_CreatePermission_FullMethodName, in, out, cOpts...)
@@ -501,7 +796,7 @@ func (c *dBServiceClient) GetPermissions(ctx context.Context, in *FileID, opts .
 	return out, nil
 }
 
-func (c *dBServiceClient) UpdatePermission(ctx context.Context, in *FilePermission, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *dBServiceClient) UpdatePermission(ctx context.Context, in *UpdatePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, DBService_UpdatePermission_FullMethodName, in, out, cOpts...)
@@ -511,7 +806,7 @@ func (c *dBServiceClient) UpdatePermission(ctx context.Context, in *FilePermissi
 	return out, nil
 }
 
-func (c *dBServiceClient) DeletePermission(ctx context.Context, in *PermissionID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *dBServiceClient) DeletePermission(ctx context.Context, in *DeletePermissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, DBService_DeletePermission_FullMethodName, in, out, cOpts...)
@@ -531,6 +826,26 @@ func (c *dBServiceClient) CheckPermission(ctx context.Context, in *CheckPermissi
 	return out, nil
 }
 
+func (c *dBServiceClient) CheckPermissions(ctx context.Context, in *CheckPermissionsRequest, opts ...grpc.CallOption) (*CheckPermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPermissionsResponse)
+	err := c.cc.Invoke(ctx, DBService_CheckPermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) GetEffectivePermission(ctx context.Context, in *GetEffectivePermissionRequest, opts ...grpc.CallOption) (*EffectivePermission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EffectivePermission)
+	err := c.cc.Invoke(ctx, DBService_GetEffectivePermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBServiceClient) UpdateFileMetadata(ctx context.Context, in *UpdateFileMetadataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -601,6 +916,16 @@ func (c *dBServiceClient) RenameFile(ctx context.Context, in *RenameFileRequest,
 	return out, nil
 }
 
+func (c *dBServiceClient) TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*TransferOwnershipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferOwnershipResponse)
+	err := c.cc.Invoke(ctx, DBService_TransferOwnership_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBServiceClient) VerifyFileIntegrity(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*IntegrityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IntegrityResponse)
@@ -621,194 +946,698 @@ func (c *dBServiceClient) CalculateFileChecksums(ctx context.Context, in *FileID
 	return out, nil
 }
 
-// DBServiceServer is the server API for DBService service.
-// All implementations must embed UnimplementedDBServiceServer
-// for forward compatibility.
-//
-// Service definition
-type DBServiceServer interface {
-	// User operations
-	CreateUser(context.Context, *User) (*UserID, error)
-	GetUserByID(context.Context, *UserID) (*User, error)
-	GetUserByEmail(context.Context, *EmailRequest) (*User, error)
-	GetUserExtendedInfo(context.Context, *UserID) (*UserExtendedInfo, error)
-	UpdateUser(context.Context, *User) (*emptypb.Empty, error)
-	UpdatePassword(context.Context, *UpdatePasswordRequest) (*emptypb.Empty, error)
-	UpdateUsername(context.Context, *UpdateUsernameRequest) (*emptypb.Empty, error)
-	UpdateEmailVerification(context.Context, *UpdateEmailVerificationRequest) (*emptypb.Empty, error)
-	UpdateLastLogin(context.Context, *UserID) (*emptypb.Empty, error)
-	UpdateFailedLoginAttempts(context.Context, *UpdateFailedLoginAttemptsRequest) (*emptypb.Empty, error)
-	UpdateLockedUntil(context.Context, *UpdateLockedUntilRequest) (*emptypb.Empty, error)
-	UpdateStorageUsage(context.Context, *UpdateStorageUsageRequest) (*emptypb.Empty, error)
-	CheckEmailExists(context.Context, *EmailRequest) (*ExistsResponse, error)
-	CheckUsernameExists(context.Context, *UsernameRequest) (*ExistsResponse, error)
-	// File operations
-	CreateFile(context.Context, *File) (*FileID, error)
-	GetFileByID(context.Context, *FileID) (*File, error)
-	GetFileByPath(context.Context, *GetFileByPathRequest) (*File, error)
-	UpdateFile(context.Context, *File) (*emptypb.Empty, error)
-	DeleteFile(context.Context, *FileID) (*emptypb.Empty, error)
-	SoftDeleteFile(context.Context, *FileID) (*emptypb.Empty, error)
-	RestoreFile(context.Context, *FileID) (*emptypb.Empty, error)
-	ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error)
-	ListFilesByParent(context.Context, *ListFilesByParentRequest) (*ListFilesResponse, error)
-	ListStarredFiles(context.Context, *ListStarredFilesRequest) (*ListFilesResponse, error)
-	ListTrashedFiles(context.Context, *ListTrashedFilesRequest) (*ListFilesResponse, error)
-	SearchFiles(context.Context, *SearchFilesRequest) (*ListFilesResponse, error)
-	GetFileSize(context.Context, *FileID) (*FileSizeResponse, error)
-	UpdateFileSize(context.Context, *UpdateFileSizeRequest) (*emptypb.Empty, error)
-	UpdateLastViewed(context.Context, *FileID) (*emptypb.Empty, error)
-	GetFileTree(context.Context, *GetFileTreeRequest) (*ListFilesResponse, error)
-	// File revision operations
-	CreateRevision(context.Context, *FileRevision) (*RevisionID, error)
-	GetRevisions(context.Context, *FileID) (*ListRevisionsResponse, error)
-	GetRevision(context.Context, *GetRevisionRequest) (*FileRevision, error)
-	DeleteRevision(context.Context, *RevisionID) (*emptypb.Empty, error)
-	// File permission operations
-	CreatePermission(context.Context, *FilePermission) (*PermissionID, error)
-	GetPermissions(context.Context, *FileID) (*ListPermissionsResponse, error)
-	UpdatePermission(context.Context, *FilePermission) (*emptypb.Empty, error)
-	DeletePermission(context.Context, *PermissionID) (*emptypb.Empty, error)
-	CheckPermission(context.Context, *CheckPermissionRequest) (*PermissionResponse, error)
-	// File metadata operations
-	UpdateFileMetadata(context.Context, *UpdateFileMetadataRequest) (*emptypb.Empty, error)
-	GetFileMetadata(context.Context, *FileID) (*FileMetadataResponse, error)
-	// File operations (star, move, copy, rename)
-	StarFile(context.Context, *FileID) (*emptypb.Empty, error)
-	UnstarFile(context.Context, *FileID) (*emptypb.Empty, error)
-	MoveFile(context.Context, *MoveFileRequest) (*emptypb.Empty, error)
-	CopyFile(context.Context, *CopyFileRequest) (*File, error)
-	RenameFile(context.Context, *RenameFileRequest) (*emptypb.Empty, error)
-	// File integrity operations
-	VerifyFileIntegrity(context.Context, *FileID) (*IntegrityResponse, error)
-	CalculateFileChecksums(context.Context, *FileID) (*ChecksumsResponse, error)
-	mustEmbedUnimplementedDBServiceServer()
+func (c *dBServiceClient) FindBySHA256(ctx context.Context, in *FindBySHA256Request, opts ...grpc.CallOption) (*StorageBlob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageBlob)
+	err := c.cc.Invoke(ctx, DBService_FindBySHA256_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedDBServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedDBServiceServer struct{}
-
-func (UnimplementedDBServiceServer) CreateUser(context.Context, *User) (*UserID, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
-}
-func (UnimplementedDBServiceServer) GetUserByID(context.Context, *UserID) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserByID not implemented")
+func (c *dBServiceClient) FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindDuplicatesResponse)
+	err := c.cc.Invoke(ctx, DBService_FindDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetUserByEmail(context.Context, *EmailRequest) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserByEmail not implemented")
+
+func (c *dBServiceClient) GetStorageBlob(ctx context.Context, in *StoragePathRequest, opts ...grpc.CallOption) (*StorageBlob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageBlob)
+	err := c.cc.Invoke(ctx, DBService_GetStorageBlob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetUserExtendedInfo(context.Context, *UserID) (*UserExtendedInfo, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserExtendedInfo not implemented")
+
+func (c *dBServiceClient) ListUnreferencedBlobs(ctx context.Context, in *ListUnreferencedBlobsRequest, opts ...grpc.CallOption) (*ListStorageBlobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStorageBlobsResponse)
+	err := c.cc.Invoke(ctx, DBService_ListUnreferencedBlobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateUser(context.Context, *User) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+
+func (c *dBServiceClient) ReleaseBlob(ctx context.Context, in *StoragePathRequest, opts ...grpc.CallOption) (*ReleaseBlobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseBlobResponse)
+	err := c.cc.Invoke(ctx, DBService_ReleaseBlob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdatePassword(context.Context, *UpdatePasswordRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdatePassword not implemented")
+
+func (c *dBServiceClient) CreateGroup(ctx context.Context, in *Group, opts ...grpc.CallOption) (*GroupID, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupID)
+	err := c.cc.Invoke(ctx, DBService_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateUsername(context.Context, *UpdateUsernameRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUsername not implemented")
-}
-func (UnimplementedDBServiceServer) UpdateEmailVerification(context.Context, *UpdateEmailVerificationRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmailVerification not implemented")
+
+func (c *dBServiceClient) GetGroup(ctx context.Context, in *GroupID, opts ...grpc.CallOption) (*Group, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Group)
+	err := c.cc.Invoke(ctx, DBService_GetGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateLastLogin(context.Context, *UserID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateLastLogin not implemented")
+
+func (c *dBServiceClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_UpdateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateFailedLoginAttempts(context.Context, *UpdateFailedLoginAttemptsRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateFailedLoginAttempts not implemented")
+
+func (c *dBServiceClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_DeleteGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateLockedUntil(context.Context, *UpdateLockedUntilRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateLockedUntil not implemented")
+
+func (c *dBServiceClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, DBService_ListGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateStorageUsage(context.Context, *UpdateStorageUsageRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateStorageUsage not implemented")
+
+func (c *dBServiceClient) AddGroupMember(ctx context.Context, in *AddGroupMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_AddGroupMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) CheckEmailExists(context.Context, *EmailRequest) (*ExistsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CheckEmailExists not implemented")
+
+func (c *dBServiceClient) RemoveGroupMember(ctx context.Context, in *RemoveGroupMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RemoveGroupMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) CheckUsernameExists(context.Context, *UsernameRequest) (*ExistsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CheckUsernameExists not implemented")
+
+func (c *dBServiceClient) ListGroupMembers(ctx context.Context, in *GroupID, opts ...grpc.CallOption) (*ListGroupMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGroupMembersResponse)
+	err := c.cc.Invoke(ctx, DBService_ListGroupMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) CreateFile(context.Context, *File) (*FileID, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateFile not implemented")
+
+func (c *dBServiceClient) CreateShareLink(ctx context.Context, in *ShareLink, opts ...grpc.CallOption) (*CreateShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateShareLinkResponse)
+	err := c.cc.Invoke(ctx, DBService_CreateShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetFileByID(context.Context, *FileID) (*File, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFileByID not implemented")
+
+func (c *dBServiceClient) ListShareLinks(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*ListShareLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShareLinksResponse)
+	err := c.cc.Invoke(ctx, DBService_ListShareLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetFileByPath(context.Context, *GetFileByPathRequest) (*File, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFileByPath not implemented")
+
+func (c *dBServiceClient) RevokeShareLink(ctx context.Context, in *ShareLinkID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RevokeShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateFile(context.Context, *File) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateFile not implemented")
+
+func (c *dBServiceClient) ResolveShareLink(ctx context.Context, in *ResolveShareLinkRequest, opts ...grpc.CallOption) (*ResolveShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveShareLinkResponse)
+	err := c.cc.Invoke(ctx, DBService_ResolveShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) DeleteFile(context.Context, *FileID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+
+func (c *dBServiceClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*SessionTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionTokenResponse)
+	err := c.cc.Invoke(ctx, DBService_CreateSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) SoftDeleteFile(context.Context, *FileID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SoftDeleteFile not implemented")
+
+func (c *dBServiceClient) RotateSession(ctx context.Context, in *RotateSessionRequest, opts ...grpc.CallOption) (*RotateSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateSessionResponse)
+	err := c.cc.Invoke(ctx, DBService_RotateSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) RestoreFile(context.Context, *FileID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RestoreFile not implemented")
+
+func (c *dBServiceClient) ListSessions(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, DBService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListFiles not implemented")
+
+func (c *dBServiceClient) RevokeSession(ctx context.Context, in *SessionID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) ListFilesByParent(context.Context, *ListFilesByParentRequest) (*ListFilesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListFilesByParent not implemented")
+
+func (c *dBServiceClient) RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*RevokeAllSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAllSessionsResponse)
+	err := c.cc.Invoke(ctx, DBService_RevokeAllSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) ListStarredFiles(context.Context, *ListStarredFilesRequest) (*ListFilesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListStarredFiles not implemented")
+
+func (c *dBServiceClient) EnrollTwoFactor(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*EnrollTwoFactorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollTwoFactorResponse)
+	err := c.cc.Invoke(ctx, DBService_EnrollTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) ListTrashedFiles(context.Context, *ListTrashedFilesRequest) (*ListFilesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTrashedFiles not implemented")
+
+func (c *dBServiceClient) ConfirmTwoFactor(ctx context.Context, in *TwoFactorCodeRequest, opts ...grpc.CallOption) (*ConfirmTwoFactorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTwoFactorResponse)
+	err := c.cc.Invoke(ctx, DBService_ConfirmTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) SearchFiles(context.Context, *SearchFilesRequest) (*ListFilesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SearchFiles not implemented")
+
+func (c *dBServiceClient) VerifyTwoFactor(ctx context.Context, in *TwoFactorCodeRequest, opts ...grpc.CallOption) (*VerifyTwoFactorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyTwoFactorResponse)
+	err := c.cc.Invoke(ctx, DBService_VerifyTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetFileSize(context.Context, *FileID) (*FileSizeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFileSize not implemented")
+
+func (c *dBServiceClient) ConsumeRecoveryCode(ctx context.Context, in *TwoFactorCodeRequest, opts ...grpc.CallOption) (*ConsumeRecoveryCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsumeRecoveryCodeResponse)
+	err := c.cc.Invoke(ctx, DBService_ConsumeRecoveryCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateFileSize(context.Context, *UpdateFileSizeRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileSize not implemented")
+
+func (c *dBServiceClient) RegenerateRecoveryCodes(ctx context.Context, in *TwoFactorCodeRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegenerateRecoveryCodesResponse)
+	err := c.cc.Invoke(ctx, DBService_RegenerateRecoveryCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateLastViewed(context.Context, *FileID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateLastViewed not implemented")
+
+func (c *dBServiceClient) RegisterWebAuthnCredential(ctx context.Context, in *WebAuthnCredential, opts ...grpc.CallOption) (*WebAuthnCredential, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebAuthnCredential)
+	err := c.cc.Invoke(ctx, DBService_RegisterWebAuthnCredential_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetFileTree(context.Context, *GetFileTreeRequest) (*ListFilesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFileTree not implemented")
+
+func (c *dBServiceClient) GetWebAuthnCredential(ctx context.Context, in *GetWebAuthnCredentialRequest, opts ...grpc.CallOption) (*WebAuthnCredential, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebAuthnCredential)
+	err := c.cc.Invoke(ctx, DBService_GetWebAuthnCredential_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) CreateRevision(context.Context, *FileRevision) (*RevisionID, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRevision not implemented")
+
+func (c *dBServiceClient) ListWebAuthnCredentials(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ListWebAuthnCredentialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebAuthnCredentialsResponse)
+	err := c.cc.Invoke(ctx, DBService_ListWebAuthnCredentials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetRevisions(context.Context, *FileID) (*ListRevisionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRevisions not implemented")
+
+func (c *dBServiceClient) RenameWebAuthnCredential(ctx context.Context, in *RenameWebAuthnCredentialRequest, opts ...grpc.CallOption) (*WebAuthnCredential, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebAuthnCredential)
+	err := c.cc.Invoke(ctx, DBService_RenameWebAuthnCredential_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetRevision(context.Context, *GetRevisionRequest) (*FileRevision, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+
+func (c *dBServiceClient) DeleteWebAuthnCredential(ctx context.Context, in *WebAuthnCredentialID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_DeleteWebAuthnCredential_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) DeleteRevision(context.Context, *RevisionID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteRevision not implemented")
+
+func (c *dBServiceClient) UpdateWebAuthnSignCount(ctx context.Context, in *UpdateWebAuthnSignCountRequest, opts ...grpc.CallOption) (*UpdateWebAuthnSignCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWebAuthnSignCountResponse)
+	err := c.cc.Invoke(ctx, DBService_UpdateWebAuthnSignCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) CreatePermission(context.Context, *FilePermission) (*PermissionID, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreatePermission not implemented")
+
+func (c *dBServiceClient) LinkIdentity(ctx context.Context, in *UserIdentity, opts ...grpc.CallOption) (*UserIdentity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserIdentity)
+	err := c.cc.Invoke(ctx, DBService_LinkIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetPermissions(context.Context, *FileID) (*ListPermissionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPermissions not implemented")
+
+func (c *dBServiceClient) UnlinkIdentity(ctx context.Context, in *UnlinkIdentityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_UnlinkIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdatePermission(context.Context, *FilePermission) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdatePermission not implemented")
+
+func (c *dBServiceClient) ListIdentities(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*ListIdentitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIdentitiesResponse)
+	err := c.cc.Invoke(ctx, DBService_ListIdentities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) DeletePermission(context.Context, *PermissionID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeletePermission not implemented")
+
+func (c *dBServiceClient) GetUserByIdentity(ctx context.Context, in *IdentityKey, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, DBService_GetUserByIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) CheckPermission(context.Context, *CheckPermissionRequest) (*PermissionResponse, error) {
+
+func (c *dBServiceClient) ListSecurityEvents(ctx context.Context, in *ListSecurityEventsRequest, opts ...grpc.CallOption) (*ListSecurityEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSecurityEventsResponse)
+	err := c.cc.Invoke(ctx, DBService_ListSecurityEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DBServiceServer is the server API for DBService service.
+// All implementations must embed UnimplementedDBServiceServer
+// for forward compatibility.
+//
+// Service definition
+type DBServiceServer interface {
+	// User operations
+	CreateUser(context.Context, *User) (*UserID, error)
+	GetUserByID(context.Context, *UserID) (*User, error)
+	GetUserByEmail(context.Context, *EmailRequest) (*User, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	GetUserExtendedInfo(context.Context, *UserID) (*UserExtendedInfo, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*emptypb.Empty, error)
+	UpdatePassword(context.Context, *UpdatePasswordRequest) (*emptypb.Empty, error)
+	GetPasswordHistory(context.Context, *UserID) (*PasswordHistoryResponse, error)
+	GetPreferences(context.Context, *UserID) (*Preferences, error)
+	SetPreferences(context.Context, *SetPreferencesRequest) (*Preferences, error)
+	PatchPreferences(context.Context, *PatchPreferencesRequest) (*Preferences, error)
+	UpdateUsername(context.Context, *UpdateUsernameRequest) (*emptypb.Empty, error)
+	UpdateEmailVerification(context.Context, *UpdateEmailVerificationRequest) (*emptypb.Empty, error)
+	UpdateLastLogin(context.Context, *UserID) (*emptypb.Empty, error)
+	UpdateFailedLoginAttempts(context.Context, *UpdateFailedLoginAttemptsRequest) (*emptypb.Empty, error)
+	UpdateLockedUntil(context.Context, *UpdateLockedUntilRequest) (*emptypb.Empty, error)
+	RecordFailedLogin(context.Context, *UserID) (*LoginState, error)
+	RecordSuccessfulLogin(context.Context, *UserID) (*LoginState, error)
+	IssueToken(context.Context, *IssueTokenRequest) (*IssueTokenResponse, error)
+	ConsumeToken(context.Context, *ConsumeTokenRequest) (*ConsumeTokenResponse, error)
+	// Account deletion
+	DeactivateUser(context.Context, *UserID) (*AccountDeletion, error)
+	CancelUserDeletion(context.Context, *UserID) (*emptypb.Empty, error)
+	ListPendingErasures(context.Context, *ListPendingErasuresRequest) (*ListPendingErasuresResponse, error)
+	EraseUser(context.Context, *UserID) (*ErasureResult, error)
+	UpdateStorageUsage(context.Context, *UpdateStorageUsageRequest) (*emptypb.Empty, error)
+	CheckEmailExists(context.Context, *EmailRequest) (*ExistsResponse, error)
+	CheckUsernameExists(context.Context, *UsernameRequest) (*ExistsResponse, error)
+	// File operations
+	CreateFile(context.Context, *File) (*FileID, error)
+	GetFileByID(context.Context, *FileID) (*File, error)
+	GetFileByPath(context.Context, *GetFileByPathRequest) (*File, error)
+	UpdateFile(context.Context, *UpdateFileRequest) (*emptypb.Empty, error)
+	DeleteFile(context.Context, *FileID) (*emptypb.Empty, error)
+	SoftDeleteFile(context.Context, *FileID) (*emptypb.Empty, error)
+	RestoreFile(context.Context, *FileID) (*emptypb.Empty, error)
+	ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error)
+	ListFilesByParent(context.Context, *ListFilesByParentRequest) (*ListFilesResponse, error)
+	ListStarredFiles(context.Context, *ListStarredFilesRequest) (*ListFilesResponse, error)
+	ListTrashedFiles(context.Context, *ListTrashedFilesRequest) (*ListFilesResponse, error)
+	SearchFiles(context.Context, *SearchFilesRequest) (*ListFilesResponse, error)
+	GetFileSize(context.Context, *FileID) (*FileSizeResponse, error)
+	UpdateFileSize(context.Context, *UpdateFileSizeRequest) (*emptypb.Empty, error)
+	UpdateLastViewed(context.Context, *FileID) (*emptypb.Empty, error)
+	GetFileTree(context.Context, *GetFileTreeRequest) (*ListFilesResponse, error)
+	// File revision operations
+	CreateRevision(context.Context, *FileRevision) (*RevisionID, error)
+	GetRevisions(context.Context, *FileID) (*ListRevisionsResponse, error)
+	GetRevision(context.Context, *GetRevisionRequest) (*FileRevision, error)
+	DeleteRevision(context.Context, *RevisionID) (*emptypb.Empty, error)
+	SetRevisionLabel(context.Context, *SetRevisionLabelRequest) (*FileRevision, error)
+	ClearRevisionLabel(context.Context, *GetRevisionRequest) (*emptypb.Empty, error)
+	GetRevisionByLabel(context.Context, *GetRevisionByLabelRequest) (*FileRevision, error)
+	PruneRevisions(context.Context, *PruneRevisionsRequest) (*ListRevisionsResponse, error)
+	// File permission operations
+	CreatePermission(context.Context, *CreatePermissionRequest) (*PermissionID, error)
+	GetPermissions(context.Context, *FileID) (*ListPermissionsResponse, error)
+	UpdatePermission(context.Context, *UpdatePermissionRequest) (*emptypb.Empty, error)
+	DeletePermission(context.Context, *DeletePermissionRequest) (*emptypb.Empty, error)
+	CheckPermission(context.Context, *CheckPermissionRequest) (*PermissionResponse, error)
+	CheckPermissions(context.Context, *CheckPermissionsRequest) (*CheckPermissionsResponse, error)
+	GetEffectivePermission(context.Context, *GetEffectivePermissionRequest) (*EffectivePermission, error)
+	// File metadata operations
+	UpdateFileMetadata(context.Context, *UpdateFileMetadataRequest) (*emptypb.Empty, error)
+	GetFileMetadata(context.Context, *FileID) (*FileMetadataResponse, error)
+	// File operations (star, move, copy, rename)
+	StarFile(context.Context, *FileID) (*emptypb.Empty, error)
+	UnstarFile(context.Context, *FileID) (*emptypb.Empty, error)
+	MoveFile(context.Context, *MoveFileRequest) (*emptypb.Empty, error)
+	CopyFile(context.Context, *CopyFileRequest) (*File, error)
+	RenameFile(context.Context, *RenameFileRequest) (*emptypb.Empty, error)
+	TransferOwnership(context.Context, *TransferOwnershipRequest) (*TransferOwnershipResponse, error)
+	// File integrity operations
+	VerifyFileIntegrity(context.Context, *FileID) (*IntegrityResponse, error)
+	CalculateFileChecksums(context.Context, *FileID) (*ChecksumsResponse, error)
+	// Content deduplication operations
+	FindBySHA256(context.Context, *FindBySHA256Request) (*StorageBlob, error)
+	FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error)
+	GetStorageBlob(context.Context, *StoragePathRequest) (*StorageBlob, error)
+	ListUnreferencedBlobs(context.Context, *ListUnreferencedBlobsRequest) (*ListStorageBlobsResponse, error)
+	ReleaseBlob(context.Context, *StoragePathRequest) (*ReleaseBlobResponse, error)
+	// Group operations
+	CreateGroup(context.Context, *Group) (*GroupID, error)
+	GetGroup(context.Context, *GroupID) (*Group, error)
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*emptypb.Empty, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*emptypb.Empty, error)
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	AddGroupMember(context.Context, *AddGroupMemberRequest) (*emptypb.Empty, error)
+	RemoveGroupMember(context.Context, *RemoveGroupMemberRequest) (*emptypb.Empty, error)
+	ListGroupMembers(context.Context, *GroupID) (*ListGroupMembersResponse, error)
+	// Share link operations
+	CreateShareLink(context.Context, *ShareLink) (*CreateShareLinkResponse, error)
+	ListShareLinks(context.Context, *FileID) (*ListShareLinksResponse, error)
+	RevokeShareLink(context.Context, *ShareLinkID) (*emptypb.Empty, error)
+	ResolveShareLink(context.Context, *ResolveShareLinkRequest) (*ResolveShareLinkResponse, error)
+	// Session operations
+	CreateSession(context.Context, *CreateSessionRequest) (*SessionTokenResponse, error)
+	RotateSession(context.Context, *RotateSessionRequest) (*RotateSessionResponse, error)
+	ListSessions(context.Context, *UserID) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *SessionID) (*emptypb.Empty, error)
+	RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error)
+	// Two-factor authentication (TOTP)
+	EnrollTwoFactor(context.Context, *UserID) (*EnrollTwoFactorResponse, error)
+	ConfirmTwoFactor(context.Context, *TwoFactorCodeRequest) (*ConfirmTwoFactorResponse, error)
+	VerifyTwoFactor(context.Context, *TwoFactorCodeRequest) (*VerifyTwoFactorResponse, error)
+	ConsumeRecoveryCode(context.Context, *TwoFactorCodeRequest) (*ConsumeRecoveryCodeResponse, error)
+	RegenerateRecoveryCodes(context.Context, *TwoFactorCodeRequest) (*RegenerateRecoveryCodesResponse, error)
+	// WebAuthn credentials (passkeys)
+	RegisterWebAuthnCredential(context.Context, *WebAuthnCredential) (*WebAuthnCredential, error)
+	GetWebAuthnCredential(context.Context, *GetWebAuthnCredentialRequest) (*WebAuthnCredential, error)
+	ListWebAuthnCredentials(context.Context, *UserID) (*ListWebAuthnCredentialsResponse, error)
+	RenameWebAuthnCredential(context.Context, *RenameWebAuthnCredentialRequest) (*WebAuthnCredential, error)
+	DeleteWebAuthnCredential(context.Context, *WebAuthnCredentialID) (*emptypb.Empty, error)
+	UpdateWebAuthnSignCount(context.Context, *UpdateWebAuthnSignCountRequest) (*UpdateWebAuthnSignCountResponse, error)
+	// External identities (OAuth/OIDC)
+	LinkIdentity(context.Context, *UserIdentity) (*UserIdentity, error)
+	UnlinkIdentity(context.Context, *UnlinkIdentityRequest) (*emptypb.Empty, error)
+	ListIdentities(context.Context, *UserID) (*ListIdentitiesResponse, error)
+	GetUserByIdentity(context.Context, *IdentityKey) (*User, error)
+	// Security event audit log
+	ListSecurityEvents(context.Context, *ListSecurityEventsRequest) (*ListSecurityEventsResponse, error)
+	mustEmbedUnimplementedDBServiceServer()
+}
+
+// UnimplementedDBServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDBServiceServer struct{}
+
+func (UnimplementedDBServiceServer) CreateUser(context.Context, *User) (*UserID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedDBServiceServer) GetUserByID(context.Context, *UserID) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByID not implemented")
+}
+func (UnimplementedDBServiceServer) GetUserByEmail(context.Context, *EmailRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByEmail not implemented")
+}
+func (UnimplementedDBServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedDBServiceServer) GetUserExtendedInfo(context.Context, *UserID) (*UserExtendedInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserExtendedInfo not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedDBServiceServer) UpdatePassword(context.Context, *UpdatePasswordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePassword not implemented")
+}
+func (UnimplementedDBServiceServer) GetPasswordHistory(context.Context, *UserID) (*PasswordHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPasswordHistory not implemented")
+}
+func (UnimplementedDBServiceServer) GetPreferences(context.Context, *UserID) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedDBServiceServer) SetPreferences(context.Context, *SetPreferencesRequest) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPreferences not implemented")
+}
+func (UnimplementedDBServiceServer) PatchPreferences(context.Context, *PatchPreferencesRequest) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchPreferences not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateUsername(context.Context, *UpdateUsernameRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUsername not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateEmailVerification(context.Context, *UpdateEmailVerificationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmailVerification not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateLastLogin(context.Context, *UserID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLastLogin not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateFailedLoginAttempts(context.Context, *UpdateFailedLoginAttemptsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFailedLoginAttempts not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateLockedUntil(context.Context, *UpdateLockedUntilRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLockedUntil not implemented")
+}
+func (UnimplementedDBServiceServer) RecordFailedLogin(context.Context, *UserID) (*LoginState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordFailedLogin not implemented")
+}
+func (UnimplementedDBServiceServer) RecordSuccessfulLogin(context.Context, *UserID) (*LoginState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordSuccessfulLogin not implemented")
+}
+func (UnimplementedDBServiceServer) IssueToken(context.Context, *IssueTokenRequest) (*IssueTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueToken not implemented")
+}
+func (UnimplementedDBServiceServer) ConsumeToken(context.Context, *ConsumeTokenRequest) (*ConsumeTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConsumeToken not implemented")
+}
+func (UnimplementedDBServiceServer) DeactivateUser(context.Context, *UserID) (*AccountDeletion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateUser not implemented")
+}
+func (UnimplementedDBServiceServer) CancelUserDeletion(context.Context, *UserID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelUserDeletion not implemented")
+}
+func (UnimplementedDBServiceServer) ListPendingErasures(context.Context, *ListPendingErasuresRequest) (*ListPendingErasuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingErasures not implemented")
+}
+func (UnimplementedDBServiceServer) EraseUser(context.Context, *UserID) (*ErasureResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateStorageUsage(context.Context, *UpdateStorageUsageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStorageUsage not implemented")
+}
+func (UnimplementedDBServiceServer) CheckEmailExists(context.Context, *EmailRequest) (*ExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckEmailExists not implemented")
+}
+func (UnimplementedDBServiceServer) CheckUsernameExists(context.Context, *UsernameRequest) (*ExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckUsernameExists not implemented")
+}
+func (UnimplementedDBServiceServer) CreateFile(context.Context, *File) (*FileID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFile not implemented")
+}
+func (UnimplementedDBServiceServer) GetFileByID(context.Context, *FileID) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileByID not implemented")
+}
+func (UnimplementedDBServiceServer) GetFileByPath(context.Context, *GetFileByPathRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileByPath not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateFile(context.Context, *UpdateFileRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFile not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteFile(context.Context, *FileID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (UnimplementedDBServiceServer) SoftDeleteFile(context.Context, *FileID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SoftDeleteFile not implemented")
+}
+func (UnimplementedDBServiceServer) RestoreFile(context.Context, *FileID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFile not implemented")
+}
+func (UnimplementedDBServiceServer) ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFiles not implemented")
+}
+func (UnimplementedDBServiceServer) ListFilesByParent(context.Context, *ListFilesByParentRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFilesByParent not implemented")
+}
+func (UnimplementedDBServiceServer) ListStarredFiles(context.Context, *ListStarredFilesRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStarredFiles not implemented")
+}
+func (UnimplementedDBServiceServer) ListTrashedFiles(context.Context, *ListTrashedFilesRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrashedFiles not implemented")
+}
+func (UnimplementedDBServiceServer) SearchFiles(context.Context, *SearchFilesRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchFiles not implemented")
+}
+func (UnimplementedDBServiceServer) GetFileSize(context.Context, *FileID) (*FileSizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileSize not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateFileSize(context.Context, *UpdateFileSizeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileSize not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateLastViewed(context.Context, *FileID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLastViewed not implemented")
+}
+func (UnimplementedDBServiceServer) GetFileTree(context.Context, *GetFileTreeRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileTree not implemented")
+}
+func (UnimplementedDBServiceServer) CreateRevision(context.Context, *FileRevision) (*RevisionID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRevision not implemented")
+}
+func (UnimplementedDBServiceServer) GetRevisions(context.Context, *FileID) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevisions not implemented")
+}
+func (UnimplementedDBServiceServer) GetRevision(context.Context, *GetRevisionRequest) (*FileRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteRevision(context.Context, *RevisionID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRevision not implemented")
+}
+func (UnimplementedDBServiceServer) SetRevisionLabel(context.Context, *SetRevisionLabelRequest) (*FileRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRevisionLabel not implemented")
+}
+func (UnimplementedDBServiceServer) ClearRevisionLabel(context.Context, *GetRevisionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearRevisionLabel not implemented")
+}
+func (UnimplementedDBServiceServer) GetRevisionByLabel(context.Context, *GetRevisionByLabelRequest) (*FileRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevisionByLabel not implemented")
+}
+func (UnimplementedDBServiceServer) PruneRevisions(context.Context, *PruneRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneRevisions not implemented")
+}
+func (UnimplementedDBServiceServer) CreatePermission(context.Context, *CreatePermissionRequest) (*PermissionID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePermission not implemented")
+}
+func (UnimplementedDBServiceServer) GetPermissions(context.Context, *FileID) (*ListPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPermissions not implemented")
+}
+func (UnimplementedDBServiceServer) UpdatePermission(context.Context, *UpdatePermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePermission not implemented")
+}
+func (UnimplementedDBServiceServer) DeletePermission(context.Context, *DeletePermissionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePermission not implemented")
+}
+func (UnimplementedDBServiceServer) CheckPermission(context.Context, *CheckPermissionRequest) (*PermissionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckPermission not implemented")
 }
+func (UnimplementedDBServiceServer) CheckPermissions(context.Context, *CheckPermissionsRequest) (*CheckPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPermissions not implemented")
+}
+func (UnimplementedDBServiceServer) GetEffectivePermission(context.Context, *GetEffectivePermissionRequest) (*EffectivePermission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectivePermission not implemented")
+}
 func (UnimplementedDBServiceServer) UpdateFileMetadata(context.Context, *UpdateFileMetadataRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileMetadata not implemented")
 }
@@ -824,899 +1653,2060 @@ func (UnimplementedDBServiceServer) UnstarFile(context.Context, *FileID) (*empty
 func (UnimplementedDBServiceServer) MoveFile(context.Context, *MoveFileRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MoveFile not implemented")
 }
-func (UnimplementedDBServiceServer) CopyFile(context.Context, *CopyFileRequest) (*File, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CopyFile not implemented")
+func (UnimplementedDBServiceServer) CopyFile(context.Context, *CopyFileRequest) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyFile not implemented")
+}
+func (UnimplementedDBServiceServer) RenameFile(context.Context, *RenameFileRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameFile not implemented")
+}
+func (UnimplementedDBServiceServer) TransferOwnership(context.Context, *TransferOwnershipRequest) (*TransferOwnershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferOwnership not implemented")
+}
+func (UnimplementedDBServiceServer) VerifyFileIntegrity(context.Context, *FileID) (*IntegrityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyFileIntegrity not implemented")
+}
+func (UnimplementedDBServiceServer) CalculateFileChecksums(context.Context, *FileID) (*ChecksumsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateFileChecksums not implemented")
+}
+func (UnimplementedDBServiceServer) FindBySHA256(context.Context, *FindBySHA256Request) (*StorageBlob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindBySHA256 not implemented")
+}
+func (UnimplementedDBServiceServer) FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicates not implemented")
+}
+func (UnimplementedDBServiceServer) GetStorageBlob(context.Context, *StoragePathRequest) (*StorageBlob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageBlob not implemented")
+}
+func (UnimplementedDBServiceServer) ListUnreferencedBlobs(context.Context, *ListUnreferencedBlobsRequest) (*ListStorageBlobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnreferencedBlobs not implemented")
+}
+func (UnimplementedDBServiceServer) ReleaseBlob(context.Context, *StoragePathRequest) (*ReleaseBlobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseBlob not implemented")
+}
+func (UnimplementedDBServiceServer) CreateGroup(context.Context, *Group) (*GroupID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedDBServiceServer) GetGroup(context.Context, *GroupID) (*Group, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateGroup(context.Context, *UpdateGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroup not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedDBServiceServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedDBServiceServer) AddGroupMember(context.Context, *AddGroupMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGroupMember not implemented")
+}
+func (UnimplementedDBServiceServer) RemoveGroupMember(context.Context, *RemoveGroupMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupMember not implemented")
+}
+func (UnimplementedDBServiceServer) ListGroupMembers(context.Context, *GroupID) (*ListGroupMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroupMembers not implemented")
+}
+func (UnimplementedDBServiceServer) CreateShareLink(context.Context, *ShareLink) (*CreateShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShareLink not implemented")
+}
+func (UnimplementedDBServiceServer) ListShareLinks(context.Context, *FileID) (*ListShareLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShareLinks not implemented")
+}
+func (UnimplementedDBServiceServer) RevokeShareLink(context.Context, *ShareLinkID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShareLink not implemented")
+}
+func (UnimplementedDBServiceServer) ResolveShareLink(context.Context, *ResolveShareLinkRequest) (*ResolveShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveShareLink not implemented")
+}
+func (UnimplementedDBServiceServer) CreateSession(context.Context, *CreateSessionRequest) (*SessionTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
+}
+func (UnimplementedDBServiceServer) RotateSession(context.Context, *RotateSessionRequest) (*RotateSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSession not implemented")
+}
+func (UnimplementedDBServiceServer) ListSessions(context.Context, *UserID) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedDBServiceServer) RevokeSession(context.Context, *SessionID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedDBServiceServer) RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllSessions not implemented")
+}
+func (UnimplementedDBServiceServer) EnrollTwoFactor(context.Context, *UserID) (*EnrollTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTwoFactor not implemented")
+}
+func (UnimplementedDBServiceServer) ConfirmTwoFactor(context.Context, *TwoFactorCodeRequest) (*ConfirmTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTwoFactor not implemented")
+}
+func (UnimplementedDBServiceServer) VerifyTwoFactor(context.Context, *TwoFactorCodeRequest) (*VerifyTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTwoFactor not implemented")
+}
+func (UnimplementedDBServiceServer) ConsumeRecoveryCode(context.Context, *TwoFactorCodeRequest) (*ConsumeRecoveryCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConsumeRecoveryCode not implemented")
+}
+func (UnimplementedDBServiceServer) RegenerateRecoveryCodes(context.Context, *TwoFactorCodeRequest) (*RegenerateRecoveryCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
+func (UnimplementedDBServiceServer) RegisterWebAuthnCredential(context.Context, *WebAuthnCredential) (*WebAuthnCredential, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebAuthnCredential not implemented")
+}
+func (UnimplementedDBServiceServer) GetWebAuthnCredential(context.Context, *GetWebAuthnCredentialRequest) (*WebAuthnCredential, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebAuthnCredential not implemented")
+}
+func (UnimplementedDBServiceServer) ListWebAuthnCredentials(context.Context, *UserID) (*ListWebAuthnCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebAuthnCredentials not implemented")
+}
+func (UnimplementedDBServiceServer) RenameWebAuthnCredential(context.Context, *RenameWebAuthnCredentialRequest) (*WebAuthnCredential, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameWebAuthnCredential not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteWebAuthnCredential(context.Context, *WebAuthnCredentialID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebAuthnCredential not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateWebAuthnSignCount(context.Context, *UpdateWebAuthnSignCountRequest) (*UpdateWebAuthnSignCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebAuthnSignCount not implemented")
+}
+func (UnimplementedDBServiceServer) LinkIdentity(context.Context, *UserIdentity) (*UserIdentity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkIdentity not implemented")
+}
+func (UnimplementedDBServiceServer) UnlinkIdentity(context.Context, *UnlinkIdentityRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkIdentity not implemented")
+}
+func (UnimplementedDBServiceServer) ListIdentities(context.Context, *UserID) (*ListIdentitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIdentities not implemented")
+}
+func (UnimplementedDBServiceServer) GetUserByIdentity(context.Context, *IdentityKey) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByIdentity not implemented")
+}
+func (UnimplementedDBServiceServer) ListSecurityEvents(context.Context, *ListSecurityEventsRequest) (*ListSecurityEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecurityEvents not implemented")
+}
+func (UnimplementedDBServiceServer) mustEmbedUnimplementedDBServiceServer() {}
+func (UnimplementedDBServiceServer) testEmbeddedByValue()                   {}
+
+// UnsafeDBServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DBServiceServer will
+// result in compilation errors.
+type UnsafeDBServiceServer interface {
+	mustEmbedUnimplementedDBServiceServer()
+}
+
+func RegisterDBServiceServer(s grpc.ServiceRegistrar, srv DBServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDBServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DBService_ServiceDesc, srv)
+}
+
+func _DBService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreateUser(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetUserByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetUserByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetUserByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetUserByID(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetUserByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetUserByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetUserByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetUserByEmail(ctx, req.(*EmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetUserExtendedInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetUserExtendedInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetUserExtendedInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetUserExtendedInfo(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdatePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdatePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdatePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdatePassword(ctx, req.(*UpdatePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetPasswordHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetPasswordHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetPasswordHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetPasswordHistory(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetPreferences(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_SetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).SetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_SetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).SetPreferences(ctx, req.(*SetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_PatchPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).PatchPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_PatchPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).PatchPreferences(ctx, req.(*PatchPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateUsername(ctx, req.(*UpdateUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateEmailVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEmailVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateEmailVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateEmailVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateEmailVerification(ctx, req.(*UpdateEmailVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateLastLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateLastLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateLastLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateLastLogin(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateFailedLoginAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFailedLoginAttemptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateFailedLoginAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateFailedLoginAttempts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateFailedLoginAttempts(ctx, req.(*UpdateFailedLoginAttemptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateLockedUntil_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLockedUntilRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateLockedUntil(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateLockedUntil_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateLockedUntil(ctx, req.(*UpdateLockedUntilRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_RecordFailedLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).RecordFailedLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_RecordFailedLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).RecordFailedLogin(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_RecordSuccessfulLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).RecordSuccessfulLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_RecordSuccessfulLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).RecordSuccessfulLogin(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_IssueToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).IssueToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_IssueToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).IssueToken(ctx, req.(*IssueTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ConsumeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsumeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ConsumeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ConsumeToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ConsumeToken(ctx, req.(*ConsumeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_DeactivateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeactivateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeactivateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeactivateUser(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CancelUserDeletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CancelUserDeletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CancelUserDeletion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CancelUserDeletion(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListPendingErasures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingErasuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListPendingErasures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListPendingErasures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListPendingErasures(ctx, req.(*ListPendingErasuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_EraseUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).EraseUser(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateStorageUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateStorageUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateStorageUsage(ctx, req.(*UpdateStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CheckEmailExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CheckEmailExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CheckEmailExists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CheckEmailExists(ctx, req.(*EmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CheckUsernameExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CheckUsernameExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CheckUsernameExists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CheckUsernameExists(ctx, req.(*UsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CreateFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(File)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreateFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreateFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreateFile(ctx, req.(*File))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetFileByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetFileByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetFileByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetFileByID(ctx, req.(*FileID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetFileByPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileByPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetFileByPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetFileByPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetFileByPath(ctx, req.(*GetFileByPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateFile(ctx, req.(*UpdateFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeleteFile(ctx, req.(*FileID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_SoftDeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).SoftDeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_SoftDeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).SoftDeleteFile(ctx, req.(*FileID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_RestoreFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).RestoreFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_RestoreFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).RestoreFile(ctx, req.(*FileID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListFiles(ctx, req.(*ListFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListFilesByParent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFilesByParentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListFilesByParent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListFilesByParent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListFilesByParent(ctx, req.(*ListFilesByParentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListStarredFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStarredFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListStarredFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListStarredFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListStarredFiles(ctx, req.(*ListStarredFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListTrashedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListTrashedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListTrashedFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListTrashedFiles(ctx, req.(*ListTrashedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_SearchFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).SearchFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_SearchFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).SearchFiles(ctx, req.(*SearchFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetFileSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetFileSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetFileSize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetFileSize(ctx, req.(*FileID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateFileSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateFileSize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateFileSize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateFileSize(ctx, req.(*UpdateFileSizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateLastViewed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateLastViewed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateLastViewed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateLastViewed(ctx, req.(*FileID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetFileTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetFileTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetFileTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetFileTree(ctx, req.(*GetFileTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CreateRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileRevision)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreateRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreateRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreateRevision(ctx, req.(*FileRevision))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetRevisions(ctx, req.(*FileID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetRevision(ctx, req.(*GetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_DeleteRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevisionID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeleteRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeleteRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeleteRevision(ctx, req.(*RevisionID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_SetRevisionLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRevisionLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).SetRevisionLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_SetRevisionLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).SetRevisionLabel(ctx, req.(*SetRevisionLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ClearRevisionLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ClearRevisionLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ClearRevisionLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ClearRevisionLabel(ctx, req.(*GetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetRevisionByLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevisionByLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetRevisionByLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetRevisionByLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetRevisionByLabel(ctx, req.(*GetRevisionByLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_PruneRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).PruneRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_PruneRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).PruneRevisions(ctx, req.(*PruneRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CreatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreatePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreatePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreatePermission(ctx, req.(*CreatePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetPermissions(ctx, req.(*FileID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) RenameFile(context.Context, *RenameFileRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RenameFile not implemented")
+
+func _DBService_UpdatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdatePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdatePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdatePermission(ctx, req.(*UpdatePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) VerifyFileIntegrity(context.Context, *FileID) (*IntegrityResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifyFileIntegrity not implemented")
+
+func _DBService_DeletePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeletePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeletePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeletePermission(ctx, req.(*DeletePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) CalculateFileChecksums(context.Context, *FileID) (*ChecksumsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CalculateFileChecksums not implemented")
+
+func _DBService_CheckPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CheckPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CheckPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CheckPermission(ctx, req.(*CheckPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) mustEmbedUnimplementedDBServiceServer() {}
-func (UnimplementedDBServiceServer) testEmbeddedByValue()                   {}
 
-// UnsafeDBServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DBServiceServer will
-// result in compilation errors.
-type UnsafeDBServiceServer interface {
-	mustEmbedUnimplementedDBServiceServer()
+func _DBService_CheckPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CheckPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CheckPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CheckPermissions(ctx, req.(*CheckPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDBServiceServer(s grpc.ServiceRegistrar, srv DBServiceServer) {
-	// If the following call pancis, it indicates UnimplementedDBServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _DBService_GetEffectivePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectivePermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&DBService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetEffectivePermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetEffectivePermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetEffectivePermission(ctx, req.(*GetEffectivePermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(User)
+func _DBService_UpdateFileMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileMetadataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CreateUser(ctx, in)
+		return srv.(DBServiceServer).UpdateFileMetadata(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CreateUser_FullMethodName,
+		FullMethod: DBService_UpdateFileMetadata_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CreateUser(ctx, req.(*User))
+		return srv.(DBServiceServer).UpdateFileMetadata(ctx, req.(*UpdateFileMetadataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetUserByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserID)
+func _DBService_GetFileMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetUserByID(ctx, in)
+		return srv.(DBServiceServer).GetFileMetadata(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetUserByID_FullMethodName,
+		FullMethod: DBService_GetFileMetadata_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetUserByID(ctx, req.(*UserID))
+		return srv.(DBServiceServer).GetFileMetadata(ctx, req.(*FileID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetUserByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmailRequest)
+func _DBService_StarFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetUserByEmail(ctx, in)
+		return srv.(DBServiceServer).StarFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetUserByEmail_FullMethodName,
+		FullMethod: DBService_StarFile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetUserByEmail(ctx, req.(*EmailRequest))
+		return srv.(DBServiceServer).StarFile(ctx, req.(*FileID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetUserExtendedInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserID)
+func _DBService_UnstarFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetUserExtendedInfo(ctx, in)
+		return srv.(DBServiceServer).UnstarFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetUserExtendedInfo_FullMethodName,
+		FullMethod: DBService_UnstarFile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetUserExtendedInfo(ctx, req.(*UserID))
+		return srv.(DBServiceServer).UnstarFile(ctx, req.(*FileID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(User)
+func _DBService_MoveFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateUser(ctx, in)
+		return srv.(DBServiceServer).MoveFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateUser_FullMethodName,
+		FullMethod: DBService_MoveFile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateUser(ctx, req.(*User))
+		return srv.(DBServiceServer).MoveFile(ctx, req.(*MoveFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdatePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdatePasswordRequest)
+func _DBService_CopyFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdatePassword(ctx, in)
+		return srv.(DBServiceServer).CopyFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdatePassword_FullMethodName,
+		FullMethod: DBService_CopyFile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdatePassword(ctx, req.(*UpdatePasswordRequest))
+		return srv.(DBServiceServer).CopyFile(ctx, req.(*CopyFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUsernameRequest)
+func _DBService_RenameFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateUsername(ctx, in)
+		return srv.(DBServiceServer).RenameFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateUsername_FullMethodName,
+		FullMethod: DBService_RenameFile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateUsername(ctx, req.(*UpdateUsernameRequest))
+		return srv.(DBServiceServer).RenameFile(ctx, req.(*RenameFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateEmailVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateEmailVerificationRequest)
+func _DBService_TransferOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferOwnershipRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateEmailVerification(ctx, in)
+		return srv.(DBServiceServer).TransferOwnership(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateEmailVerification_FullMethodName,
+		FullMethod: DBService_TransferOwnership_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateEmailVerification(ctx, req.(*UpdateEmailVerificationRequest))
+		return srv.(DBServiceServer).TransferOwnership(ctx, req.(*TransferOwnershipRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateLastLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserID)
+func _DBService_VerifyFileIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateLastLogin(ctx, in)
+		return srv.(DBServiceServer).VerifyFileIntegrity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateLastLogin_FullMethodName,
+		FullMethod: DBService_VerifyFileIntegrity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateLastLogin(ctx, req.(*UserID))
+		return srv.(DBServiceServer).VerifyFileIntegrity(ctx, req.(*FileID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateFailedLoginAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateFailedLoginAttemptsRequest)
+func _DBService_CalculateFileChecksums_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateFailedLoginAttempts(ctx, in)
+		return srv.(DBServiceServer).CalculateFileChecksums(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateFailedLoginAttempts_FullMethodName,
+		FullMethod: DBService_CalculateFileChecksums_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateFailedLoginAttempts(ctx, req.(*UpdateFailedLoginAttemptsRequest))
+		return srv.(DBServiceServer).CalculateFileChecksums(ctx, req.(*FileID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateLockedUntil_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateLockedUntilRequest)
+func _DBService_FindBySHA256_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindBySHA256Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateLockedUntil(ctx, in)
+		return srv.(DBServiceServer).FindBySHA256(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateLockedUntil_FullMethodName,
+		FullMethod: DBService_FindBySHA256_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateLockedUntil(ctx, req.(*UpdateLockedUntilRequest))
+		return srv.(DBServiceServer).FindBySHA256(ctx, req.(*FindBySHA256Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateStorageUsageRequest)
+func _DBService_FindDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateStorageUsage(ctx, in)
+		return srv.(DBServiceServer).FindDuplicates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateStorageUsage_FullMethodName,
+		FullMethod: DBService_FindDuplicates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateStorageUsage(ctx, req.(*UpdateStorageUsageRequest))
+		return srv.(DBServiceServer).FindDuplicates(ctx, req.(*FindDuplicatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CheckEmailExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmailRequest)
+func _DBService_GetStorageBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoragePathRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CheckEmailExists(ctx, in)
+		return srv.(DBServiceServer).GetStorageBlob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CheckEmailExists_FullMethodName,
+		FullMethod: DBService_GetStorageBlob_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CheckEmailExists(ctx, req.(*EmailRequest))
+		return srv.(DBServiceServer).GetStorageBlob(ctx, req.(*StoragePathRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CheckUsernameExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UsernameRequest)
+func _DBService_ListUnreferencedBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnreferencedBlobsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CheckUsernameExists(ctx, in)
+		return srv.(DBServiceServer).ListUnreferencedBlobs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CheckUsernameExists_FullMethodName,
+		FullMethod: DBService_ListUnreferencedBlobs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CheckUsernameExists(ctx, req.(*UsernameRequest))
+		return srv.(DBServiceServer).ListUnreferencedBlobs(ctx, req.(*ListUnreferencedBlobsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CreateFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(File)
+func _DBService_ReleaseBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoragePathRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CreateFile(ctx, in)
+		return srv.(DBServiceServer).ReleaseBlob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CreateFile_FullMethodName,
+		FullMethod: DBService_ReleaseBlob_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CreateFile(ctx, req.(*File))
+		return srv.(DBServiceServer).ReleaseBlob(ctx, req.(*StoragePathRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetFileByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Group)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetFileByID(ctx, in)
+		return srv.(DBServiceServer).CreateGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetFileByID_FullMethodName,
+		FullMethod: DBService_CreateGroup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetFileByID(ctx, req.(*FileID))
+		return srv.(DBServiceServer).CreateGroup(ctx, req.(*Group))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetFileByPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFileByPathRequest)
+func _DBService_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetFileByPath(ctx, in)
+		return srv.(DBServiceServer).GetGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetFileByPath_FullMethodName,
+		FullMethod: DBService_GetGroup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetFileByPath(ctx, req.(*GetFileByPathRequest))
+		return srv.(DBServiceServer).GetGroup(ctx, req.(*GroupID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(File)
+func _DBService_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateFile(ctx, in)
+		return srv.(DBServiceServer).UpdateGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateFile_FullMethodName,
+		FullMethod: DBService_UpdateGroup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateFile(ctx, req.(*File))
+		return srv.(DBServiceServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).DeleteFile(ctx, in)
+		return srv.(DBServiceServer).DeleteGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_DeleteFile_FullMethodName,
+		FullMethod: DBService_DeleteGroup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).DeleteFile(ctx, req.(*FileID))
+		return srv.(DBServiceServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_SoftDeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).SoftDeleteFile(ctx, in)
+		return srv.(DBServiceServer).ListGroups(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_SoftDeleteFile_FullMethodName,
+		FullMethod: DBService_ListGroups_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).SoftDeleteFile(ctx, req.(*FileID))
+		return srv.(DBServiceServer).ListGroups(ctx, req.(*ListGroupsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_RestoreFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_AddGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddGroupMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).RestoreFile(ctx, in)
+		return srv.(DBServiceServer).AddGroupMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_RestoreFile_FullMethodName,
+		FullMethod: DBService_AddGroupMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).RestoreFile(ctx, req.(*FileID))
+		return srv.(DBServiceServer).AddGroupMember(ctx, req.(*AddGroupMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_ListFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListFilesRequest)
+func _DBService_RemoveGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveGroupMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).ListFiles(ctx, in)
+		return srv.(DBServiceServer).RemoveGroupMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_ListFiles_FullMethodName,
+		FullMethod: DBService_RemoveGroupMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).ListFiles(ctx, req.(*ListFilesRequest))
+		return srv.(DBServiceServer).RemoveGroupMember(ctx, req.(*RemoveGroupMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_ListFilesByParent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListFilesByParentRequest)
+func _DBService_ListGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).ListFilesByParent(ctx, in)
+		return srv.(DBServiceServer).ListGroupMembers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_ListFilesByParent_FullMethodName,
+		FullMethod: DBService_ListGroupMembers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).ListFilesByParent(ctx, req.(*ListFilesByParentRequest))
+		return srv.(DBServiceServer).ListGroupMembers(ctx, req.(*GroupID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_ListStarredFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListStarredFilesRequest)
+func _DBService_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareLink)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).ListStarredFiles(ctx, in)
+		return srv.(DBServiceServer).CreateShareLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_ListStarredFiles_FullMethodName,
+		FullMethod: DBService_CreateShareLink_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).ListStarredFiles(ctx, req.(*ListStarredFilesRequest))
+		return srv.(DBServiceServer).CreateShareLink(ctx, req.(*ShareLink))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_ListTrashedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTrashedFilesRequest)
+func _DBService_ListShareLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).ListTrashedFiles(ctx, in)
+		return srv.(DBServiceServer).ListShareLinks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_ListTrashedFiles_FullMethodName,
+		FullMethod: DBService_ListShareLinks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).ListTrashedFiles(ctx, req.(*ListTrashedFilesRequest))
+		return srv.(DBServiceServer).ListShareLinks(ctx, req.(*FileID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_SearchFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchFilesRequest)
+func _DBService_RevokeShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareLinkID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).SearchFiles(ctx, in)
+		return srv.(DBServiceServer).RevokeShareLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_SearchFiles_FullMethodName,
+		FullMethod: DBService_RevokeShareLink_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).SearchFiles(ctx, req.(*SearchFilesRequest))
+		return srv.(DBServiceServer).RevokeShareLink(ctx, req.(*ShareLinkID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetFileSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_ResolveShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveShareLinkRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetFileSize(ctx, in)
+		return srv.(DBServiceServer).ResolveShareLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetFileSize_FullMethodName,
+		FullMethod: DBService_ResolveShareLink_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetFileSize(ctx, req.(*FileID))
+		return srv.(DBServiceServer).ResolveShareLink(ctx, req.(*ResolveShareLinkRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateFileSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateFileSizeRequest)
+func _DBService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateFileSize(ctx, in)
+		return srv.(DBServiceServer).CreateSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateFileSize_FullMethodName,
+		FullMethod: DBService_CreateSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateFileSize(ctx, req.(*UpdateFileSizeRequest))
+		return srv.(DBServiceServer).CreateSession(ctx, req.(*CreateSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateLastViewed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_RotateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateLastViewed(ctx, in)
+		return srv.(DBServiceServer).RotateSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateLastViewed_FullMethodName,
+		FullMethod: DBService_RotateSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateLastViewed(ctx, req.(*FileID))
+		return srv.(DBServiceServer).RotateSession(ctx, req.(*RotateSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetFileTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFileTreeRequest)
+func _DBService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetFileTree(ctx, in)
+		return srv.(DBServiceServer).ListSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetFileTree_FullMethodName,
+		FullMethod: DBService_ListSessions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetFileTree(ctx, req.(*GetFileTreeRequest))
+		return srv.(DBServiceServer).ListSessions(ctx, req.(*UserID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CreateRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileRevision)
+func _DBService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CreateRevision(ctx, in)
+		return srv.(DBServiceServer).RevokeSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CreateRevision_FullMethodName,
+		FullMethod: DBService_RevokeSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CreateRevision(ctx, req.(*FileRevision))
+		return srv.(DBServiceServer).RevokeSession(ctx, req.(*SessionID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_RevokeAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAllSessionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetRevisions(ctx, in)
+		return srv.(DBServiceServer).RevokeAllSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetRevisions_FullMethodName,
+		FullMethod: DBService_RevokeAllSessions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetRevisions(ctx, req.(*FileID))
+		return srv.(DBServiceServer).RevokeAllSessions(ctx, req.(*RevokeAllSessionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRevisionRequest)
+func _DBService_EnrollTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetRevision(ctx, in)
+		return srv.(DBServiceServer).EnrollTwoFactor(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetRevision_FullMethodName,
+		FullMethod: DBService_EnrollTwoFactor_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetRevision(ctx, req.(*GetRevisionRequest))
+		return srv.(DBServiceServer).EnrollTwoFactor(ctx, req.(*UserID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_DeleteRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RevisionID)
+func _DBService_ConfirmTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCodeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).DeleteRevision(ctx, in)
+		return srv.(DBServiceServer).ConfirmTwoFactor(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_DeleteRevision_FullMethodName,
+		FullMethod: DBService_ConfirmTwoFactor_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).DeleteRevision(ctx, req.(*RevisionID))
+		return srv.(DBServiceServer).ConfirmTwoFactor(ctx, req.(*TwoFactorCodeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CreatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FilePermission)
+func _DBService_VerifyTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCodeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CreatePermission(ctx, in)
+		return srv.(DBServiceServer).VerifyTwoFactor(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CreatePermission_FullMethodName,
+		FullMethod: DBService_VerifyTwoFactor_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CreatePermission(ctx, req.(*FilePermission))
+		return srv.(DBServiceServer).VerifyTwoFactor(ctx, req.(*TwoFactorCodeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_ConsumeRecoveryCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCodeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetPermissions(ctx, in)
+		return srv.(DBServiceServer).ConsumeRecoveryCode(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetPermissions_FullMethodName,
+		FullMethod: DBService_ConsumeRecoveryCode_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetPermissions(ctx, req.(*FileID))
+		return srv.(DBServiceServer).ConsumeRecoveryCode(ctx, req.(*TwoFactorCodeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FilePermission)
+func _DBService_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCodeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdatePermission(ctx, in)
+		return srv.(DBServiceServer).RegenerateRecoveryCodes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdatePermission_FullMethodName,
+		FullMethod: DBService_RegenerateRecoveryCodes_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdatePermission(ctx, req.(*FilePermission))
+		return srv.(DBServiceServer).RegenerateRecoveryCodes(ctx, req.(*TwoFactorCodeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_DeletePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PermissionID)
+func _DBService_RegisterWebAuthnCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebAuthnCredential)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).DeletePermission(ctx, in)
+		return srv.(DBServiceServer).RegisterWebAuthnCredential(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_DeletePermission_FullMethodName,
+		FullMethod: DBService_RegisterWebAuthnCredential_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).DeletePermission(ctx, req.(*PermissionID))
+		return srv.(DBServiceServer).RegisterWebAuthnCredential(ctx, req.(*WebAuthnCredential))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CheckPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckPermissionRequest)
+func _DBService_GetWebAuthnCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebAuthnCredentialRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CheckPermission(ctx, in)
+		return srv.(DBServiceServer).GetWebAuthnCredential(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CheckPermission_FullMethodName,
+		FullMethod: DBService_GetWebAuthnCredential_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CheckPermission(ctx, req.(*CheckPermissionRequest))
+		return srv.(DBServiceServer).GetWebAuthnCredential(ctx, req.(*GetWebAuthnCredentialRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateFileMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateFileMetadataRequest)
+func _DBService_ListWebAuthnCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateFileMetadata(ctx, in)
+		return srv.(DBServiceServer).ListWebAuthnCredentials(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateFileMetadata_FullMethodName,
+		FullMethod: DBService_ListWebAuthnCredentials_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateFileMetadata(ctx, req.(*UpdateFileMetadataRequest))
+		return srv.(DBServiceServer).ListWebAuthnCredentials(ctx, req.(*UserID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetFileMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_RenameWebAuthnCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameWebAuthnCredentialRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetFileMetadata(ctx, in)
+		return srv.(DBServiceServer).RenameWebAuthnCredential(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetFileMetadata_FullMethodName,
+		FullMethod: DBService_RenameWebAuthnCredential_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetFileMetadata(ctx, req.(*FileID))
+		return srv.(DBServiceServer).RenameWebAuthnCredential(ctx, req.(*RenameWebAuthnCredentialRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_StarFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_DeleteWebAuthnCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebAuthnCredentialID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).StarFile(ctx, in)
+		return srv.(DBServiceServer).DeleteWebAuthnCredential(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_StarFile_FullMethodName,
+		FullMethod: DBService_DeleteWebAuthnCredential_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).StarFile(ctx, req.(*FileID))
+		return srv.(DBServiceServer).DeleteWebAuthnCredential(ctx, req.(*WebAuthnCredentialID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UnstarFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_UpdateWebAuthnSignCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebAuthnSignCountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UnstarFile(ctx, in)
+		return srv.(DBServiceServer).UpdateWebAuthnSignCount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UnstarFile_FullMethodName,
+		FullMethod: DBService_UpdateWebAuthnSignCount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UnstarFile(ctx, req.(*FileID))
+		return srv.(DBServiceServer).UpdateWebAuthnSignCount(ctx, req.(*UpdateWebAuthnSignCountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_MoveFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MoveFileRequest)
+func _DBService_LinkIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIdentity)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).MoveFile(ctx, in)
+		return srv.(DBServiceServer).LinkIdentity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_MoveFile_FullMethodName,
+		FullMethod: DBService_LinkIdentity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).MoveFile(ctx, req.(*MoveFileRequest))
+		return srv.(DBServiceServer).LinkIdentity(ctx, req.(*UserIdentity))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CopyFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CopyFileRequest)
+func _DBService_UnlinkIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkIdentityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CopyFile(ctx, in)
+		return srv.(DBServiceServer).UnlinkIdentity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CopyFile_FullMethodName,
+		FullMethod: DBService_UnlinkIdentity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CopyFile(ctx, req.(*CopyFileRequest))
+		return srv.(DBServiceServer).UnlinkIdentity(ctx, req.(*UnlinkIdentityRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_RenameFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RenameFileRequest)
+func _DBService_ListIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).RenameFile(ctx, in)
+		return srv.(DBServiceServer).ListIdentities(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_RenameFile_FullMethodName,
+		FullMethod: DBService_ListIdentities_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).RenameFile(ctx, req.(*RenameFileRequest))
+		return srv.(DBServiceServer).ListIdentities(ctx, req.(*UserID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_VerifyFileIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_GetUserByIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityKey)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).VerifyFileIntegrity(ctx, in)
+		return srv.(DBServiceServer).GetUserByIdentity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_VerifyFileIntegrity_FullMethodName,
+		FullMethod: DBService_GetUserByIdentity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).VerifyFileIntegrity(ctx, req.(*FileID))
+		return srv.(DBServiceServer).GetUserByIdentity(ctx, req.(*IdentityKey))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CalculateFileChecksums_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileID)
+func _DBService_ListSecurityEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecurityEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CalculateFileChecksums(ctx, in)
+		return srv.(DBServiceServer).ListSecurityEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CalculateFileChecksums_FullMethodName,
+		FullMethod: DBService_ListSecurityEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CalculateFileChecksums(ctx, req.(*FileID))
+		return srv.(DBServiceServer).ListSecurityEvents(ctx, req.(*ListSecurityEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1740,6 +3730,10 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserByEmail",
 			Handler:    _DBService_GetUserByEmail_Handler,
 		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _DBService_ListUsers_Handler,
+		},
 		{
 			MethodName: "GetUserExtendedInfo",
 			Handler:    _DBService_GetUserExtendedInfo_Handler,
@@ -1752,6 +3746,22 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdatePassword",
 			Handler:    _DBService_UpdatePassword_Handler,
 		},
+		{
+			MethodName: "GetPasswordHistory",
+			Handler:    _DBService_GetPasswordHistory_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _DBService_GetPreferences_Handler,
+		},
+		{
+			MethodName: "SetPreferences",
+			Handler:    _DBService_SetPreferences_Handler,
+		},
+		{
+			MethodName: "PatchPreferences",
+			Handler:    _DBService_PatchPreferences_Handler,
+		},
 		{
 			MethodName: "UpdateUsername",
 			Handler:    _DBService_UpdateUsername_Handler,
@@ -1772,6 +3782,38 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateLockedUntil",
 			Handler:    _DBService_UpdateLockedUntil_Handler,
 		},
+		{
+			MethodName: "RecordFailedLogin",
+			Handler:    _DBService_RecordFailedLogin_Handler,
+		},
+		{
+			MethodName: "RecordSuccessfulLogin",
+			Handler:    _DBService_RecordSuccessfulLogin_Handler,
+		},
+		{
+			MethodName: "IssueToken",
+			Handler:    _DBService_IssueToken_Handler,
+		},
+		{
+			MethodName: "ConsumeToken",
+			Handler:    _DBService_ConsumeToken_Handler,
+		},
+		{
+			MethodName: "DeactivateUser",
+			Handler:    _DBService_DeactivateUser_Handler,
+		},
+		{
+			MethodName: "CancelUserDeletion",
+			Handler:    _DBService_CancelUserDeletion_Handler,
+		},
+		{
+			MethodName: "ListPendingErasures",
+			Handler:    _DBService_ListPendingErasures_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _DBService_EraseUser_Handler,
+		},
 		{
 			MethodName: "UpdateStorageUsage",
 			Handler:    _DBService_UpdateStorageUsage_Handler,
@@ -1864,6 +3906,22 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRevision",
 			Handler:    _DBService_DeleteRevision_Handler,
 		},
+		{
+			MethodName: "SetRevisionLabel",
+			Handler:    _DBService_SetRevisionLabel_Handler,
+		},
+		{
+			MethodName: "ClearRevisionLabel",
+			Handler:    _DBService_ClearRevisionLabel_Handler,
+		},
+		{
+			MethodName: "GetRevisionByLabel",
+			Handler:    _DBService_GetRevisionByLabel_Handler,
+		},
+		{
+			MethodName: "PruneRevisions",
+			Handler:    _DBService_PruneRevisions_Handler,
+		},
 		{
 			MethodName: "CreatePermission",
 			Handler:    _DBService_CreatePermission_Handler,
@@ -1884,6 +3942,14 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckPermission",
 			Handler:    _DBService_CheckPermission_Handler,
 		},
+		{
+			MethodName: "CheckPermissions",
+			Handler:    _DBService_CheckPermissions_Handler,
+		},
+		{
+			MethodName: "GetEffectivePermission",
+			Handler:    _DBService_GetEffectivePermission_Handler,
+		},
 		{
 			MethodName: "UpdateFileMetadata",
 			Handler:    _DBService_UpdateFileMetadata_Handler,
@@ -1912,6 +3978,10 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RenameFile",
 			Handler:    _DBService_RenameFile_Handler,
 		},
+		{
+			MethodName: "TransferOwnership",
+			Handler:    _DBService_TransferOwnership_Handler,
+		},
 		{
 			MethodName: "VerifyFileIntegrity",
 			Handler:    _DBService_VerifyFileIntegrity_Handler,
@@ -1920,6 +3990,158 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CalculateFileChecksums",
 			Handler:    _DBService_CalculateFileChecksums_Handler,
 		},
+		{
+			MethodName: "FindBySHA256",
+			Handler:    _DBService_FindBySHA256_Handler,
+		},
+		{
+			MethodName: "FindDuplicates",
+			Handler:    _DBService_FindDuplicates_Handler,
+		},
+		{
+			MethodName: "GetStorageBlob",
+			Handler:    _DBService_GetStorageBlob_Handler,
+		},
+		{
+			MethodName: "ListUnreferencedBlobs",
+			Handler:    _DBService_ListUnreferencedBlobs_Handler,
+		},
+		{
+			MethodName: "ReleaseBlob",
+			Handler:    _DBService_ReleaseBlob_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _DBService_CreateGroup_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _DBService_GetGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _DBService_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _DBService_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _DBService_ListGroups_Handler,
+		},
+		{
+			MethodName: "AddGroupMember",
+			Handler:    _DBService_AddGroupMember_Handler,
+		},
+		{
+			MethodName: "RemoveGroupMember",
+			Handler:    _DBService_RemoveGroupMember_Handler,
+		},
+		{
+			MethodName: "ListGroupMembers",
+			Handler:    _DBService_ListGroupMembers_Handler,
+		},
+		{
+			MethodName: "CreateShareLink",
+			Handler:    _DBService_CreateShareLink_Handler,
+		},
+		{
+			MethodName: "ListShareLinks",
+			Handler:    _DBService_ListShareLinks_Handler,
+		},
+		{
+			MethodName: "RevokeShareLink",
+			Handler:    _DBService_RevokeShareLink_Handler,
+		},
+		{
+			MethodName: "ResolveShareLink",
+			Handler:    _DBService_ResolveShareLink_Handler,
+		},
+		{
+			MethodName: "CreateSession",
+			Handler:    _DBService_CreateSession_Handler,
+		},
+		{
+			MethodName: "RotateSession",
+			Handler:    _DBService_RotateSession_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _DBService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _DBService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "RevokeAllSessions",
+			Handler:    _DBService_RevokeAllSessions_Handler,
+		},
+		{
+			MethodName: "EnrollTwoFactor",
+			Handler:    _DBService_EnrollTwoFactor_Handler,
+		},
+		{
+			MethodName: "ConfirmTwoFactor",
+			Handler:    _DBService_ConfirmTwoFactor_Handler,
+		},
+		{
+			MethodName: "VerifyTwoFactor",
+			Handler:    _DBService_VerifyTwoFactor_Handler,
+		},
+		{
+			MethodName: "ConsumeRecoveryCode",
+			Handler:    _DBService_ConsumeRecoveryCode_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _DBService_RegenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "RegisterWebAuthnCredential",
+			Handler:    _DBService_RegisterWebAuthnCredential_Handler,
+		},
+		{
+			MethodName: "GetWebAuthnCredential",
+			Handler:    _DBService_GetWebAuthnCredential_Handler,
+		},
+		{
+			MethodName: "ListWebAuthnCredentials",
+			Handler:    _DBService_ListWebAuthnCredentials_Handler,
+		},
+		{
+			MethodName: "RenameWebAuthnCredential",
+			Handler:    _DBService_RenameWebAuthnCredential_Handler,
+		},
+		{
+			MethodName: "DeleteWebAuthnCredential",
+			Handler:    _DBService_DeleteWebAuthnCredential_Handler,
+		},
+		{
+			MethodName: "UpdateWebAuthnSignCount",
+			Handler:    _DBService_UpdateWebAuthnSignCount_Handler,
+		},
+		{
+			MethodName: "LinkIdentity",
+			Handler:    _DBService_LinkIdentity_Handler,
+		},
+		{
+			MethodName: "UnlinkIdentity",
+			Handler:    _DBService_UnlinkIdentity_Handler,
+		},
+		{
+			MethodName: "ListIdentities",
+			Handler:    _DBService_ListIdentities_Handler,
+		},
+		{
+			MethodName: "GetUserByIdentity",
+			Handler:    _DBService_GetUserByIdentity_Handler,
+		},
+		{
+			MethodName: "ListSecurityEvents",
+			Handler:    _DBService_ListSecurityEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/transport/grpc/protos/db_manager.proto",
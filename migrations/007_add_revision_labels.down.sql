@@ -0,0 +1,4 @@
+-- Откат меток ревизий
+DROP INDEX IF EXISTS homecloud.idx_file_revisions_label;
+ALTER TABLE homecloud.file_revisions DROP COLUMN IF EXISTS description;
+ALTER TABLE homecloud.file_revisions DROP COLUMN IF EXISTS label;
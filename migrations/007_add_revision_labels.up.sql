@@ -0,0 +1,6 @@
+-- Метки и описания ревизий ("final draft", "sent to client")
+ALTER TABLE homecloud.file_revisions ADD COLUMN label TEXT;
+ALTER TABLE homecloud.file_revisions ADD COLUMN description TEXT;
+
+-- Метка уникальна в пределах файла
+CREATE UNIQUE INDEX idx_file_revisions_label ON homecloud.file_revisions(file_id, label) WHERE label IS NOT NULL;
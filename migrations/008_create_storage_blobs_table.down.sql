@@ -0,0 +1,13 @@
+-- Откат счётчиков ссылок на физические объекты
+DROP TRIGGER IF EXISTS file_revisions_storage_blob_refs_update ON homecloud.file_revisions;
+DROP TRIGGER IF EXISTS file_revisions_storage_blob_refs_insert_delete ON homecloud.file_revisions;
+DROP TRIGGER IF EXISTS files_storage_blob_refs_update ON homecloud.files;
+DROP TRIGGER IF EXISTS files_storage_blob_refs_insert_delete ON homecloud.files;
+DROP FUNCTION IF EXISTS homecloud.file_revisions_storage_blob_refs();
+DROP FUNCTION IF EXISTS homecloud.files_storage_blob_refs();
+DROP FUNCTION IF EXISTS homecloud.storage_blob_unref(TEXT);
+DROP FUNCTION IF EXISTS homecloud.storage_blob_ref(TEXT, TEXT, BIGINT);
+DROP TABLE IF EXISTS homecloud.storage_blobs CASCADE;
+DROP INDEX IF EXISTS homecloud.idx_files_sha256;
+DROP INDEX IF EXISTS homecloud.idx_file_revisions_sha256;
+ALTER TABLE homecloud.file_revisions DROP COLUMN IF EXISTS sha256_checksum;
@@ -0,0 +1,101 @@
+-- SHA-256 для ревизий (у файлов уже есть)
+ALTER TABLE homecloud.file_revisions ADD COLUMN sha256_checksum TEXT;
+CREATE INDEX idx_file_revisions_sha256 ON homecloud.file_revisions(sha256_checksum);
+CREATE INDEX idx_files_sha256 ON homecloud.files(sha256_checksum);
+
+-- Физические объекты хранилища и счётчик ссылок на них
+CREATE TABLE homecloud.storage_blobs (
+    storage_path     TEXT      PRIMARY KEY,
+    sha256_checksum  TEXT,
+    size             BIGINT    NOT NULL DEFAULT 0,
+    ref_count        INTEGER   NOT NULL DEFAULT 0,
+    created_at       TIMESTAMP NOT NULL DEFAULT now(),
+    released_at      TIMESTAMP  -- когда исчезла последняя ссылка
+);
+
+CREATE INDEX idx_storage_blobs_sha256 ON homecloud.storage_blobs(sha256_checksum);
+CREATE INDEX idx_storage_blobs_unreferenced ON homecloud.storage_blobs(released_at) WHERE ref_count = 0;
+
+-- Заполняем счётчики по уже существующим файлам и ревизиям
+INSERT INTO homecloud.storage_blobs (storage_path, sha256_checksum, size, ref_count)
+SELECT storage_path, MAX(sha256_checksum), MAX(size), COUNT(*)
+FROM (
+    SELECT storage_path, sha256_checksum, size FROM homecloud.files WHERE NOT is_folder
+    UNION ALL
+    SELECT storage_path, sha256_checksum, COALESCE(size, 0) FROM homecloud.file_revisions
+) refs
+GROUP BY storage_path;
+
+CREATE OR REPLACE FUNCTION homecloud.storage_blob_ref(p_path TEXT, p_sha256 TEXT, p_size BIGINT)
+RETURNS void AS $$
+BEGIN
+    INSERT INTO homecloud.storage_blobs (storage_path, sha256_checksum, size, ref_count)
+    VALUES (p_path, p_sha256, COALESCE(p_size, 0), 1)
+    ON CONFLICT (storage_path) DO UPDATE
+        SET ref_count = homecloud.storage_blobs.ref_count + 1,
+            sha256_checksum = COALESCE(EXCLUDED.sha256_checksum, homecloud.storage_blobs.sha256_checksum),
+            released_at = NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION homecloud.storage_blob_unref(p_path TEXT)
+RETURNS void AS $$
+BEGIN
+    UPDATE homecloud.storage_blobs
+    SET ref_count = GREATEST(ref_count - 1, 0),
+        released_at = CASE WHEN ref_count <= 1 THEN now() ELSE NULL END
+    WHERE storage_path = p_path;
+END;
+$$ language 'plpgsql';
+
+-- Триггеры поддерживают ref_count при любых изменениях files и file_revisions
+CREATE OR REPLACE FUNCTION homecloud.files_storage_blob_refs()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND NOT OLD.is_folder THEN
+        PERFORM homecloud.storage_blob_unref(OLD.storage_path);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NOT NEW.is_folder THEN
+        PERFORM homecloud.storage_blob_ref(NEW.storage_path, NEW.sha256_checksum, NEW.size);
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION homecloud.file_revisions_storage_blob_refs()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM homecloud.storage_blob_unref(OLD.storage_path);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM homecloud.storage_blob_ref(NEW.storage_path, NEW.sha256_checksum, NEW.size);
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER files_storage_blob_refs_insert_delete
+    AFTER INSERT OR DELETE ON homecloud.files
+    FOR EACH ROW
+    EXECUTE FUNCTION homecloud.files_storage_blob_refs();
+
+CREATE TRIGGER files_storage_blob_refs_update
+    AFTER UPDATE OF storage_path, sha256_checksum, is_folder ON homecloud.files
+    FOR EACH ROW
+    WHEN (OLD.storage_path IS DISTINCT FROM NEW.storage_path
+       OR OLD.sha256_checksum IS DISTINCT FROM NEW.sha256_checksum
+       OR OLD.is_folder IS DISTINCT FROM NEW.is_folder)
+    EXECUTE FUNCTION homecloud.files_storage_blob_refs();
+
+CREATE TRIGGER file_revisions_storage_blob_refs_insert_delete
+    AFTER INSERT OR DELETE ON homecloud.file_revisions
+    FOR EACH ROW
+    EXECUTE FUNCTION homecloud.file_revisions_storage_blob_refs();
+
+CREATE TRIGGER file_revisions_storage_blob_refs_update
+    AFTER UPDATE OF storage_path, sha256_checksum ON homecloud.file_revisions
+    FOR EACH ROW
+    WHEN (OLD.storage_path IS DISTINCT FROM NEW.storage_path
+       OR OLD.sha256_checksum IS DISTINCT FROM NEW.sha256_checksum)
+    EXECUTE FUNCTION homecloud.file_revisions_storage_blob_refs();
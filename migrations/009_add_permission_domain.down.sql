@@ -0,0 +1,5 @@
+-- Откат доменных прав
+DROP INDEX IF EXISTS homecloud.idx_users_email_domain;
+DROP INDEX IF EXISTS homecloud.idx_file_permissions_unique_domain;
+ALTER TABLE homecloud.file_permissions DROP CONSTRAINT IF EXISTS chk_grantee_domain;
+ALTER TABLE homecloud.file_permissions DROP COLUMN IF EXISTS domain;
@@ -0,0 +1,10 @@
+-- Домен для прав с grantee_type = 'DOMAIN' (grantee_id хранит только UUID)
+ALTER TABLE homecloud.file_permissions ADD COLUMN domain TEXT;
+
+ALTER TABLE homecloud.file_permissions ADD CONSTRAINT chk_grantee_domain
+    CHECK ((grantee_type = 'DOMAIN') = (domain IS NOT NULL)) NOT VALID;
+
+CREATE UNIQUE INDEX idx_file_permissions_unique_domain ON homecloud.file_permissions(file_id, lower(domain)) WHERE grantee_type = 'DOMAIN';
+
+-- Поиск пользователей по домену email
+CREATE INDEX IF NOT EXISTS idx_users_email_domain ON homecloud.users(lower(split_part(email, '@', 2)));
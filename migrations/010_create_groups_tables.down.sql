@@ -0,0 +1,3 @@
+-- Откат групп пользователей
+DROP TABLE IF EXISTS homecloud.group_members CASCADE;
+DROP TABLE IF EXISTS homecloud.groups CASCADE;
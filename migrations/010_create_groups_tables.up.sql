@@ -0,0 +1,35 @@
+-- Группы пользователей
+CREATE TABLE homecloud.groups (
+    id           UUID      PRIMARY KEY DEFAULT gen_random_uuid(),
+    name         TEXT      NOT NULL,
+    description  TEXT,
+    created_by   UUID      NOT NULL,  -- пользователь, создавший группу
+    created_at   TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_groups_created_by ON homecloud.groups(created_by);
+
+-- Участники групп: пользователи и вложенные группы
+CREATE TABLE homecloud.group_members (
+    group_id     UUID      NOT NULL REFERENCES homecloud.groups(id) ON DELETE CASCADE,
+    member_id    UUID      NOT NULL,  -- user_id или id вложенной группы
+    member_type  TEXT      NOT NULL,  -- USER, GROUP
+    role         TEXT      NOT NULL DEFAULT 'MEMBER',  -- OWNER, ADMIN, MEMBER
+    created_at   TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (group_id, member_id, member_type)
+);
+
+CREATE INDEX idx_group_members_member ON homecloud.group_members(member_id, member_type);
+
+ALTER TABLE homecloud.group_members ADD CONSTRAINT chk_group_member_type
+    CHECK (member_type IN ('USER', 'GROUP'));
+
+-- Управлять группой могут только пользователи, вложенная группа - всегда MEMBER
+ALTER TABLE homecloud.group_members ADD CONSTRAINT chk_group_member_role
+    CHECK (role IN ('OWNER', 'ADMIN', 'MEMBER') AND (member_type = 'USER' OR role = 'MEMBER'));
+
+CREATE TRIGGER update_groups_updated_at
+    BEFORE UPDATE ON homecloud.groups
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
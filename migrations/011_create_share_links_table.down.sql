@@ -0,0 +1,2 @@
+-- Откат ссылок общего доступа
+DROP TABLE IF EXISTS homecloud.share_links CASCADE;
@@ -0,0 +1,25 @@
+-- Ссылки "доступ у всех, у кого есть ссылка"
+CREATE TABLE homecloud.share_links (
+    id             UUID      PRIMARY KEY DEFAULT gen_random_uuid(),
+    file_id        UUID      NOT NULL REFERENCES homecloud.files(id) ON DELETE CASCADE,
+    token_hash     TEXT      NOT NULL,  -- SHA-256 от токена, сам токен не хранится
+    role           TEXT      NOT NULL,  -- READER, COMMENTER, WRITER
+    created_by     UUID      NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at     TIMESTAMP,           -- NULL - бессрочно
+    password_hash  TEXT,                -- NULL - без пароля
+    max_uses       INTEGER,             -- NULL - без ограничения
+    use_count      INTEGER   NOT NULL DEFAULT 0,
+    last_used_at   TIMESTAMP,
+    revoked        BOOLEAN   NOT NULL DEFAULT FALSE,
+    revoked_at     TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_share_links_token_hash ON homecloud.share_links(token_hash);
+CREATE INDEX idx_share_links_file_id ON homecloud.share_links(file_id);
+
+ALTER TABLE homecloud.share_links ADD CONSTRAINT chk_share_link_role
+    CHECK (role IN ('WRITER', 'COMMENTER', 'READER'));
+
+ALTER TABLE homecloud.share_links ADD CONSTRAINT chk_share_link_max_uses
+    CHECK (max_uses IS NULL OR max_uses > 0);
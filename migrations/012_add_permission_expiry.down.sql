@@ -0,0 +1,4 @@
+-- Откат срока действия прав доступа
+DROP TABLE IF EXISTS homecloud.expired_permissions_log CASCADE;
+DROP INDEX IF EXISTS homecloud.idx_file_permissions_expires_at;
+ALTER TABLE homecloud.file_permissions DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,22 @@
+-- Срок действия прав доступа
+ALTER TABLE homecloud.file_permissions ADD COLUMN expires_at TIMESTAMP;  -- NULL - бессрочно
+
+CREATE INDEX idx_file_permissions_expires_at ON homecloud.file_permissions(expires_at) WHERE expires_at IS NOT NULL;
+
+-- Журнал прав, удалённых по истечении срока
+CREATE TABLE homecloud.expired_permissions_log (
+    id             UUID      PRIMARY KEY DEFAULT gen_random_uuid(),
+    permission_id  UUID      NOT NULL,
+    file_id        UUID      NOT NULL,
+    grantee_id     UUID,
+    grantee_type   TEXT      NOT NULL,
+    domain         TEXT,
+    role           TEXT      NOT NULL,
+    allow_share    BOOLEAN   NOT NULL,
+    granted_at     TIMESTAMP NOT NULL,
+    expired_at     TIMESTAMP NOT NULL,
+    removed_at     TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_expired_permissions_log_file_id ON homecloud.expired_permissions_log(file_id);
+CREATE INDEX idx_expired_permissions_log_grantee_id ON homecloud.expired_permissions_log(grantee_id);
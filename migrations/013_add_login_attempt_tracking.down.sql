@@ -0,0 +1 @@
+ALTER TABLE homecloud.users DROP COLUMN IF EXISTS last_failed_login_at;
@@ -0,0 +1,2 @@
+-- Время последней неудачной попытки входа: по нему сбрасывается счётчик неудач
+ALTER TABLE homecloud.users ADD COLUMN last_failed_login_at TIMESTAMP WITH TIME ZONE;
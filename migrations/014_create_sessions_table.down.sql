@@ -0,0 +1,2 @@
+-- Откат таблицы сессий
+DROP TABLE IF EXISTS homecloud.sessions CASCADE;
@@ -0,0 +1,24 @@
+-- Сессии пользователей с refresh-токенами.
+-- При ротации создаётся новая строка в той же семье (family_id), старая помечается ROTATED.
+CREATE TABLE homecloud.sessions (
+    id                  UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    family_id           UUID        NOT NULL,  -- Все ротации одного входа
+    user_id             UUID        NOT NULL REFERENCES homecloud.users(id) ON DELETE CASCADE,
+    refresh_token_hash  TEXT        NOT NULL,  -- SHA-256 от токена, сам токен не хранится
+    device_name         TEXT,
+    ip_address          TEXT,
+    user_agent          TEXT,
+    created_at          TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    last_used_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at          TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at          TIMESTAMP WITH TIME ZONE,
+    revoked_reason      TEXT,                  -- ROTATED, REVOKED, REUSE_DETECTED
+    replaced_by         UUID REFERENCES homecloud.sessions(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_sessions_refresh_token_hash ON homecloud.sessions(refresh_token_hash);
+CREATE INDEX idx_sessions_family_id ON homecloud.sessions(family_id);
+CREATE INDEX idx_sessions_user_id ON homecloud.sessions(user_id) WHERE revoked_at IS NULL;
+
+ALTER TABLE homecloud.sessions ADD CONSTRAINT chk_session_revoked_reason
+    CHECK (revoked_reason IS NULL OR revoked_reason IN ('ROTATED', 'REVOKED', 'REUSE_DETECTED'));
@@ -0,0 +1,2 @@
+-- Откат одноразовых токенов пользователей
+DROP TABLE IF EXISTS homecloud.user_tokens CASCADE;
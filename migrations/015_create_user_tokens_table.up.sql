@@ -0,0 +1,20 @@
+-- Одноразовые токены пользователей: подтверждение email, сброс пароля, смена email
+CREATE TABLE homecloud.user_tokens (
+    id           UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID        NOT NULL REFERENCES homecloud.users(id) ON DELETE CASCADE,
+    purpose      TEXT        NOT NULL,  -- EMAIL_VERIFICATION, PASSWORD_RESET, EMAIL_CHANGE
+    token_hash   TEXT        NOT NULL,  -- SHA-256 от токена, сам токен не хранится
+    new_email    VARCHAR(100),          -- Только для EMAIL_CHANGE
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at      TIMESTAMP WITH TIME ZONE   -- NULL - ещё не использован
+);
+
+CREATE UNIQUE INDEX idx_user_tokens_token_hash ON homecloud.user_tokens(token_hash);
+CREATE INDEX idx_user_tokens_user_purpose ON homecloud.user_tokens(user_id, purpose) WHERE used_at IS NULL;
+
+ALTER TABLE homecloud.user_tokens ADD CONSTRAINT chk_user_token_purpose
+    CHECK (purpose IN ('EMAIL_VERIFICATION', 'PASSWORD_RESET', 'EMAIL_CHANGE'));
+
+ALTER TABLE homecloud.user_tokens ADD CONSTRAINT chk_user_token_new_email
+    CHECK ((purpose = 'EMAIL_CHANGE') = (new_email IS NOT NULL));
@@ -0,0 +1,3 @@
+-- Откат двухфакторной аутентификации
+DROP TABLE IF EXISTS homecloud.user_recovery_codes CASCADE;
+DROP TABLE IF EXISTS homecloud.user_two_factor CASCADE;
@@ -0,0 +1,20 @@
+-- TOTP двухфакторная аутентификация
+CREATE TABLE homecloud.user_two_factor (
+    user_id           UUID        PRIMARY KEY REFERENCES homecloud.users(id) ON DELETE CASCADE,
+    secret_encrypted  BYTEA       NOT NULL,  -- AES-GCM, ключ в конфигурации сервиса
+    enabled           BOOLEAN     NOT NULL DEFAULT FALSE,  -- FALSE - секрет выдан, но не подтверждён кодом
+    enrolled_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    enabled_at        TIMESTAMP WITH TIME ZONE,
+    last_used_step    BIGINT                 -- Последний принятый 30-секундный интервал, защита от повтора кода
+);
+
+-- Резервные коды, хранятся только SHA-256
+CREATE TABLE homecloud.user_recovery_codes (
+    id          UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID        NOT NULL REFERENCES homecloud.users(id) ON DELETE CASCADE,
+    code_hash   TEXT        NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    used_at     TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_user_recovery_codes_user_hash ON homecloud.user_recovery_codes(user_id, code_hash);
@@ -0,0 +1,2 @@
+-- Откат таблицы ключей WebAuthn
+DROP TABLE IF EXISTS homecloud.webauthn_credentials CASCADE;
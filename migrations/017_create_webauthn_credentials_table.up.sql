@@ -0,0 +1,18 @@
+-- Ключи доступа WebAuthn (passkeys) пользователей
+CREATE TABLE homecloud.webauthn_credentials (
+    id             UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        UUID        NOT NULL REFERENCES homecloud.users(id) ON DELETE CASCADE,
+    credential_id  BYTEA       NOT NULL,  -- Идентификатор, выданный аутентификатором
+    public_key     BYTEA       NOT NULL,  -- COSE-ключ
+    sign_count     BIGINT      NOT NULL DEFAULT 0,
+    transports     TEXT[]      NOT NULL DEFAULT '{}',  -- usb, nfc, ble, internal, hybrid
+    name           TEXT        NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    last_used_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_webauthn_credentials_credential_id ON homecloud.webauthn_credentials(credential_id);
+CREATE INDEX idx_webauthn_credentials_user_id ON homecloud.webauthn_credentials(user_id);
+
+ALTER TABLE homecloud.webauthn_credentials ADD CONSTRAINT chk_webauthn_sign_count
+    CHECK (sign_count >= 0 AND sign_count <= 4294967295);
@@ -0,0 +1,2 @@
+-- Откат таблицы внешних учётных записей
+DROP TABLE IF EXISTS homecloud.user_identities CASCADE;
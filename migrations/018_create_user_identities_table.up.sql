@@ -0,0 +1,11 @@
+-- Внешние учётные записи (OAuth/OIDC), через которые пользователь может входить
+CREATE TABLE homecloud.user_identities (
+    provider   TEXT        NOT NULL,  -- google, github, ... (в нижнем регистре)
+    subject    TEXT        NOT NULL,  -- Идентификатор пользователя у провайдера (claim sub)
+    user_id    UUID        NOT NULL REFERENCES homecloud.users(id) ON DELETE CASCADE,
+    email      TEXT,                  -- Email, сообщённый провайдером при привязке
+    linked_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON homecloud.user_identities(user_id);
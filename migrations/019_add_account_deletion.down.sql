@@ -0,0 +1,5 @@
+-- Откат удаления аккаунтов
+ALTER TABLE homecloud.files DROP CONSTRAINT IF EXISTS fk_files_owner_id;
+DROP INDEX IF EXISTS homecloud.idx_users_erase_after;
+ALTER TABLE homecloud.users DROP COLUMN IF EXISTS erase_after;
+ALTER TABLE homecloud.users DROP COLUMN IF EXISTS deactivated_at;
@@ -0,0 +1,10 @@
+-- Удаление аккаунта: после деактивации данные стираются по истечении льготного срока
+ALTER TABLE homecloud.users ADD COLUMN deactivated_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE homecloud.users ADD COLUMN erase_after TIMESTAMP WITH TIME ZONE;  -- NULL - удаление не запланировано
+
+CREATE INDEX idx_users_erase_after ON homecloud.users(erase_after) WHERE erase_after IS NOT NULL;
+
+-- Файлы больше не могут ссылаться на несуществующего владельца.
+-- NOT VALID: уже висящие ссылки не проверяются, новые - проверяются.
+ALTER TABLE homecloud.files ADD CONSTRAINT fk_files_owner_id
+    FOREIGN KEY (owner_id) REFERENCES homecloud.users(id) NOT VALID;
@@ -0,0 +1,3 @@
+-- Откат: сравнение email снова с учётом регистра. Приведённые к нижнему регистру адреса не восстанавливаются.
+CREATE INDEX IF NOT EXISTS idx_users_email ON homecloud.users(email);
+DROP INDEX IF EXISTS homecloud.idx_users_email_lower;
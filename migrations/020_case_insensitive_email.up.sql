@@ -0,0 +1,36 @@
+-- Email сравнивается без учёта регистра: "Bob@X.com" и "bob@x.com" - один адрес.
+-- migrate.sh не останавливается на ошибках SQL, поэтому миграция включает ON_ERROR_STOP
+-- сама: при найденных совпадениях она откатывается и не помечается применённой.
+\set ON_ERROR_STOP on
+
+BEGIN;
+
+-- Сначала сообщаем обо всех адресах, которые совпадут после нормализации
+DO $$
+DECLARE
+    collision  RECORD;
+    collisions INTEGER := 0;
+BEGIN
+    FOR collision IN
+        SELECT lower(trim(email)) AS normalized,
+               string_agg(id::text || ' <' || email || '>', ', ' ORDER BY created_at) AS accounts
+        FROM homecloud.users
+        GROUP BY lower(trim(email))
+        HAVING COUNT(*) > 1
+    LOOP
+        RAISE WARNING 'email collision %: %', collision.normalized, collision.accounts;
+        collisions := collisions + 1;
+    END LOOP;
+
+    IF collisions > 0 THEN
+        RAISE EXCEPTION '% email collision(s) found: merge or rename the accounts listed above and rerun the migration', collisions;
+    END IF;
+END $$;
+
+UPDATE homecloud.users SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+CREATE UNIQUE INDEX idx_users_email_lower ON homecloud.users(lower(email));
+-- Поиск идёт по lower(email), обычный индекс больше не нужен
+DROP INDEX IF EXISTS homecloud.idx_users_email;
+
+COMMIT;
@@ -0,0 +1,2 @@
+-- Откат истории паролей
+DROP TABLE IF EXISTS homecloud.password_history CASCADE;
@@ -0,0 +1,14 @@
+-- История хешей паролей: запрет повторного использования последних N паролей.
+-- Хеши солёные, поэтому кандидата проверяет сервис аутентификации.
+CREATE TABLE homecloud.password_history (
+    id             BIGSERIAL   PRIMARY KEY,  -- Порядок записей, created_at в одной транзакции совпадает
+    user_id        UUID        NOT NULL REFERENCES homecloud.users(id) ON DELETE CASCADE,
+    password_hash  TEXT        NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_password_history_user_id ON homecloud.password_history(user_id, id DESC);
+
+-- Текущий пароль - первая запись истории
+INSERT INTO homecloud.password_history (user_id, password_hash, created_at)
+SELECT id, password_hash, COALESCE(updated_at, now()) FROM homecloud.users WHERE password_hash <> '';
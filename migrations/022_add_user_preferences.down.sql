@@ -0,0 +1,3 @@
+-- Откат настроек пользователя
+DROP FUNCTION IF EXISTS homecloud.jsonb_merge_patch(JSONB, JSONB);
+ALTER TABLE homecloud.users DROP COLUMN IF EXISTS preferences;
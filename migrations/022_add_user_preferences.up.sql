@@ -0,0 +1,27 @@
+-- Настройки пользователя (тема, язык, сортировка, уведомления). Схему ключей проверяет сервис.
+ALTER TABLE homecloud.users ADD COLUMN preferences JSONB NOT NULL DEFAULT '{}';
+
+-- JSON Merge Patch (RFC 7396): объекты сливаются рекурсивно, null удаляет ключ,
+-- любое другое значение заменяет прежнее целиком
+CREATE OR REPLACE FUNCTION homecloud.jsonb_merge_patch(target JSONB, patch JSONB)
+RETURNS JSONB AS $$
+BEGIN
+    IF patch IS NULL OR jsonb_typeof(patch) <> 'object' THEN
+        RETURN patch;
+    END IF;
+    IF target IS NULL OR jsonb_typeof(target) <> 'object' THEN
+        target := '{}';
+    END IF;
+    RETURN COALESCE((
+        SELECT jsonb_object_agg(key, value)
+        FROM (
+            SELECT key,
+                   CASE WHEN p.value IS NULL THEN t.value
+                        ELSE homecloud.jsonb_merge_patch(t.value, p.value) END AS value
+            FROM jsonb_each(target) t
+            FULL JOIN jsonb_each(patch) p USING (key)
+        ) merged
+        WHERE jsonb_typeof(value) <> 'null'
+    ), '{}');
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
@@ -0,0 +1,3 @@
+-- Откат журнала событий безопасности
+DROP TABLE IF EXISTS homecloud.security_events CASCADE;
+DROP FUNCTION IF EXISTS homecloud.security_events_append_only();
@@ -0,0 +1,35 @@
+-- Журнал событий безопасности пользователей. Только добавление: записи нельзя изменить
+-- или удалить, кроме каскадного удаления вместе с пользователем при стирании аккаунта.
+CREATE TABLE homecloud.security_events (
+    id          BIGSERIAL   PRIMARY KEY,  -- Порядок событий и курсор страниц
+    user_id     UUID        NOT NULL REFERENCES homecloud.users(id) ON DELETE CASCADE,
+    event_type  TEXT        NOT NULL,
+    actor_id    UUID,                     -- Кто выполнил действие, NULL - неизвестно
+    ip_address  TEXT,
+    user_agent  TEXT,
+    details     JSONB       NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_security_events_user_id ON homecloud.security_events(user_id, id DESC);
+
+ALTER TABLE homecloud.security_events ADD CONSTRAINT chk_security_event_type
+    CHECK (event_type IN ('PASSWORD_CHANGED', 'EMAIL_VERIFIED', 'EMAIL_UNVERIFIED', 'ACCOUNT_LOCKED', 'ACCOUNT_UNLOCKED',
+                          'ROLE_CHANGED', 'ACCOUNT_ACTIVATED', 'ACCOUNT_DEACTIVATED',
+                          'LOGIN_SUCCEEDED', 'LOGIN_FAILED', 'LOGIN_BLOCKED'));
+
+CREATE OR REPLACE FUNCTION homecloud.security_events_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Каскад от удалённого пользователя: строки users уже нет
+    IF TG_OP = 'DELETE' AND NOT EXISTS (SELECT 1 FROM homecloud.users WHERE id = OLD.user_id) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'security_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER security_events_append_only
+    BEFORE UPDATE OR DELETE ON homecloud.security_events
+    FOR EACH ROW
+    EXECUTE FUNCTION homecloud.security_events_append_only();
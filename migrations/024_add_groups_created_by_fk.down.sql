@@ -0,0 +1,3 @@
+-- Откат внешнего ключа создателя группы.
+-- NOT NULL не возвращается: у групп стёртых пользователей создателя уже нет.
+ALTER TABLE homecloud.groups DROP CONSTRAINT IF EXISTS fk_groups_created_by;
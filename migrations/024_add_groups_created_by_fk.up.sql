@@ -0,0 +1,9 @@
+-- Создатель группы ссылается на пользователя; при стирании аккаунта ссылка обнуляется
+ALTER TABLE homecloud.groups ALTER COLUMN created_by DROP NOT NULL;
+
+-- Группы, созданные уже удалёнными пользователями
+UPDATE homecloud.groups g SET created_by = NULL
+WHERE NOT EXISTS (SELECT 1 FROM homecloud.users u WHERE u.id = g.created_by);
+
+ALTER TABLE homecloud.groups ADD CONSTRAINT fk_groups_created_by
+    FOREIGN KEY (created_by) REFERENCES homecloud.users(id) ON DELETE SET NULL;
@@ -0,0 +1,22 @@
+-- Откат сохранения журнала после стирания: события стёртых пользователей удаляются
+CREATE OR REPLACE FUNCTION homecloud.security_events_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Каскад от удалённого пользователя: строки users уже нет
+    IF TG_OP = 'DELETE' AND NOT EXISTS (SELECT 1 FROM homecloud.users WHERE id = OLD.user_id) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'security_events is append-only';
+END;
+$$ language 'plpgsql';
+
+DELETE FROM homecloud.security_events e WHERE NOT EXISTS (SELECT 1 FROM homecloud.users u WHERE u.id = e.user_id);
+
+ALTER TABLE homecloud.security_events DROP CONSTRAINT chk_security_event_type;
+ALTER TABLE homecloud.security_events ADD CONSTRAINT chk_security_event_type
+    CHECK (event_type IN ('PASSWORD_CHANGED', 'EMAIL_VERIFIED', 'EMAIL_UNVERIFIED', 'ACCOUNT_LOCKED', 'ACCOUNT_UNLOCKED',
+                          'ROLE_CHANGED', 'ACCOUNT_ACTIVATED', 'ACCOUNT_DEACTIVATED',
+                          'LOGIN_SUCCEEDED', 'LOGIN_FAILED', 'LOGIN_BLOCKED'));
+
+ALTER TABLE homecloud.security_events ADD CONSTRAINT security_events_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES homecloud.users(id) ON DELETE CASCADE;
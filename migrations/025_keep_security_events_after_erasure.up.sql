@@ -0,0 +1,25 @@
+-- Журнал событий переживает стирание аккаунта: вместо каскадного удаления записи
+-- обезличиваются (IP и user agent очищаются), а последним событием пишется ACCOUNT_ERASED
+ALTER TABLE homecloud.security_events DROP CONSTRAINT IF EXISTS security_events_user_id_fkey;
+
+ALTER TABLE homecloud.security_events DROP CONSTRAINT chk_security_event_type;
+ALTER TABLE homecloud.security_events ADD CONSTRAINT chk_security_event_type
+    CHECK (event_type IN ('PASSWORD_CHANGED', 'EMAIL_VERIFIED', 'EMAIL_UNVERIFIED', 'ACCOUNT_LOCKED', 'ACCOUNT_UNLOCKED',
+                          'ROLE_CHANGED', 'ACCOUNT_ACTIVATED', 'ACCOUNT_DEACTIVATED',
+                          'LOGIN_SUCCEEDED', 'LOGIN_FAILED', 'LOGIN_BLOCKED', 'ACCOUNT_ERASED'));
+
+CREATE OR REPLACE FUNCTION homecloud.security_events_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Единственное допустимое изменение - обезличивание событий стёртого пользователя
+    IF TG_OP = 'UPDATE'
+       AND NOT EXISTS (SELECT 1 FROM homecloud.users WHERE id = OLD.user_id)
+       AND NEW.id = OLD.id AND NEW.user_id = OLD.user_id AND NEW.event_type = OLD.event_type
+       AND NEW.actor_id IS NOT DISTINCT FROM OLD.actor_id AND NEW.details = OLD.details
+       AND NEW.created_at = OLD.created_at
+       AND NEW.ip_address IS NULL AND NEW.user_agent IS NULL THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'security_events is append-only';
+END;
+$$ language 'plpgsql';
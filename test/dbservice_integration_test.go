@@ -4,6 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,9 +16,17 @@ import (
 
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"homecloud--dbmanager-service/internal/interfaces"
+	"homecloud--dbmanager-service/internal/logger"
+	"homecloud--dbmanager-service/internal/models"
 	"homecloud--dbmanager-service/internal/repository"
 	grpcServer "homecloud--dbmanager-service/internal/transport/grpc/dbManagerServer"
 	protos "homecloud--dbmanager-service/internal/transport/grpc/protos"
@@ -73,17 +86,77 @@ func setupTestDB(t *testing.T) *sql.DB {
 	return dbTest
 }
 
+// setupMigratedTestDB пересоздаёт тестовую базу и накатывает на неё migrations/*.up.sql
+func setupMigratedTestDB(t *testing.T) *sql.DB {
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=postgres sslmode=disable", testDBHost, testDBPort, testDBUser, testDBPassword)
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to postgres: %v", err)
+	}
+	_, _ = db.Exec("DROP DATABASE IF EXISTS " + testDBName)
+	_, err = db.Exec("CREATE DATABASE " + testDBName)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	db.Close()
+
+	dsnTest := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", testDBHost, testDBPort, testDBUser, testDBPassword, testDBName)
+	dbTest, err := sql.Open("postgres", dsnTest)
+	if err != nil {
+		t.Fatalf("failed to connect to test db: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join("..", "migrations", "*.up.sql"))
+	if err != nil {
+		t.Fatalf("failed to list migrations: %v", err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		// База уже создана выше, а .up.up.sql - дубликат миграции пользователей
+		if strings.HasPrefix(filepath.Base(file), "000_") || strings.HasSuffix(file, ".up.up.sql") {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read migration %s: %v", file, err)
+		}
+		// Метакоманды psql (\set и т.п.) сервер не понимает
+		var lines []string
+		for _, line := range strings.Split(string(content), "\n") {
+			if !strings.HasPrefix(strings.TrimSpace(line), "\\") {
+				lines = append(lines, line)
+			}
+		}
+		if _, err := dbTest.Exec(strings.Join(lines, "\n")); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", file, err)
+		}
+	}
+	return dbTest
+}
+
 func startTestGRPCServer(t *testing.T, repo interfaces.DBRepository) (addr string, stop func()) {
+	return startConfiguredTestGRPCServer(t, &grpcServer.Server{Repo: repo})
+}
+
+func startConfiguredTestGRPCServer(t *testing.T, srv *grpcServer.Server) (addr string, stop func()) {
 	lis, err := net.Listen("tcp", ":0")
 	if err != nil {
 		t.Fatalf("failed to listen: %v", err)
 	}
 	server := grpc.NewServer()
-	protos.RegisterDBServiceServer(server, &grpcServer.Server{Repo: repo})
+	protos.RegisterDBServiceServer(server, srv)
 	go server.Serve(lis)
 	return lis.Addr().String(), func() { server.Stop(); lis.Close() }
 }
 
+func newTestServer(t *testing.T, db *sql.DB) *grpcServer.Server {
+	log, err := logger.New("info")
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	return &grpcServer.Server{Repo: repository.NewDBRepository(db), Logger: log}
+}
+
 func getClient(t *testing.T, addr string) protos.DBServiceClient {
 	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -136,7 +209,7 @@ func TestDBService_AllMethods(t *testing.T) {
 
 	// UpdateUser
 	gotUser2.Username = "updateduser"
-	_, err = client.UpdateUser(ctx, gotUser2)
+	_, err = client.UpdateUser(ctx, &protos.UpdateUserRequest{User: gotUser2, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"username"}}})
 	if err != nil {
 		t.Fatalf("UpdateUser failed: %v", err)
 	}
@@ -205,3 +278,1901 @@ func TestDBService_AllMethods(t *testing.T) {
 		t.Errorf("CheckUsernameExists: expected true, got false")
 	}
 }
+
+func createTestUser(ctx context.Context, t *testing.T, client protos.DBServiceClient, name string) string {
+	id, err := client.CreateUser(ctx, &protos.User{
+		Email:        name + "@example.com",
+		Username:     name,
+		PasswordHash: "hash",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser %s failed: %v", name, err)
+	}
+	return id.Id
+}
+
+func createTestFile(ctx context.Context, t *testing.T, client protos.DBServiceClient, ownerID, name string) string {
+	id, err := client.CreateFile(ctx, &protos.File{
+		OwnerId:     ownerID,
+		Name:        name,
+		MimeType:    "text/plain",
+		StoragePath: "/storage/" + ownerID + "/" + name,
+		Size:        1,
+	})
+	if err != nil {
+		t.Fatalf("CreateFile %s failed: %v", name, err)
+	}
+	return id.Id
+}
+
+func TestDBService_GroupPermissions(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	memberID := createTestUser(ctx, t, client, "member")
+	nestedID := createTestUser(ctx, t, client, "nested")
+	fileID := createTestFile(ctx, t, client, ownerID, "report.txt")
+
+	groupID, err := client.CreateGroup(ctx, &protos.Group{Name: "team", CreatedBy: ownerID})
+	if err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	subgroupID, err := client.CreateGroup(ctx, &protos.Group{Name: "subteam", CreatedBy: ownerID})
+	if err != nil {
+		t.Fatalf("CreateGroup subgroup failed: %v", err)
+	}
+
+	addMember := func(groupID, memberID, memberType, actingUserID string) error {
+		_, err := client.AddGroupMember(ctx, &protos.AddGroupMemberRequest{
+			Member: &protos.GroupMember{
+				GroupId:    groupID,
+				MemberId:   memberID,
+				MemberType: memberType,
+				Role:       models.GroupRoleMember,
+			},
+			ActingUserId: actingUserID,
+		})
+		return err
+	}
+	if err := addMember(groupID.Id, memberID, models.GroupMemberUser, ownerID); err != nil {
+		t.Fatalf("AddGroupMember failed: %v", err)
+	}
+	if err := addMember(subgroupID.Id, nestedID, models.GroupMemberUser, ownerID); err != nil {
+		t.Fatalf("AddGroupMember subgroup failed: %v", err)
+	}
+	if err := addMember(groupID.Id, subgroupID.Id, models.GroupMemberGroup, ownerID); err != nil {
+		t.Fatalf("AddGroupMember nested group failed: %v", err)
+	}
+
+	// Рядовой участник не управляет составом группы
+	err = addMember(groupID.Id, ownerID, models.GroupMemberUser, memberID)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("AddGroupMember by MEMBER: expected PermissionDenied, got %v", err)
+	}
+
+	_, err = client.CreatePermission(ctx, &protos.CreatePermissionRequest{
+		Permission: &protos.FilePermission{
+			FileId:         fileID,
+			GranteeId:      groupID.Id,
+			GranteeKind:    protos.GranteeType_GRANTEE_TYPE_GROUP,
+			PermissionRole: protos.PermissionRole_PERMISSION_ROLE_WRITER,
+		},
+		ActingUserId: ownerID,
+	})
+	if err != nil {
+		t.Fatalf("CreatePermission failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		role   protos.PermissionRole
+		want   bool
+	}{
+		{"direct member has granted role", memberID, protos.PermissionRole_PERMISSION_ROLE_WRITER, true},
+		{"direct member has lower role", memberID, protos.PermissionRole_PERMISSION_ROLE_READER, true},
+		{"direct member lacks higher role", memberID, protos.PermissionRole_PERMISSION_ROLE_FILE_OWNER, false},
+		{"nested group member inherits grant", nestedID, protos.PermissionRole_PERMISSION_ROLE_WRITER, true},
+		{"owner keeps ownership", ownerID, protos.PermissionRole_PERMISSION_ROLE_FILE_OWNER, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.CheckPermission(ctx, &protos.CheckPermissionRequest{FileId: fileID, UserId: tt.userID, MinimumRole: tt.role})
+			if err != nil {
+				t.Fatalf("CheckPermission failed: %v", err)
+			}
+			if resp.HasPermission != tt.want {
+				t.Errorf("CheckPermission: expected %v, got %v", tt.want, resp.HasPermission)
+			}
+		})
+	}
+
+	_, err = client.RemoveGroupMember(ctx, &protos.RemoveGroupMemberRequest{
+		GroupId:      groupID.Id,
+		MemberId:     memberID,
+		MemberType:   models.GroupMemberUser,
+		ActingUserId: ownerID,
+	})
+	if err != nil {
+		t.Fatalf("RemoveGroupMember failed: %v", err)
+	}
+	resp, err := client.CheckPermission(ctx, &protos.CheckPermissionRequest{FileId: fileID, UserId: memberID, MinimumRole: protos.PermissionRole_PERMISSION_ROLE_READER})
+	if err != nil {
+		t.Fatalf("CheckPermission after removal failed: %v", err)
+	}
+	if resp.HasPermission {
+		t.Errorf("CheckPermission after removal: expected false, got true")
+	}
+}
+
+func TestDBService_ResolveShareLinkStatuses(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	fileID := createTestFile(ctx, t, client, ownerID, "shared.txt")
+	trashedFileID := createTestFile(ctx, t, client, ownerID, "trashed.txt")
+
+	createLink := func(t *testing.T, link *protos.ShareLink) *protos.CreateShareLinkResponse {
+		if link.FileId == "" {
+			link.FileId = fileID
+		}
+		link.CreatedBy = ownerID
+		link.Role = protos.PermissionRole_PERMISSION_ROLE_READER
+		resp, err := client.CreateShareLink(ctx, link)
+		if err != nil {
+			t.Fatalf("CreateShareLink failed: %v", err)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name             string
+		link             *protos.ShareLink
+		prepare          func(t *testing.T, link *protos.CreateShareLinkResponse)
+		token            string
+		passwordVerified bool
+		want             protos.ShareLinkStatus
+	}{
+		{
+			name: "valid",
+			link: &protos.ShareLink{},
+			want: protos.ShareLinkStatus_SHARE_LINK_STATUS_VALID,
+		},
+		{
+			name:  "unknown token",
+			token: "unknown-token",
+			want:  protos.ShareLinkStatus_SHARE_LINK_STATUS_NOT_FOUND,
+		},
+		{
+			name: "password required",
+			link: &protos.ShareLink{PasswordHash: "hash"},
+			want: protos.ShareLinkStatus_SHARE_LINK_STATUS_PASSWORD_REQUIRED,
+		},
+		{
+			name:             "password verified",
+			link:             &protos.ShareLink{PasswordHash: "hash"},
+			passwordVerified: true,
+			want:             protos.ShareLinkStatus_SHARE_LINK_STATUS_VALID,
+		},
+		{
+			name: "revoked",
+			link: &protos.ShareLink{},
+			prepare: func(t *testing.T, link *protos.CreateShareLinkResponse) {
+				if _, err := client.RevokeShareLink(ctx, &protos.ShareLinkID{Id: link.Id}); err != nil {
+					t.Fatalf("RevokeShareLink failed: %v", err)
+				}
+			},
+			want: protos.ShareLinkStatus_SHARE_LINK_STATUS_REVOKED,
+		},
+		{
+			name: "expired",
+			link: &protos.ShareLink{ExpiresAt: timestamppb.New(time.Now().Add(time.Hour))},
+			prepare: func(t *testing.T, link *protos.CreateShareLinkResponse) {
+				// Прошедший срок через API не задать, поэтому сдвигаем его в базе
+				if _, err := db.Exec(`UPDATE homecloud.share_links SET expires_at = NOW() - interval '1 minute' WHERE id=$1`, link.Id); err != nil {
+					t.Fatalf("failed to expire share link: %v", err)
+				}
+			},
+			want: protos.ShareLinkStatus_SHARE_LINK_STATUS_EXPIRED,
+		},
+		{
+			name: "use limit reached",
+			link: &protos.ShareLink{MaxUses: 1},
+			prepare: func(t *testing.T, link *protos.CreateShareLinkResponse) {
+				resp, err := client.ResolveShareLink(ctx, &protos.ResolveShareLinkRequest{Token: link.Token})
+				if err != nil {
+					t.Fatalf("ResolveShareLink failed: %v", err)
+				}
+				if resp.Status != protos.ShareLinkStatus_SHARE_LINK_STATUS_VALID {
+					t.Fatalf("first ResolveShareLink: expected VALID, got %v", resp.Status)
+				}
+			},
+			want: protos.ShareLinkStatus_SHARE_LINK_STATUS_USE_LIMIT_REACHED,
+		},
+		{
+			name: "file in trash",
+			link: &protos.ShareLink{FileId: trashedFileID},
+			prepare: func(t *testing.T, link *protos.CreateShareLinkResponse) {
+				if _, err := client.SoftDeleteFile(ctx, &protos.FileID{Id: trashedFileID}); err != nil {
+					t.Fatalf("SoftDeleteFile failed: %v", err)
+				}
+			},
+			want: protos.ShareLinkStatus_SHARE_LINK_STATUS_FILE_UNAVAILABLE,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.token
+			if tt.link != nil {
+				link := createLink(t, tt.link)
+				if tt.prepare != nil {
+					tt.prepare(t, link)
+				}
+				token = link.Token
+			}
+
+			resp, err := client.ResolveShareLink(ctx, &protos.ResolveShareLinkRequest{Token: token, PasswordVerified: tt.passwordVerified})
+			if err != nil {
+				t.Fatalf("ResolveShareLink failed: %v", err)
+			}
+			if resp.Status != tt.want {
+				t.Errorf("ResolveShareLink: expected %v, got %v", tt.want, resp.Status)
+			}
+			if tt.want == protos.ShareLinkStatus_SHARE_LINK_STATUS_VALID && resp.FileId != fileID {
+				t.Errorf("ResolveShareLink: expected file %s, got %s", fileID, resp.FileId)
+			}
+		})
+	}
+}
+
+func TestDBService_LoginLockout(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	srv := newTestServer(t, db)
+	srv.Lockout = models.LockoutPolicy{Threshold: 3, LockDuration: time.Minute, MaxLockDuration: time.Hour}
+	addr, stop := startConfiguredTestGRPCServer(t, srv)
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := &protos.UserID{Id: createTestUser(ctx, t, client, "user")}
+
+	// Неудачи до порога только считаются, на пороге аккаунт блокируется
+	for attempt := int32(1); attempt <= 3; attempt++ {
+		state, err := client.RecordFailedLogin(ctx, userID)
+		if err != nil {
+			t.Fatalf("RecordFailedLogin #%d failed: %v", attempt, err)
+		}
+		if state.FailedLoginAttempts != attempt {
+			t.Errorf("RecordFailedLogin #%d: expected %d attempts, got %d", attempt, attempt, state.FailedLoginAttempts)
+		}
+		if wantLocked := attempt == 3; state.Locked != wantLocked {
+			t.Errorf("RecordFailedLogin #%d: expected locked=%v, got %v", attempt, wantLocked, state.Locked)
+		}
+	}
+
+	lockedState, err := client.RecordFailedLogin(ctx, userID)
+	if err != nil {
+		t.Fatalf("RecordFailedLogin while locked failed: %v", err)
+	}
+
+	// Неудачи во время блокировки не засчитываются и не продлевают её
+	for i := 0; i < 3; i++ {
+		state, err := client.RecordFailedLogin(ctx, userID)
+		if err != nil {
+			t.Fatalf("RecordFailedLogin while locked failed: %v", err)
+		}
+		if !state.Locked || state.FailedLoginAttempts != 3 || !state.LockedUntil.AsTime().Equal(lockedState.LockedUntil.AsTime()) {
+			t.Errorf("RecordFailedLogin while locked: expected unchanged lock, got %+v", state)
+		}
+	}
+
+	// Успешный вход во время блокировки ничего не сбрасывает
+	state, err := client.RecordSuccessfulLogin(ctx, userID)
+	if err != nil {
+		t.Fatalf("RecordSuccessfulLogin while locked failed: %v", err)
+	}
+	if !state.Locked || state.FailedLoginAttempts != 3 {
+		t.Errorf("RecordSuccessfulLogin while locked: expected locked with 3 attempts, got locked=%v attempts=%d", state.Locked, state.FailedLoginAttempts)
+	}
+
+	// Снятие блокировки администратором, после чего вход сбрасывает счётчик
+	_, err = client.UpdateLockedUntil(ctx, &protos.UpdateLockedUntilRequest{Id: userID.Id})
+	if err != nil {
+		t.Fatalf("UpdateLockedUntil failed: %v", err)
+	}
+	state, err = client.RecordSuccessfulLogin(ctx, userID)
+	if err != nil {
+		t.Fatalf("RecordSuccessfulLogin after unlock failed: %v", err)
+	}
+	if state.Locked || state.FailedLoginAttempts != 0 || state.LockedUntil != nil {
+		t.Errorf("RecordSuccessfulLogin after unlock: expected reset state, got %+v", state)
+	}
+
+	// ACCOUNT_UNLOCKED пишется только при снятии действующей блокировки
+	neverLockedID := createTestUser(ctx, t, client, "never-locked")
+	_, err = client.UpdateLockedUntil(ctx, &protos.UpdateLockedUntilRequest{Id: neverLockedID})
+	if err != nil {
+		t.Fatalf("UpdateLockedUntil for unlocked user failed: %v", err)
+	}
+	countUnlocks := func(userID string) int {
+		var count int
+		err := db.QueryRow(`SELECT count(*) FROM homecloud.security_events WHERE user_id=$1 AND event_type=$2`, userID, models.SecurityEventAccountUnlocked).Scan(&count)
+		if err != nil {
+			t.Fatalf("failed to count security events: %v", err)
+		}
+		return count
+	}
+	if got := countUnlocks(neverLockedID); got != 0 {
+		t.Errorf("UpdateLockedUntil for unlocked user: expected no ACCOUNT_UNLOCKED events, got %d", got)
+	}
+	if got := countUnlocks(userID.Id); got != 1 {
+		t.Errorf("UpdateLockedUntil for locked user: expected one ACCOUNT_UNLOCKED event, got %d", got)
+	}
+}
+
+func TestDBService_PatchPreferences(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := createTestUser(ctx, t, client, "user")
+
+	initial, err := structpb.NewStruct(map[string]interface{}{
+		"theme":         "dark",
+		"language":      "ru",
+		"notifications": map[string]interface{}{"email": true, "push": true},
+	})
+	if err != nil {
+		t.Fatalf("failed to build preferences: %v", err)
+	}
+	if _, err := client.SetPreferences(ctx, &protos.SetPreferencesRequest{UserId: userID, Preferences: initial}); err != nil {
+		t.Fatalf("SetPreferences failed: %v", err)
+	}
+
+	// null удаляет ключ, вложенные объекты сливаются, остальные ключи не трогаются
+	patch, err := structpb.NewStruct(map[string]interface{}{
+		"language":      nil,
+		"default_sort":  map[string]interface{}{"field": "size"},
+		"notifications": map[string]interface{}{"push": false, "shares": true},
+	})
+	if err != nil {
+		t.Fatalf("failed to build patch: %v", err)
+	}
+	got, err := client.PatchPreferences(ctx, &protos.PatchPreferencesRequest{UserId: userID, Patch: patch})
+	if err != nil {
+		t.Fatalf("PatchPreferences failed: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"theme":         "dark",
+		"default_sort":  map[string]interface{}{"field": "size"},
+		"notifications": map[string]interface{}{"email": true, "push": false, "shares": true},
+	}
+	if !reflect.DeepEqual(got.Preferences.AsMap(), want) {
+		t.Errorf("PatchPreferences: expected %v, got %v", want, got.Preferences.AsMap())
+	}
+
+	// Патч, не проходящий схему, не меняет сохранённые настройки
+	invalid, err := structpb.NewStruct(map[string]interface{}{"theme": "neon"})
+	if err != nil {
+		t.Fatalf("failed to build patch: %v", err)
+	}
+	_, err = client.PatchPreferences(ctx, &protos.PatchPreferencesRequest{UserId: userID, Patch: invalid})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("PatchPreferences with invalid value: expected InvalidArgument, got %v", err)
+	}
+	stored, err := client.GetPreferences(ctx, &protos.UserID{Id: userID})
+	if err != nil {
+		t.Fatalf("GetPreferences failed: %v", err)
+	}
+	if !reflect.DeepEqual(stored.Preferences.AsMap(), want) {
+		t.Errorf("GetPreferences: expected %v, got %v", want, stored.Preferences.AsMap())
+	}
+}
+
+func grantTestPermission(ctx context.Context, t *testing.T, client protos.DBServiceClient, ownerID string, permission *protos.FilePermission) string {
+	id, err := client.CreatePermission(ctx, &protos.CreatePermissionRequest{Permission: permission, ActingUserId: ownerID})
+	if err != nil {
+		t.Fatalf("CreatePermission on %s failed: %v", permission.FileId, err)
+	}
+	return id.Id
+}
+
+func TestDBService_CheckPermissionsMatchesCheckPermission(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	userID := createTestUser(ctx, t, client, "reader")
+
+	folder, err := client.CreateFile(ctx, &protos.File{OwnerId: ownerID, Name: "folder", MimeType: "application/vnd.folder", StoragePath: "/storage/folder", IsFolder: true})
+	if err != nil {
+		t.Fatalf("CreateFile folder failed: %v", err)
+	}
+	inherited, err := client.CreateFile(ctx, &protos.File{OwnerId: ownerID, ParentId: folder.Id, Name: "inherited.txt", MimeType: "text/plain", StoragePath: "/storage/inherited.txt", Size: 1})
+	if err != nil {
+		t.Fatalf("CreateFile in folder failed: %v", err)
+	}
+	direct := createTestFile(ctx, t, client, ownerID, "direct.txt")
+	viaGroup := createTestFile(ctx, t, client, ownerID, "group.txt")
+	expired := createTestFile(ctx, t, client, ownerID, "expired.txt")
+	trashed := createTestFile(ctx, t, client, ownerID, "trashed.txt")
+	unshared := createTestFile(ctx, t, client, ownerID, "unshared.txt")
+	own := createTestFile(ctx, t, client, userID, "own.txt")
+
+	userGrant := func(fileID string, role protos.PermissionRole) *protos.FilePermission {
+		return &protos.FilePermission{FileId: fileID, GranteeId: userID, GranteeKind: protos.GranteeType_GRANTEE_TYPE_USER, PermissionRole: role}
+	}
+	grantTestPermission(ctx, t, client, ownerID, userGrant(direct, protos.PermissionRole_PERMISSION_ROLE_READER))
+	grantTestPermission(ctx, t, client, ownerID, userGrant(folder.Id, protos.PermissionRole_PERMISSION_ROLE_WRITER))
+	grantTestPermission(ctx, t, client, ownerID, userGrant(trashed, protos.PermissionRole_PERMISSION_ROLE_READER))
+
+	expiring := userGrant(expired, protos.PermissionRole_PERMISSION_ROLE_WRITER)
+	expiring.ExpiresAt = timestamppb.New(time.Now().Add(time.Hour))
+	expiredID := grantTestPermission(ctx, t, client, ownerID, expiring)
+	if _, err := db.Exec(`UPDATE homecloud.file_permissions SET expires_at = NOW() - interval '1 minute' WHERE id=$1`, expiredID); err != nil {
+		t.Fatalf("failed to expire permission: %v", err)
+	}
+
+	groupID, err := client.CreateGroup(ctx, &protos.Group{Name: "readers", CreatedBy: ownerID})
+	if err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	_, err = client.AddGroupMember(ctx, &protos.AddGroupMemberRequest{
+		Member:       &protos.GroupMember{GroupId: groupID.Id, MemberId: userID, MemberType: models.GroupMemberUser, Role: models.GroupRoleMember},
+		ActingUserId: ownerID,
+	})
+	if err != nil {
+		t.Fatalf("AddGroupMember failed: %v", err)
+	}
+	grantTestPermission(ctx, t, client, ownerID, &protos.FilePermission{
+		FileId:         viaGroup,
+		GranteeId:      groupID.Id,
+		GranteeKind:    protos.GranteeType_GRANTEE_TYPE_GROUP,
+		PermissionRole: protos.PermissionRole_PERMISSION_ROLE_COMMENTER,
+	})
+
+	if _, err := client.SoftDeleteFile(ctx, &protos.FileID{Id: trashed}); err != nil {
+		t.Fatalf("SoftDeleteFile failed: %v", err)
+	}
+
+	missing := "00000000-0000-0000-0000-000000000000"
+	fileIDs := []string{direct, inherited.Id, viaGroup, expired, trashed, unshared, own, missing}
+	roles := []protos.PermissionRole{
+		protos.PermissionRole_PERMISSION_ROLE_READER,
+		protos.PermissionRole_PERMISSION_ROLE_COMMENTER,
+		protos.PermissionRole_PERMISSION_ROLE_WRITER,
+		protos.PermissionRole_PERMISSION_ROLE_FILE_OWNER,
+	}
+	for _, role := range roles {
+		t.Run(role.String(), func(t *testing.T) {
+			batch, err := client.CheckPermissions(ctx, &protos.CheckPermissionsRequest{UserId: userID, FileIds: fileIDs, MinimumRole: role})
+			if err != nil {
+				t.Fatalf("CheckPermissions failed: %v", err)
+			}
+			if len(batch.Permissions) != len(fileIDs) {
+				t.Errorf("CheckPermissions: expected %d results, got %d", len(fileIDs), len(batch.Permissions))
+			}
+			for _, fileID := range fileIDs {
+				single, err := client.CheckPermission(ctx, &protos.CheckPermissionRequest{FileId: fileID, UserId: userID, MinimumRole: role})
+				if err != nil {
+					t.Fatalf("CheckPermission %s failed: %v", fileID, err)
+				}
+				if batch.Permissions[fileID] != single.HasPermission {
+					t.Errorf("file %s: CheckPermissions returned %v, CheckPermission returned %v", fileID, batch.Permissions[fileID], single.HasPermission)
+				}
+			}
+		})
+	}
+
+	// Проверяем и сами ответы, чтобы совпадение не было совпадением двух ошибок
+	batch, err := client.CheckPermissions(ctx, &protos.CheckPermissionsRequest{UserId: userID, FileIds: fileIDs, MinimumRole: protos.PermissionRole_PERMISSION_ROLE_READER})
+	if err != nil {
+		t.Fatalf("CheckPermissions failed: %v", err)
+	}
+	want := map[string]bool{direct: true, inherited.Id: true, viaGroup: true, expired: false, trashed: true, unshared: false, own: true, missing: false}
+	for fileID, allowed := range want {
+		if batch.Permissions[fileID] != allowed {
+			t.Errorf("CheckPermissions READER on %s: expected %v, got %v", fileID, allowed, batch.Permissions[fileID])
+		}
+	}
+
+	_, err = client.CheckPermissions(ctx, &protos.CheckPermissionsRequest{UserId: userID, FileIds: []string{"not-a-uuid"}, MinimumRole: protos.PermissionRole_PERMISSION_ROLE_READER})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CheckPermissions with malformed id: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDBService_CreateGroupErrors(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name      string
+		createdBy string
+		want      codes.Code
+	}{
+		{"unknown creator", "00000000-0000-0000-0000-000000000000", codes.NotFound},
+		{"malformed creator", "not-a-uuid", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CreateGroup(ctx, &protos.Group{Name: "team", CreatedBy: tt.createdBy})
+			if status.Code(err) != tt.want {
+				t.Errorf("CreateGroup: expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDBService_EraseUser(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	erasedID := createTestUser(ctx, t, client, "erased")
+	adminID := createTestUser(ctx, t, client, "admin")
+	memberID := createTestUser(ctx, t, client, "member")
+	createTestFile(ctx, t, client, erasedID, "private.txt")
+
+	createGroup := func(name string) string {
+		id, err := client.CreateGroup(ctx, &protos.Group{Name: name, CreatedBy: erasedID})
+		if err != nil {
+			t.Fatalf("CreateGroup %s failed: %v", name, err)
+		}
+		return id.Id
+	}
+	addMember := func(groupID, memberID, memberType, role string) {
+		_, err := client.AddGroupMember(ctx, &protos.AddGroupMemberRequest{
+			Member:       &protos.GroupMember{GroupId: groupID, MemberId: memberID, MemberType: memberType, Role: role},
+			ActingUserId: erasedID,
+		})
+		if err != nil {
+			t.Fatalf("AddGroupMember %s to %s failed: %v", memberID, groupID, err)
+		}
+	}
+	// В shared владение переходит ADMIN, а не более давнему MEMBER
+	shared := createGroup("shared")
+	addMember(shared, memberID, models.GroupMemberUser, models.GroupRoleMember)
+	addMember(shared, adminID, models.GroupMemberUser, models.GroupRoleAdmin)
+	// В solo других пользователей нет, только вложенная группа - solo удаляется
+	solo := createGroup("solo")
+	addMember(solo, shared, models.GroupMemberGroup, models.GroupRoleMember)
+
+	// Событие с IP и user agent, которые должны быть обезличены
+	auditCtx := metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", "203.0.113.7", "x-user-agent", "test-agent")
+	if _, err := client.RecordFailedLogin(auditCtx, &protos.UserID{Id: erasedID}); err != nil {
+		t.Fatalf("RecordFailedLogin failed: %v", err)
+	}
+
+	if _, err := client.DeactivateUser(ctx, &protos.UserID{Id: erasedID}); err != nil {
+		t.Fatalf("DeactivateUser failed: %v", err)
+	}
+	if _, err := client.EraseUser(ctx, &protos.UserID{Id: erasedID}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("EraseUser before grace period: expected FailedPrecondition, got %v", err)
+	}
+	if _, err := db.Exec(`UPDATE homecloud.users SET erase_after = NOW() - interval '1 minute' WHERE id=$1`, erasedID); err != nil {
+		t.Fatalf("failed to end grace period: %v", err)
+	}
+	pendingErasures := func() []string {
+		pending, err := client.ListPendingErasures(ctx, &protos.ListPendingErasuresRequest{})
+		if err != nil {
+			t.Fatalf("ListPendingErasures failed: %v", err)
+		}
+		var ids []string
+		for _, deletion := range pending.Deletions {
+			ids = append(ids, deletion.UserId)
+		}
+		return ids
+	}
+	if got := pendingErasures(); !reflect.DeepEqual(got, []string{erasedID}) {
+		t.Errorf("ListPendingErasures: expected [%s], got %v", erasedID, got)
+	}
+
+	result, err := client.EraseUser(ctx, &protos.UserID{Id: erasedID})
+	if err != nil {
+		t.Fatalf("EraseUser failed: %v", err)
+	}
+	if result.FilesDeleted != 1 || result.GroupsTransferred != 1 || result.GroupsDeleted != 1 {
+		t.Errorf("EraseUser: expected 1 file, 1 transferred and 1 deleted group, got %+v", result)
+	}
+	if len(result.StoragePaths) != 1 {
+		t.Errorf("EraseUser: expected one unreferenced storage path, got %v", result.StoragePaths)
+	}
+
+	var userExists bool
+	if err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM homecloud.users WHERE id=$1)`, erasedID).Scan(&userExists); err != nil {
+		t.Fatalf("failed to check user row: %v", err)
+	}
+	if userExists {
+		t.Errorf("EraseUser: user row still exists")
+	}
+	if got := pendingErasures(); len(got) != 0 {
+		t.Errorf("ListPendingErasures after erasure: expected none, got %v", got)
+	}
+	if _, err := client.EraseUser(ctx, &protos.UserID{Id: "erased"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("EraseUser with malformed id: expected InvalidArgument, got %v", err)
+	}
+
+	// Отменённое удаление повторно не отменяется
+	if _, err := client.DeactivateUser(ctx, &protos.UserID{Id: memberID}); err != nil {
+		t.Fatalf("DeactivateUser member failed: %v", err)
+	}
+	if _, err := client.CancelUserDeletion(ctx, &protos.UserID{Id: memberID}); err != nil {
+		t.Errorf("CancelUserDeletion failed: %v", err)
+	}
+	if _, err := client.CancelUserDeletion(ctx, &protos.UserID{Id: memberID}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CancelUserDeletion without a scheduled deletion: expected FailedPrecondition, got %v", err)
+	}
+
+	group, err := client.GetGroup(ctx, &protos.GroupID{Id: shared})
+	if err != nil {
+		t.Fatalf("GetGroup shared failed: %v", err)
+	}
+	if group.CreatedBy != "" {
+		t.Errorf("GetGroup shared: expected empty created_by, got %q", group.CreatedBy)
+	}
+	members, err := client.ListGroupMembers(ctx, &protos.GroupID{Id: shared})
+	if err != nil {
+		t.Fatalf("ListGroupMembers failed: %v", err)
+	}
+	roles := make(map[string]string)
+	for _, member := range members.Members {
+		roles[member.MemberId] = member.Role
+	}
+	wantRoles := map[string]string{adminID: models.GroupRoleOwner, memberID: models.GroupRoleMember}
+	if !reflect.DeepEqual(roles, wantRoles) {
+		t.Errorf("ListGroupMembers shared: expected %v, got %v", wantRoles, roles)
+	}
+	if _, err := client.GetGroup(ctx, &protos.GroupID{Id: solo}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetGroup solo: expected NotFound, got %v", err)
+	}
+
+	events, err := client.ListSecurityEvents(ctx, &protos.ListSecurityEventsRequest{UserId: erasedID})
+	if err != nil {
+		t.Fatalf("ListSecurityEvents failed: %v", err)
+	}
+	if len(events.Events) == 0 || events.Events[0].EventType != protos.SecurityEventType_SECURITY_EVENT_TYPE_ACCOUNT_ERASED {
+		t.Fatalf("ListSecurityEvents: expected ACCOUNT_ERASED as the latest event, got %v", events.Events)
+	}
+	for _, event := range events.Events {
+		if event.IpAddress != nil || event.UserAgent != nil {
+			t.Errorf("event %d: expected anonymized ip and user agent, got %v / %v", event.Id, event.GetIpAddress(), event.GetUserAgent())
+		}
+	}
+}
+
+func TestDBService_PasswordHistory(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	srv := newTestServer(t, db)
+	srv.PasswordHistoryDepth = 2
+	addr, stop := startConfiguredTestGRPCServer(t, srv)
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := createTestUser(ctx, t, client, "user")
+	history := func() []string {
+		resp, err := client.GetPasswordHistory(ctx, &protos.UserID{Id: userID})
+		if err != nil {
+			t.Fatalf("GetPasswordHistory failed: %v", err)
+		}
+		hashes := make([]string, len(resp.Entries))
+		for i, entry := range resp.Entries {
+			hashes[i] = entry.PasswordHash
+		}
+		return hashes
+	}
+	changePassword := func(hash string) {
+		if _, err := client.UpdatePassword(ctx, &protos.UpdatePasswordRequest{Id: userID, PasswordHash: hash}); err != nil {
+			t.Fatalf("UpdatePassword %s failed: %v", hash, err)
+		}
+	}
+
+	// Начальный пароль попадает в историю при создании пользователя
+	if got := history(); !reflect.DeepEqual(got, []string{"hash"}) {
+		t.Errorf("history after CreateUser: expected [hash], got %v", got)
+	}
+
+	changePassword("second")
+	if got := history(); !reflect.DeepEqual(got, []string{"second", "hash"}) {
+		t.Errorf("history after first change: expected [second hash], got %v", got)
+	}
+
+	// Старше depth записи обрезаются
+	changePassword("third")
+	if got := history(); !reflect.DeepEqual(got, []string{"third", "second"}) {
+		t.Errorf("history after second change: expected [third second], got %v", got)
+	}
+	var stored int
+	if err := db.QueryRow(`SELECT count(*) FROM homecloud.password_history WHERE user_id=$1`, userID).Scan(&stored); err != nil {
+		t.Fatalf("failed to count password history: %v", err)
+	}
+	if stored != 2 {
+		t.Errorf("password_history: expected 2 stored entries, got %d", stored)
+	}
+
+	// Без настройки глубины история не стирается
+	srv.PasswordHistoryDepth = 0
+	changePassword("fourth")
+	if err := db.QueryRow(`SELECT count(*) FROM homecloud.password_history WHERE user_id=$1`, userID).Scan(&stored); err != nil {
+		t.Fatalf("failed to count password history: %v", err)
+	}
+	if stored != 3 {
+		t.Errorf("password_history with depth 0: expected 3 stored entries, got %d", stored)
+	}
+}
+
+func TestDBService_SessionRotation(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := createTestUser(ctx, t, client, "user")
+	createSession := func(device string) *protos.SessionTokenResponse {
+		resp, err := client.CreateSession(ctx, &protos.CreateSessionRequest{
+			UserId:     userID,
+			DeviceName: device,
+			ExpiresAt:  timestamppb.New(time.Now().Add(time.Hour)),
+		})
+		if err != nil {
+			t.Fatalf("CreateSession %s failed: %v", device, err)
+		}
+		return resp
+	}
+	rotate := func(token string) *protos.RotateSessionResponse {
+		resp, err := client.RotateSession(ctx, &protos.RotateSessionRequest{RefreshToken: token})
+		if err != nil {
+			t.Fatalf("RotateSession failed: %v", err)
+		}
+		return resp
+	}
+
+	laptop := createSession("laptop")
+	rotated := rotate(laptop.RefreshToken)
+	if rotated.Status != protos.RotateSessionStatus_ROTATE_SESSION_STATUS_ROTATED || rotated.RefreshToken == "" {
+		t.Fatalf("RotateSession: expected ROTATED with a new token, got %v", rotated.Status)
+	}
+	if rotated.Session.FamilyId != laptop.Session.FamilyId || rotated.Session.Id == laptop.Session.Id {
+		t.Errorf("RotateSession: expected a new session in the same family")
+	}
+
+	// Повторное предъявление обменянного токена отзывает всю семью, включая свежий токен
+	if resp := rotate(laptop.RefreshToken); resp.Status != protos.RotateSessionStatus_ROTATE_SESSION_STATUS_REUSE_DETECTED {
+		t.Errorf("RotateSession with a rotated token: expected REUSE_DETECTED, got %v", resp.Status)
+	}
+	if resp := rotate(rotated.RefreshToken); resp.Status != protos.RotateSessionStatus_ROTATE_SESSION_STATUS_REVOKED {
+		t.Errorf("RotateSession after reuse: expected REVOKED, got %v", resp.Status)
+	}
+
+	if resp := rotate("unknown-token"); resp.Status != protos.RotateSessionStatus_ROTATE_SESSION_STATUS_NOT_FOUND {
+		t.Errorf("RotateSession with unknown token: expected NOT_FOUND, got %v", resp.Status)
+	}
+
+	expiring := createSession("tablet")
+	if _, err := db.Exec(`UPDATE homecloud.sessions SET expires_at = NOW() - interval '1 minute' WHERE id=$1`, expiring.Session.Id); err != nil {
+		t.Fatalf("failed to expire session: %v", err)
+	}
+	if resp := rotate(expiring.RefreshToken); resp.Status != protos.RotateSessionStatus_ROTATE_SESSION_STATUS_EXPIRED {
+		t.Errorf("RotateSession with expired session: expected EXPIRED, got %v", resp.Status)
+	}
+
+	// Отзыв всех входов, кроме текущего: текущий определяется по любой сессии его семьи
+	phone := createSession("phone")
+	desktop := createSession("desktop")
+	phoneRotated := rotate(phone.RefreshToken)
+	revoked, err := client.RevokeAllSessions(ctx, &protos.RevokeAllSessionsRequest{UserId: userID, ExceptSessionId: phone.Session.Id})
+	if err != nil {
+		t.Fatalf("RevokeAllSessions failed: %v", err)
+	}
+	if revoked.Revoked != 1 {
+		t.Errorf("RevokeAllSessions: expected 1 revoked session, got %d", revoked.Revoked)
+	}
+	sessions, err := client.ListSessions(ctx, &protos.UserID{Id: userID})
+	if err != nil {
+		t.Fatalf("ListSessions failed: %v", err)
+	}
+	if len(sessions.Sessions) != 1 || sessions.Sessions[0].Id != phoneRotated.Session.Id {
+		t.Errorf("ListSessions: expected only the rotated phone session, got %v", sessions.Sessions)
+	}
+	if resp := rotate(desktop.RefreshToken); resp.Status != protos.RotateSessionStatus_ROTATE_SESSION_STATUS_REVOKED {
+		t.Errorf("RotateSession after RevokeAllSessions: expected REVOKED, got %v", resp.Status)
+	}
+
+	_, err = client.CreateSession(ctx, &protos.CreateSessionRequest{
+		UserId:    "00000000-0000-0000-0000-000000000000",
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("CreateSession for unknown user: expected NotFound, got %v", err)
+	}
+	_, err = client.RevokeAllSessions(ctx, &protos.RevokeAllSessionsRequest{UserId: userID, ExceptSessionId: "current"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RevokeAllSessions with malformed except_session_id: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDBService_EffectivePermissionLinkSources(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	userID := createTestUser(ctx, t, client, "visitor")
+	fileID := createTestFile(ctx, t, client, ownerID, "shared.txt")
+	trashedID := createTestFile(ctx, t, client, ownerID, "trashed.txt")
+
+	createLink := func(fileID string, maxUses int32) *protos.CreateShareLinkResponse {
+		resp, err := client.CreateShareLink(ctx, &protos.ShareLink{FileId: fileID, CreatedBy: ownerID, Role: protos.PermissionRole_PERMISSION_ROLE_WRITER, MaxUses: maxUses})
+		if err != nil {
+			t.Fatalf("CreateShareLink failed: %v", err)
+		}
+		return resp
+	}
+	effective := func(fileID, linkID string) *protos.EffectivePermission {
+		resp, err := client.GetEffectivePermission(ctx, &protos.GetEffectivePermissionRequest{FileId: fileID, UserId: userID, ShareLinkId: linkID})
+		if err != nil {
+			t.Fatalf("GetEffectivePermission failed: %v", err)
+		}
+		return resp
+	}
+
+	// Ссылка повышает роль, но права делиться не даёт: оно берётся только из явных прав
+	grantTestPermission(ctx, t, client, ownerID, &protos.FilePermission{
+		FileId: fileID, GranteeId: userID, GranteeKind: protos.GranteeType_GRANTEE_TYPE_USER,
+		PermissionRole: protos.PermissionRole_PERMISSION_ROLE_READER,
+	})
+	valid := createLink(fileID, 0)
+	resp := effective(fileID, valid.Id)
+	if resp.Role != protos.PermissionRole_PERMISSION_ROLE_WRITER || resp.AllowShare {
+		t.Errorf("valid link: expected WRITER without allow_share, got %v allow_share=%v", resp.Role, resp.AllowShare)
+	}
+	if len(resp.Sources) != 2 || resp.Sources[0].Type != models.PermissionSourceLink {
+		t.Errorf("valid link: expected LINK then USER sources, got %v", resp.Sources)
+	}
+
+	spent := createLink(fileID, 1)
+	if _, err := client.ResolveShareLink(ctx, &protos.ResolveShareLinkRequest{Token: spent.Token}); err != nil {
+		t.Fatalf("ResolveShareLink failed: %v", err)
+	}
+	if resp := effective(fileID, spent.Id); resp.Role != protos.PermissionRole_PERMISSION_ROLE_READER || len(resp.Sources) != 1 {
+		t.Errorf("spent link: expected only the READER grant, got %v with %d sources", resp.Role, len(resp.Sources))
+	}
+
+	trashedLink := createLink(trashedID, 0)
+	if _, err := client.SoftDeleteFile(ctx, &protos.FileID{Id: trashedID}); err != nil {
+		t.Fatalf("SoftDeleteFile failed: %v", err)
+	}
+	if resp := effective(trashedID, trashedLink.Id); resp.Role != protos.PermissionRole_PERMISSION_ROLE_UNSPECIFIED || len(resp.Sources) != 0 {
+		t.Errorf("link to trashed file: expected no access, got %v with %d sources", resp.Role, len(resp.Sources))
+	}
+
+	_, err := client.GetEffectivePermission(ctx, &protos.GetEffectivePermissionRequest{FileId: fileID, UserId: userID, ShareLinkId: "link"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("malformed share_link_id: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDBService_UpdateMaskRequired(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	otherID := createTestUser(ctx, t, client, "other")
+	fileID := createTestFile(ctx, t, client, ownerID, "report.txt")
+
+	_, err := client.UpdateUser(ctx, &protos.UpdateUserRequest{User: &protos.User{Id: ownerID, Username: "renamed"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateUser without mask: expected InvalidArgument, got %v", err)
+	}
+	_, err = client.UpdateFile(ctx, &protos.UpdateFileRequest{File: &protos.File{Id: fileID, Name: "renamed.txt"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateFile without mask: expected InvalidArgument, got %v", err)
+	}
+	_, err = client.UpdateFile(ctx, &protos.UpdateFileRequest{
+		File:       &protos.File{Id: fileID, OwnerId: otherID},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"owner_id"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateFile with owner_id: expected InvalidArgument, got %v", err)
+	}
+
+	file, err := client.GetFileByID(ctx, &protos.FileID{Id: fileID})
+	if err != nil {
+		t.Fatalf("GetFileByID failed: %v", err)
+	}
+	if file.Name != "report.txt" || file.OwnerId != ownerID {
+		t.Errorf("rejected updates changed the file: name %s, owner %s", file.Name, file.OwnerId)
+	}
+}
+
+func TestDBService_PermissionInheritance(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	readerID := createTestUser(ctx, t, client, "reader")
+	editorID := createTestUser(ctx, t, client, "editor")
+
+	folder, err := client.CreateFile(ctx, &protos.File{OwnerId: ownerID, Name: "folder", MimeType: "application/vnd.folder", StoragePath: "/storage/folder", IsFolder: true})
+	if err != nil {
+		t.Fatalf("CreateFile folder failed: %v", err)
+	}
+	subfolder, err := client.CreateFile(ctx, &protos.File{OwnerId: ownerID, ParentId: folder.Id, Name: "subfolder", MimeType: "application/vnd.folder", StoragePath: "/storage/subfolder", IsFolder: true})
+	if err != nil {
+		t.Fatalf("CreateFile subfolder failed: %v", err)
+	}
+	file, err := client.CreateFile(ctx, &protos.File{OwnerId: ownerID, ParentId: subfolder.Id, Name: "nested.txt", MimeType: "text/plain", StoragePath: "/storage/nested.txt", Size: 1})
+	if err != nil {
+		t.Fatalf("CreateFile nested failed: %v", err)
+	}
+
+	userGrant := func(fileID, userID string, role protos.PermissionRole) *protos.FilePermission {
+		return &protos.FilePermission{FileId: fileID, GranteeId: userID, GranteeKind: protos.GranteeType_GRANTEE_TYPE_USER, PermissionRole: role}
+	}
+	// У читателя более сильное право на дальней папке побеждает слабое на ближней
+	grantTestPermission(ctx, t, client, ownerID, userGrant(folder.Id, readerID, protos.PermissionRole_PERMISSION_ROLE_WRITER))
+	grantTestPermission(ctx, t, client, ownerID, userGrant(subfolder.Id, readerID, protos.PermissionRole_PERMISSION_ROLE_READER))
+	editorGrantID := grantTestPermission(ctx, t, client, ownerID, userGrant(file.Id, editorID, protos.PermissionRole_PERMISSION_ROLE_COMMENTER))
+
+	resp, err := client.GetPermissions(ctx, &protos.FileID{Id: file.Id})
+	if err != nil {
+		t.Fatalf("GetPermissions failed: %v", err)
+	}
+	byGrantee := make(map[string]*protos.FilePermission, len(resp.Permissions))
+	for _, p := range resp.Permissions {
+		byGrantee[p.GranteeId] = p
+	}
+	if len(resp.Permissions) != 2 {
+		t.Fatalf("GetPermissions: expected one entry per grantee, got %d", len(resp.Permissions))
+	}
+
+	reader := byGrantee[readerID]
+	if reader == nil || reader.PermissionRole != protos.PermissionRole_PERMISSION_ROLE_WRITER {
+		t.Fatalf("GetPermissions: expected inherited WRITER for reader, got %v", reader)
+	}
+	if !reader.Inherited || reader.InheritedFrom != folder.Id || reader.FileId != file.Id {
+		t.Errorf("GetPermissions: expected inherited from %s on file %s, got inherited=%v from %s on %s",
+			folder.Id, file.Id, reader.Inherited, reader.InheritedFrom, reader.FileId)
+	}
+
+	editor := byGrantee[editorID]
+	if editor == nil || editor.Id != editorGrantID || editor.Inherited || editor.InheritedFrom != "" || editor.FileId != file.Id {
+		t.Errorf("GetPermissions: expected the direct grant for editor, got %v", editor)
+	}
+
+	// На самой папке право не унаследовано
+	resp, err = client.GetPermissions(ctx, &protos.FileID{Id: folder.Id})
+	if err != nil {
+		t.Fatalf("GetPermissions on folder failed: %v", err)
+	}
+	if len(resp.Permissions) != 1 || resp.Permissions[0].Inherited || resp.Permissions[0].FileId != folder.Id {
+		t.Errorf("GetPermissions on folder: expected one direct grant, got %v", resp.Permissions)
+	}
+}
+
+func TestDBService_PruneRevisions(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	fileID := createTestFile(ctx, t, client, ownerID, "report.txt")
+
+	revisionIDs := make(map[int64]string)
+	for n := int64(1); n <= 5; n++ {
+		id, err := client.CreateRevision(ctx, &protos.FileRevision{
+			FileId:      fileID,
+			RevisionId:  n,
+			Size:        n,
+			StoragePath: fmt.Sprintf("/storage/report.txt.%d", n),
+			MimeType:    "text/plain",
+			UserId:      ownerID,
+		})
+		if err != nil {
+			t.Fatalf("CreateRevision %d failed: %v", n, err)
+		}
+		revisionIDs[n] = id.Id
+	}
+
+	// Ревизия 1 помечена, ревизия 2 - текущая у файла: обе переживают очистку
+	_, err := client.SetRevisionLabel(ctx, &protos.SetRevisionLabelRequest{FileId: fileID, RevisionId: 1, Label: "final"})
+	if err != nil {
+		t.Fatalf("SetRevisionLabel failed: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE homecloud.files SET revision_id=$1 WHERE id=$2`, revisionIDs[2], fileID); err != nil {
+		t.Fatalf("failed to set current revision: %v", err)
+	}
+
+	pruned, err := client.PruneRevisions(ctx, &protos.PruneRevisionsRequest{FileId: fileID, KeepLatest: 2})
+	if err != nil {
+		t.Fatalf("PruneRevisions failed: %v", err)
+	}
+	if len(pruned.Revisions) != 1 || pruned.Revisions[0].Id != revisionIDs[3] {
+		t.Errorf("PruneRevisions: expected only revision 3 to be pruned, got %v", pruned.Revisions)
+	}
+
+	remaining, err := client.GetRevisions(ctx, &protos.FileID{Id: fileID})
+	if err != nil {
+		t.Fatalf("GetRevisions failed: %v", err)
+	}
+	var kept []int64
+	for _, revision := range remaining.Revisions {
+		kept = append(kept, revision.RevisionId)
+	}
+	if !reflect.DeepEqual(kept, []int64{5, 4, 2, 1}) {
+		t.Errorf("GetRevisions after prune: expected revisions [5 4 2 1], got %v", kept)
+	}
+
+	// Повторная очистка ничего не удаляет
+	pruned, err = client.PruneRevisions(ctx, &protos.PruneRevisionsRequest{FileId: fileID, KeepLatest: 2})
+	if err != nil {
+		t.Fatalf("second PruneRevisions failed: %v", err)
+	}
+	if len(pruned.Revisions) != 0 {
+		t.Errorf("second PruneRevisions: expected nothing pruned, got %d revisions", len(pruned.Revisions))
+	}
+
+	_, err = client.PruneRevisions(ctx, &protos.PruneRevisionsRequest{FileId: fileID, KeepLatest: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("PruneRevisions with negative keep_latest: expected InvalidArgument, got %v", err)
+	}
+	_, err = client.PruneRevisions(ctx, &protos.PruneRevisionsRequest{FileId: "report.txt", KeepLatest: 2})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("PruneRevisions with malformed file_id: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDBService_StorageBlobRefCounts(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	otherID := createTestUser(ctx, t, client, "other")
+
+	const sha = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	sharedPath := "/storage/blobs/shared"
+	copyPath := "/storage/blobs/copy"
+	createFile := func(name, path string) string {
+		id, err := client.CreateFile(ctx, &protos.File{OwnerId: ownerID, Name: name, MimeType: "text/plain", StoragePath: path, Size: 10, Sha256Checksum: sha})
+		if err != nil {
+			t.Fatalf("CreateFile %s failed: %v", name, err)
+		}
+		return id.Id
+	}
+	refCount := func(path string) int64 {
+		blob, err := client.GetStorageBlob(ctx, &protos.StoragePathRequest{StoragePath: path})
+		if err != nil {
+			t.Fatalf("GetStorageBlob %s failed: %v", path, err)
+		}
+		return blob.RefCount
+	}
+
+	// Два файла делят один объект, третий хранит копию того же содержимого
+	original := createFile("original.txt", sharedPath)
+	dedup := createFile("dedup.txt", sharedPath)
+	createFile("copy.txt", copyPath)
+	revision, err := client.CreateRevision(ctx, &protos.FileRevision{FileId: original, RevisionId: 1, Size: 10, StoragePath: sharedPath, MimeType: "text/plain", UserId: ownerID, Sha256Checksum: sha})
+	if err != nil {
+		t.Fatalf("CreateRevision failed: %v", err)
+	}
+	if got := refCount(sharedPath); got != 3 {
+		t.Errorf("shared blob: expected 3 references, got %d", got)
+	}
+
+	found, err := client.FindBySHA256(ctx, &protos.FindBySHA256Request{Sha256Checksum: sha, OwnerId: ownerID})
+	if err != nil {
+		t.Fatalf("FindBySHA256 failed: %v", err)
+	}
+	if found.Sha256Checksum != sha || found.RefCount == 0 {
+		t.Errorf("FindBySHA256: expected a live blob with the hash, got %v", found)
+	}
+	_, err = client.FindBySHA256(ctx, &protos.FindBySHA256Request{Sha256Checksum: sha, OwnerId: otherID})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("FindBySHA256 for another owner: expected NotFound, got %v", err)
+	}
+
+	duplicates, err := client.FindDuplicates(ctx, &protos.FindDuplicatesRequest{OwnerId: ownerID})
+	if err != nil {
+		t.Fatalf("FindDuplicates failed: %v", err)
+	}
+	if len(duplicates.Groups) != 1 || len(duplicates.Groups[0].Files) != 3 {
+		t.Fatalf("FindDuplicates: expected one group of 3 files, got %v", duplicates.Groups)
+	}
+	// Файлы на одном объекте место не тратят: лишняя только копия
+	if duplicates.TotalWastedBytes != 10 {
+		t.Errorf("FindDuplicates: expected 10 wasted bytes, got %d", duplicates.TotalWastedBytes)
+	}
+
+	if _, err := client.DeleteFile(ctx, &protos.FileID{Id: dedup}); err != nil {
+		t.Fatalf("DeleteFile failed: %v", err)
+	}
+	if _, err := client.DeleteRevision(ctx, &protos.RevisionID{Id: revision.Id}); err != nil {
+		t.Fatalf("DeleteRevision failed: %v", err)
+	}
+	if got := refCount(sharedPath); got != 1 {
+		t.Errorf("shared blob after deletes: expected 1 reference, got %d", got)
+	}
+	released, err := client.ReleaseBlob(ctx, &protos.StoragePathRequest{StoragePath: sharedPath})
+	if err != nil {
+		t.Fatalf("ReleaseBlob failed: %v", err)
+	}
+	if released.Released {
+		t.Errorf("ReleaseBlob: a referenced blob must not be released")
+	}
+
+	if _, err := client.DeleteFile(ctx, &protos.FileID{Id: original}); err != nil {
+		t.Fatalf("DeleteFile failed: %v", err)
+	}
+	unreferenced, err := client.ListUnreferencedBlobs(ctx, &protos.ListUnreferencedBlobsRequest{})
+	if err != nil {
+		t.Fatalf("ListUnreferencedBlobs failed: %v", err)
+	}
+	if len(unreferenced.Blobs) != 1 || unreferenced.Blobs[0].StoragePath != sharedPath || unreferenced.Blobs[0].ReleasedAt == nil {
+		t.Errorf("ListUnreferencedBlobs: expected only the released shared blob, got %v", unreferenced.Blobs)
+	}
+
+	released, err = client.ReleaseBlob(ctx, &protos.StoragePathRequest{StoragePath: sharedPath})
+	if err != nil {
+		t.Fatalf("ReleaseBlob failed: %v", err)
+	}
+	if !released.Released {
+		t.Errorf("ReleaseBlob: expected the unreferenced blob to be released")
+	}
+	_, err = client.GetStorageBlob(ctx, &protos.StoragePathRequest{StoragePath: sharedPath})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetStorageBlob after release: expected NotFound, got %v", err)
+	}
+	if got := refCount(copyPath); got != 1 {
+		t.Errorf("copy blob: expected 1 reference, got %d", got)
+	}
+
+	_, err = client.FindDuplicates(ctx, &protos.FindDuplicatesRequest{OwnerId: "owner"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("FindDuplicates with malformed owner_id: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDBService_ShareLinkErrors(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	fileID := createTestFile(ctx, t, client, ownerID, "shared.txt")
+
+	created, err := client.CreateShareLink(ctx, &protos.ShareLink{FileId: fileID, CreatedBy: ownerID, Role: protos.PermissionRole_PERMISSION_ROLE_READER})
+	if err != nil {
+		t.Fatalf("CreateShareLink failed: %v", err)
+	}
+	links, err := client.ListShareLinks(ctx, &protos.FileID{Id: fileID})
+	if err != nil {
+		t.Fatalf("ListShareLinks failed: %v", err)
+	}
+	if len(links.Links) != 1 || links.Links[0].Id != created.Id {
+		t.Errorf("ListShareLinks: expected the created link, got %v", links.Links)
+	}
+
+	_, err = client.CreateShareLink(ctx, &protos.ShareLink{FileId: "00000000-0000-0000-0000-000000000000", CreatedBy: ownerID, Role: protos.PermissionRole_PERMISSION_ROLE_READER})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("CreateShareLink for unknown file: expected NotFound, got %v", err)
+	}
+	_, err = client.CreateShareLink(ctx, &protos.ShareLink{FileId: "shared.txt", CreatedBy: ownerID, Role: protos.PermissionRole_PERMISSION_ROLE_READER})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateShareLink with malformed file_id: expected InvalidArgument, got %v", err)
+	}
+	_, err = client.ListShareLinks(ctx, &protos.FileID{Id: "shared.txt"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListShareLinks with malformed id: expected InvalidArgument, got %v", err)
+	}
+	_, err = client.RevokeShareLink(ctx, &protos.ShareLinkID{Id: "00000000-0000-0000-0000-000000000000"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("RevokeShareLink for unknown link: expected NotFound, got %v", err)
+	}
+}
+
+func TestDBService_PermissionSharingRules(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	editorID := createTestUser(ctx, t, client, "editor")
+	readerID := createTestUser(ctx, t, client, "reader")
+	guestID := createTestUser(ctx, t, client, "guest")
+	fileID := createTestFile(ctx, t, client, ownerID, "shared.txt")
+
+	grant := func(userID string, role protos.PermissionRole, allowShare bool) *protos.FilePermission {
+		return &protos.FilePermission{FileId: fileID, GranteeId: userID, GranteeKind: protos.GranteeType_GRANTEE_TYPE_USER, PermissionRole: role, AllowShare: allowShare}
+	}
+	grantTestPermission(ctx, t, client, ownerID, grant(editorID, protos.PermissionRole_PERMISSION_ROLE_WRITER, true))
+	readerGrantID := grantTestPermission(ctx, t, client, ownerID, grant(readerID, protos.PermissionRole_PERMISSION_ROLE_READER, false))
+
+	// Без allow_share делиться нельзя
+	_, err := client.CreatePermission(ctx, &protos.CreatePermissionRequest{Permission: grant(guestID, protos.PermissionRole_PERMISSION_ROLE_READER, false), ActingUserId: readerID})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreatePermission by reader without allow_share: expected PermissionDenied, got %v", err)
+	}
+	// С allow_share - не выше собственной роли
+	guestGrantID := grantTestPermission(ctx, t, client, editorID, grant(guestID, protos.PermissionRole_PERMISSION_ROLE_COMMENTER, false))
+	_, err = client.CreatePermission(ctx, &protos.CreatePermissionRequest{Permission: grant(guestID, protos.PermissionRole_PERMISSION_ROLE_FILE_OWNER, false), ActingUserId: editorID})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreatePermission above own role: expected PermissionDenied, got %v", err)
+	}
+
+	upgraded := grant(readerID, protos.PermissionRole_PERMISSION_ROLE_FILE_OWNER, false)
+	upgraded.Id = readerGrantID
+	_, err = client.UpdatePermission(ctx, &protos.UpdatePermissionRequest{Permission: upgraded, ActingUserId: editorID})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("UpdatePermission above own role: expected PermissionDenied, got %v", err)
+	}
+	upgraded.PermissionRole = protos.PermissionRole_PERMISSION_ROLE_COMMENTER
+	if _, err := client.UpdatePermission(ctx, &protos.UpdatePermissionRequest{Permission: upgraded, ActingUserId: editorID}); err != nil {
+		t.Errorf("UpdatePermission within own role failed: %v", err)
+	}
+
+	if _, err := client.DeletePermission(ctx, &protos.DeletePermissionRequest{Id: guestGrantID, ActingUserId: editorID}); err != nil {
+		t.Errorf("DeletePermission failed: %v", err)
+	}
+	_, err = client.DeletePermission(ctx, &protos.DeletePermissionRequest{Id: guestGrantID, ActingUserId: editorID})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("DeletePermission of a deleted grant: expected NotFound, got %v", err)
+	}
+
+	missing := grant(guestID, protos.PermissionRole_PERMISSION_ROLE_READER, false)
+	missing.FileId = "00000000-0000-0000-0000-000000000000"
+	_, err = client.CreatePermission(ctx, &protos.CreatePermissionRequest{Permission: missing, ActingUserId: ownerID})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("CreatePermission on unknown file: expected NotFound, got %v", err)
+	}
+	_, err = client.CreatePermission(ctx, &protos.CreatePermissionRequest{Permission: grant("guest", protos.PermissionRole_PERMISSION_ROLE_READER, false), ActingUserId: ownerID})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreatePermission with malformed grantee_id: expected InvalidArgument, got %v", err)
+	}
+	_, err = client.DeletePermission(ctx, &protos.DeletePermissionRequest{Id: readerGrantID, ActingUserId: "owner"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("DeletePermission with malformed acting_user_id: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDBService_WebAuthnCredentials(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := createTestUser(ctx, t, client, "user")
+	credentialID := []byte("credential-1")
+	cred, err := client.RegisterWebAuthnCredential(ctx, &protos.WebAuthnCredential{
+		UserId: userID, CredentialId: credentialID, PublicKey: []byte("public-key"), SignCount: 5, Name: "Laptop",
+	})
+	if err != nil {
+		t.Fatalf("RegisterWebAuthnCredential failed: %v", err)
+	}
+
+	_, err = client.RegisterWebAuthnCredential(ctx, &protos.WebAuthnCredential{
+		UserId: userID, CredentialId: credentialID, PublicKey: []byte("public-key"), Name: "Copy",
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("RegisterWebAuthnCredential duplicate: expected AlreadyExists, got %v", err)
+	}
+	_, err = client.RegisterWebAuthnCredential(ctx, &protos.WebAuthnCredential{
+		UserId: "00000000-0000-0000-0000-000000000000", CredentialId: []byte("credential-2"), PublicKey: []byte("public-key"), Name: "Phone",
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("RegisterWebAuthnCredential for unknown user: expected NotFound, got %v", err)
+	}
+
+	signCount := func(count uint32) protos.WebAuthnSignCountStatus {
+		resp, err := client.UpdateWebAuthnSignCount(ctx, &protos.UpdateWebAuthnSignCountRequest{CredentialId: credentialID, SignCount: count})
+		if err != nil {
+			t.Fatalf("UpdateWebAuthnSignCount failed: %v", err)
+		}
+		return resp.Status
+	}
+	if got := signCount(6); got != protos.WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_UPDATED {
+		t.Errorf("UpdateWebAuthnSignCount with a higher count: expected UPDATED, got %v", got)
+	}
+	// Тот же счётчик повторно - признак скопированного ключа
+	if got := signCount(6); got != protos.WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_CLONE_DETECTED {
+		t.Errorf("UpdateWebAuthnSignCount with the same count: expected CLONE_DETECTED, got %v", got)
+	}
+	resp, err := client.UpdateWebAuthnSignCount(ctx, &protos.UpdateWebAuthnSignCountRequest{CredentialId: []byte("unknown"), SignCount: 1})
+	if err != nil {
+		t.Fatalf("UpdateWebAuthnSignCount for unknown credential failed: %v", err)
+	}
+	if resp.Status != protos.WebAuthnSignCountStatus_WEB_AUTHN_SIGN_COUNT_STATUS_NOT_FOUND {
+		t.Errorf("UpdateWebAuthnSignCount for unknown credential: expected NOT_FOUND, got %v", resp.Status)
+	}
+
+	creds, err := client.ListWebAuthnCredentials(ctx, &protos.UserID{Id: userID})
+	if err != nil {
+		t.Fatalf("ListWebAuthnCredentials failed: %v", err)
+	}
+	if len(creds.Credentials) != 1 || creds.Credentials[0].Id != cred.Id || creds.Credentials[0].SignCount != 6 {
+		t.Errorf("ListWebAuthnCredentials: expected the credential with sign count 6, got %v", creds.Credentials)
+	}
+	_, err = client.ListWebAuthnCredentials(ctx, &protos.UserID{Id: "user"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListWebAuthnCredentials with malformed id: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDBService_ExternalIdentities(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := createTestUser(ctx, t, client, "user")
+	otherID := createTestUser(ctx, t, client, "other")
+
+	linked, err := client.LinkIdentity(ctx, &protos.UserIdentity{Provider: "Google", Subject: "sub-1", UserId: userID, Email: "user@gmail.com"})
+	if err != nil {
+		t.Fatalf("LinkIdentity failed: %v", err)
+	}
+	if linked.Provider != "google" {
+		t.Errorf("LinkIdentity: expected provider to be lowercased, got %s", linked.Provider)
+	}
+	_, err = client.LinkIdentity(ctx, &protos.UserIdentity{Provider: "google", Subject: "sub-1", UserId: otherID})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("LinkIdentity of a linked subject: expected AlreadyExists, got %v", err)
+	}
+
+	u, err := client.GetUserByIdentity(ctx, &protos.IdentityKey{Provider: "GOOGLE", Subject: "sub-1"})
+	if err != nil {
+		t.Fatalf("GetUserByIdentity failed: %v", err)
+	}
+	if u.Id != userID {
+		t.Errorf("GetUserByIdentity: expected user %s, got %s", userID, u.Id)
+	}
+	_, err = client.GetUserByIdentity(ctx, &protos.IdentityKey{Provider: "google", Subject: "SUB-1"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetUserByIdentity with a different subject case: expected NotFound, got %v", err)
+	}
+
+	identities, err := client.ListIdentities(ctx, &protos.UserID{Id: userID})
+	if err != nil {
+		t.Fatalf("ListIdentities failed: %v", err)
+	}
+	if len(identities.Identities) != 1 || identities.Identities[0].Subject != "sub-1" {
+		t.Errorf("ListIdentities: expected the linked identity, got %v", identities.Identities)
+	}
+	_, err = client.ListIdentities(ctx, &protos.UserID{Id: "user"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListIdentities with malformed id: expected InvalidArgument, got %v", err)
+	}
+
+	// Без пароля и других способов входа последнюю привязку снять нельзя
+	if _, err := db.Exec(`UPDATE homecloud.users SET password_hash='' WHERE id=$1`, userID); err != nil {
+		t.Fatalf("failed to clear password: %v", err)
+	}
+	_, err = client.UnlinkIdentity(ctx, &protos.UnlinkIdentityRequest{Provider: "google", Subject: "sub-1", UserId: userID})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("UnlinkIdentity of the last login method: expected FailedPrecondition, got %v", err)
+	}
+	if _, err := client.LinkIdentity(ctx, &protos.UserIdentity{Provider: "github", Subject: "42", UserId: userID}); err != nil {
+		t.Fatalf("LinkIdentity github failed: %v", err)
+	}
+	if _, err := client.UnlinkIdentity(ctx, &protos.UnlinkIdentityRequest{Provider: "google", Subject: "sub-1", UserId: userID}); err != nil {
+		t.Errorf("UnlinkIdentity with another login method left failed: %v", err)
+	}
+	_, err = client.UnlinkIdentity(ctx, &protos.UnlinkIdentityRequest{Provider: "google", Subject: "sub-1", UserId: userID})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("UnlinkIdentity of an unlinked identity: expected NotFound, got %v", err)
+	}
+}
+
+func TestDBService_TransferOwnershipQuota(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	contributorID := createTestUser(ctx, t, client, "contributor")
+	recipientID := createTestUser(ctx, t, client, "recipient")
+
+	createFile := func(ownerID, parentID, name string, size int64, isFolder bool) string {
+		id, err := client.CreateFile(ctx, &protos.File{OwnerId: ownerID, ParentId: parentID, Name: name, MimeType: "text/plain", StoragePath: "/storage/" + name, Size: size, IsFolder: isFolder})
+		if err != nil {
+			t.Fatalf("CreateFile %s failed: %v", name, err)
+		}
+		return id.Id
+	}
+	// Папка владельца с его файлами и файлом другого пользователя внутри
+	folderID := createFile(ownerID, "", "folder", 0, true)
+	createFile(ownerID, folderID, "a.txt", 100, false)
+	createFile(ownerID, folderID, "b.txt", 50, false)
+	createFile(contributorID, folderID, "c.txt", 30, false)
+
+	setUsage := func(userID string, used int64) {
+		if _, err := client.UpdateStorageUsage(ctx, &protos.UpdateStorageUsageRequest{Id: userID, UsedSpace: used}); err != nil {
+			t.Fatalf("UpdateStorageUsage failed: %v", err)
+		}
+	}
+	setUsage(ownerID, 150)
+	setUsage(contributorID, 30)
+	setUsage(recipientID, 0)
+	setQuota := func(userID string, quota int64) {
+		_, err := client.UpdateUser(ctx, &protos.UpdateUserRequest{
+			User:       &protos.User{Id: userID, StorageQuota: quota},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"storage_quota"}},
+		})
+		if err != nil {
+			t.Fatalf("UpdateUser storage_quota failed: %v", err)
+		}
+	}
+	usedSpace := func(userID string) int64 {
+		u, err := client.GetUserByID(ctx, &protos.UserID{Id: userID})
+		if err != nil {
+			t.Fatalf("GetUserByID failed: %v", err)
+		}
+		return u.UsedSpace
+	}
+
+	setQuota(recipientID, 100)
+	_, err := client.TransferOwnership(ctx, &protos.TransferOwnershipRequest{FileId: folderID, NewOwnerId: recipientID})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("TransferOwnership over quota: expected ResourceExhausted, got %v", err)
+	}
+	if got := usedSpace(ownerID); got != 150 {
+		t.Errorf("rejected transfer changed the owner's usage: got %d", got)
+	}
+	folder, err := client.GetFileByID(ctx, &protos.FileID{Id: folderID})
+	if err != nil {
+		t.Fatalf("GetFileByID failed: %v", err)
+	}
+	if folder.OwnerId != ownerID {
+		t.Errorf("rejected transfer changed the folder owner to %s", folder.OwnerId)
+	}
+
+	setQuota(recipientID, 1000)
+	transfer, err := client.TransferOwnership(ctx, &protos.TransferOwnershipRequest{
+		FileId: folderID, NewOwnerId: recipientID, KeepPreviousAsRole: protos.PermissionRole_PERMISSION_ROLE_READER,
+	})
+	if err != nil {
+		t.Fatalf("TransferOwnership failed: %v", err)
+	}
+	if transfer.PreviousOwnerId != ownerID || transfer.FilesTransferred != 4 || transfer.BytesTransferred != 180 {
+		t.Errorf("TransferOwnership: expected 4 files and 180 bytes from %s, got %+v", ownerID, transfer)
+	}
+	wantUsage := map[string]int64{ownerID: 0, contributorID: 0, recipientID: 180}
+	for userID, want := range wantUsage {
+		if got := usedSpace(userID); got != want {
+			t.Errorf("used_space of %s: expected %d, got %d", userID, want, got)
+		}
+	}
+
+	check := func(role protos.PermissionRole) bool {
+		resp, err := client.CheckPermission(ctx, &protos.CheckPermissionRequest{FileId: folderID, UserId: ownerID, MinimumRole: role})
+		if err != nil {
+			t.Fatalf("CheckPermission failed: %v", err)
+		}
+		return resp.HasPermission
+	}
+	if !check(protos.PermissionRole_PERMISSION_ROLE_READER) || check(protos.PermissionRole_PERMISSION_ROLE_WRITER) {
+		t.Errorf("previous owner: expected to keep READER access only")
+	}
+
+	_, err = client.TransferOwnership(ctx, &protos.TransferOwnershipRequest{FileId: folderID, NewOwnerId: "recipient"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("TransferOwnership with malformed new_owner_id: expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDBService_CaseInsensitiveEmail(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	bob, err := client.CreateUser(ctx, &protos.User{Email: " Bob@Example.COM ", Username: "bob", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	stored, err := client.GetUserByID(ctx, &protos.UserID{Id: bob.Id})
+	if err != nil {
+		t.Fatalf("GetUserByID failed: %v", err)
+	}
+	if stored.Email != "bob@example.com" {
+		t.Errorf("CreateUser: expected normalized email bob@example.com, got %q", stored.Email)
+	}
+
+	found, err := client.GetUserByEmail(ctx, &protos.EmailRequest{Email: "BOB@example.com"})
+	if err != nil {
+		t.Fatalf("GetUserByEmail failed: %v", err)
+	}
+	if found.Id != bob.Id {
+		t.Errorf("GetUserByEmail with different case: expected %s, got %s", bob.Id, found.Id)
+	}
+	exists, err := client.CheckEmailExists(ctx, &protos.EmailRequest{Email: "bob@EXAMPLE.com"})
+	if err != nil {
+		t.Fatalf("CheckEmailExists failed: %v", err)
+	}
+	if !exists.Exists {
+		t.Errorf("CheckEmailExists with different case: expected true")
+	}
+
+	_, err = client.CreateUser(ctx, &protos.User{Email: "bob@example.com", Username: "bob2", PasswordHash: "hash"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateUser with the same email in another case: expected AlreadyExists, got %v", err)
+	}
+
+	aliceID := createTestUser(ctx, t, client, "alice")
+	_, err = client.UpdateUser(ctx, &protos.UpdateUserRequest{
+		User:       &protos.User{Id: aliceID, Email: "BOB@Example.com"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("UpdateUser to a taken email in another case: expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestDBService_CheckPermissionGrantees(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	colleague, err := client.CreateUser(ctx, &protos.User{Email: "colleague@Corp.example", Username: "colleague", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("CreateUser colleague failed: %v", err)
+	}
+	outsiderID := createTestUser(ctx, t, client, "outsider")
+
+	private := createTestFile(ctx, t, client, ownerID, "private.txt")
+	public := createTestFile(ctx, t, client, ownerID, "public.txt")
+	internal := createTestFile(ctx, t, client, ownerID, "internal.txt")
+	grantTestPermission(ctx, t, client, ownerID, &protos.FilePermission{
+		FileId: public, GranteeKind: protos.GranteeType_GRANTEE_TYPE_ANYONE, PermissionRole: protos.PermissionRole_PERMISSION_ROLE_READER,
+	})
+	grantTestPermission(ctx, t, client, ownerID, &protos.FilePermission{
+		FileId: internal, GranteeKind: protos.GranteeType_GRANTEE_TYPE_DOMAIN, Domain: "CORP.example", PermissionRole: protos.PermissionRole_PERMISSION_ROLE_WRITER,
+	})
+
+	tests := []struct {
+		name   string
+		fileID string
+		userID string
+		role   protos.PermissionRole
+		want   bool
+	}{
+		{"owner without an OWNER row", private, ownerID, protos.PermissionRole_PERMISSION_ROLE_OWNER, true},
+		{"no grant", private, outsiderID, protos.PermissionRole_PERMISSION_ROLE_READER, false},
+		{"anyone reader", public, outsiderID, protos.PermissionRole_PERMISSION_ROLE_READER, true},
+		{"anyone is not writer", public, outsiderID, protos.PermissionRole_PERMISSION_ROLE_WRITER, false},
+		{"domain matches case-insensitively", internal, colleague.Id, protos.PermissionRole_PERMISSION_ROLE_WRITER, true},
+		{"other domain", internal, outsiderID, protos.PermissionRole_PERMISSION_ROLE_READER, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.CheckPermission(ctx, &protos.CheckPermissionRequest{FileId: tt.fileID, UserId: tt.userID, MinimumRole: tt.role})
+			if err != nil {
+				t.Fatalf("CheckPermission failed: %v", err)
+			}
+			if resp.HasPermission != tt.want {
+				t.Errorf("CheckPermission: expected %v, got %v", tt.want, resp.HasPermission)
+			}
+		})
+	}
+}
+
+func TestDBService_ExpiredPermissions(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+	repo := repository.NewDBRepository(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ownerID := createTestUser(ctx, t, client, "owner")
+	guestID := createTestUser(ctx, t, client, "guest")
+	fileID := createTestFile(ctx, t, client, ownerID, "shared.txt")
+
+	grant := &protos.FilePermission{
+		FileId: fileID, GranteeId: guestID, GranteeKind: protos.GranteeType_GRANTEE_TYPE_USER,
+		PermissionRole: protos.PermissionRole_PERMISSION_ROLE_READER,
+		ExpiresAt:      timestamppb.New(time.Now().Add(-time.Minute)),
+	}
+	_, err := client.CreatePermission(ctx, &protos.CreatePermissionRequest{Permission: grant, ActingUserId: ownerID})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreatePermission with past expires_at: expected InvalidArgument, got %v", err)
+	}
+
+	grant.ExpiresAt = timestamppb.New(time.Now().Add(time.Hour))
+	permissionID := grantTestPermission(ctx, t, client, ownerID, grant)
+	check := func() bool {
+		resp, err := client.CheckPermission(ctx, &protos.CheckPermissionRequest{FileId: fileID, UserId: guestID, MinimumRole: protos.PermissionRole_PERMISSION_ROLE_READER})
+		if err != nil {
+			t.Fatalf("CheckPermission failed: %v", err)
+		}
+		return resp.HasPermission
+	}
+	if !check() {
+		t.Fatalf("CheckPermission before expiry: expected access")
+	}
+
+	if _, err := db.Exec(`UPDATE homecloud.file_permissions SET expires_at = NOW() - interval '1 minute' WHERE id=$1`, permissionID); err != nil {
+		t.Fatalf("failed to expire permission: %v", err)
+	}
+	if check() {
+		t.Errorf("CheckPermission after expiry: expected no access")
+	}
+	permissions, err := client.GetPermissions(ctx, &protos.FileID{Id: fileID})
+	if err != nil {
+		t.Fatalf("GetPermissions failed: %v", err)
+	}
+	if len(permissions.Permissions) != 0 {
+		t.Errorf("GetPermissions after expiry: expected no grants, got %v", permissions.Permissions)
+	}
+
+	removed, err := repo.SweepExpiredPermissions(ctx)
+	if err != nil {
+		t.Fatalf("SweepExpiredPermissions failed: %v", err)
+	}
+	if len(removed) != 1 || removed[0].ID != permissionID {
+		t.Fatalf("SweepExpiredPermissions: expected to remove %s, got %v", permissionID, removed)
+	}
+	var logged, remaining int
+	err = db.QueryRow(`SELECT
+			(SELECT COUNT(*) FROM homecloud.expired_permissions_log WHERE permission_id=$1),
+			(SELECT COUNT(*) FROM homecloud.file_permissions WHERE id=$1)`, permissionID).Scan(&logged, &remaining)
+	if err != nil {
+		t.Fatalf("failed to check sweep results: %v", err)
+	}
+	if logged != 1 || remaining != 0 {
+		t.Errorf("SweepExpiredPermissions: expected the grant logged once and deleted, got logged=%d remaining=%d", logged, remaining)
+	}
+
+	removed, err = repo.SweepExpiredPermissions(ctx)
+	if err != nil {
+		t.Fatalf("second SweepExpiredPermissions failed: %v", err)
+	}
+	if len(removed) != 0 {
+		t.Errorf("second SweepExpiredPermissions: expected nothing to remove, got %d", len(removed))
+	}
+}
+
+func TestDBService_UserTokens(t *testing.T) {
+	db := setupMigratedTestDB(t)
+	addr, stop := startConfiguredTestGRPCServer(t, newTestServer(t, db))
+	defer stop()
+	client := getClient(t, addr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	aliceID := createTestUser(ctx, t, client, "alice")
+	createTestUser(ctx, t, client, "bob")
+	expiresAt := timestamppb.New(time.Now().Add(time.Hour))
+
+	verification, err := client.IssueToken(ctx, &protos.IssueTokenRequest{
+		UserId:    aliceID,
+		Purpose:   protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("IssueToken EMAIL_VERIFICATION failed: %v", err)
+	}
+
+	// Токен с другим назначением не находится
+	wrongPurpose, err := client.ConsumeToken(ctx, &protos.ConsumeTokenRequest{
+		Token:           verification.Token,
+		Purpose:         protos.UserTokenPurpose_USER_TOKEN_PURPOSE_PASSWORD_RESET,
+		NewPasswordHash: "new-hash",
+	})
+	if err != nil {
+		t.Fatalf("ConsumeToken with wrong purpose failed: %v", err)
+	}
+	if wrongPurpose.Status != protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_NOT_FOUND {
+		t.Errorf("ConsumeToken with wrong purpose: expected NOT_FOUND, got %v", wrongPurpose.Status)
+	}
+
+	consumed, err := client.ConsumeToken(ctx, &protos.ConsumeTokenRequest{
+		Token:   verification.Token,
+		Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION,
+	})
+	if err != nil {
+		t.Fatalf("ConsumeToken failed: %v", err)
+	}
+	if consumed.Status != protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_CONSUMED || consumed.UserId != aliceID {
+		t.Errorf("ConsumeToken: expected CONSUMED for %s, got %v for %s", aliceID, consumed.Status, consumed.UserId)
+	}
+	var verified bool
+	if err := db.QueryRow(`SELECT is_email_verified FROM homecloud.users WHERE id=$1`, aliceID).Scan(&verified); err != nil {
+		t.Fatalf("read is_email_verified failed: %v", err)
+	}
+	if !verified {
+		t.Errorf("ConsumeToken EMAIL_VERIFICATION: expected email to be verified")
+	}
+
+	again, err := client.ConsumeToken(ctx, &protos.ConsumeTokenRequest{
+		Token:   verification.Token,
+		Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION,
+	})
+	if err != nil {
+		t.Fatalf("ConsumeToken again failed: %v", err)
+	}
+	if again.Status != protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_ALREADY_USED {
+		t.Errorf("ConsumeToken again: expected ALREADY_USED, got %v", again.Status)
+	}
+
+	// Новый токен с тем же назначением отменяет предыдущий неиспользованный
+	first, err := client.IssueToken(ctx, &protos.IssueTokenRequest{
+		UserId:    aliceID,
+		Purpose:   protos.UserTokenPurpose_USER_TOKEN_PURPOSE_PASSWORD_RESET,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("IssueToken PASSWORD_RESET failed: %v", err)
+	}
+	second, err := client.IssueToken(ctx, &protos.IssueTokenRequest{
+		UserId:    aliceID,
+		Purpose:   protos.UserTokenPurpose_USER_TOKEN_PURPOSE_PASSWORD_RESET,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("IssueToken PASSWORD_RESET again failed: %v", err)
+	}
+	superseded, err := client.ConsumeToken(ctx, &protos.ConsumeTokenRequest{
+		Token:           first.Token,
+		Purpose:         protos.UserTokenPurpose_USER_TOKEN_PURPOSE_PASSWORD_RESET,
+		NewPasswordHash: "new-hash",
+	})
+	if err != nil {
+		t.Fatalf("ConsumeToken superseded failed: %v", err)
+	}
+	if superseded.Status != protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_NOT_FOUND {
+		t.Errorf("ConsumeToken superseded: expected NOT_FOUND, got %v", superseded.Status)
+	}
+
+	_, err = client.ConsumeToken(ctx, &protos.ConsumeTokenRequest{
+		Token:   second.Token,
+		Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_PASSWORD_RESET,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ConsumeToken PASSWORD_RESET without new_password_hash: expected InvalidArgument, got %v", err)
+	}
+
+	// Просроченный токен
+	if _, err := db.Exec(`UPDATE homecloud.user_tokens SET expires_at = NOW() - interval '1 minute' WHERE id=$1`, second.Id); err != nil {
+		t.Fatalf("expire token failed: %v", err)
+	}
+	expired, err := client.ConsumeToken(ctx, &protos.ConsumeTokenRequest{
+		Token:           second.Token,
+		Purpose:         protos.UserTokenPurpose_USER_TOKEN_PURPOSE_PASSWORD_RESET,
+		NewPasswordHash: "new-hash",
+	})
+	if err != nil {
+		t.Fatalf("ConsumeToken expired failed: %v", err)
+	}
+	if expired.Status != protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_EXPIRED {
+		t.Errorf("ConsumeToken expired: expected EXPIRED, got %v", expired.Status)
+	}
+
+	change, err := client.IssueToken(ctx, &protos.IssueTokenRequest{
+		UserId:    aliceID,
+		Purpose:   protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_CHANGE,
+		ExpiresAt: expiresAt,
+		NewEmail:  "Alice.New@Example.com",
+	})
+	if err != nil {
+		t.Fatalf("IssueToken EMAIL_CHANGE failed: %v", err)
+	}
+	changed, err := client.ConsumeToken(ctx, &protos.ConsumeTokenRequest{
+		Token:   change.Token,
+		Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_CHANGE,
+	})
+	if err != nil {
+		t.Fatalf("ConsumeToken EMAIL_CHANGE failed: %v", err)
+	}
+	if changed.Status != protos.ConsumeTokenStatus_CONSUME_TOKEN_STATUS_CONSUMED || changed.NewEmail != "alice.new@example.com" {
+		t.Errorf("ConsumeToken EMAIL_CHANGE: expected CONSUMED with alice.new@example.com, got %v with %q", changed.Status, changed.NewEmail)
+	}
+
+	errorCases := []struct {
+		name string
+		req  *protos.IssueTokenRequest
+		code codes.Code
+	}{
+		{"taken email", &protos.IssueTokenRequest{UserId: aliceID, Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_CHANGE, ExpiresAt: expiresAt, NewEmail: "BOB@example.com"}, codes.AlreadyExists},
+		{"new_email without EMAIL_CHANGE", &protos.IssueTokenRequest{UserId: aliceID, Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION, ExpiresAt: expiresAt, NewEmail: "x@example.com"}, codes.InvalidArgument},
+		{"past expires_at", &protos.IssueTokenRequest{UserId: aliceID, Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION, ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute))}, codes.InvalidArgument},
+		{"invalid user_id", &protos.IssueTokenRequest{UserId: "not-a-uuid", Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION, ExpiresAt: expiresAt}, codes.InvalidArgument},
+		{"unknown user", &protos.IssueTokenRequest{UserId: "00000000-0000-0000-0000-000000000000", Purpose: protos.UserTokenPurpose_USER_TOKEN_PURPOSE_EMAIL_VERIFICATION, ExpiresAt: expiresAt}, codes.NotFound},
+	}
+	for _, tc := range errorCases {
+		_, err := client.IssueToken(ctx, tc.req)
+		if status.Code(err) != tc.code {
+			t.Errorf("IssueToken %s: expected %v, got %v", tc.name, tc.code, err)
+		}
+	}
+}